@@ -1,16 +1,41 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/handlers"
 	"audio-series-app/backend/internal/middleware"
+	"audio-series-app/backend/internal/routes"
+	"audio-series-app/backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
+// Background job intervals
+const (
+	schedulerInterval      = time.Minute
+	transcodeInterval      = 10 * time.Second
+	rollupInterval         = 5 * time.Minute
+	rankingRefreshInterval = 10 * time.Minute
+	dripPlannerInterval    = 5 * time.Minute
+	notifierInterval       = time.Minute
+	renewalInterval        = time.Hour
+)
+
+// shutdownTimeout is how long in-flight requests get to finish once the server is stopping
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -25,8 +50,65 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Cancelled on SIGINT or SIGTERM, which stops the background jobs and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize services
+	blobStore, err := services.NewBlobStore(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize storage backend:", err)
+	}
+
+	supabaseService := services.NewSupabaseService(cfg)
+	storageService := services.NewStorageService(cfg, blobStore)
+	authService := services.NewAuthService(cfg, supabaseService)
+	userService := services.NewUserService(supabaseService)
+	seriesService := services.NewSeriesService(supabaseService)
+	catalogService := services.NewCatalogService(supabaseService)
+	paymentService := services.NewPaymentService(cfg, supabaseService)
+	subscriptionService := services.NewSubscriptionService(cfg, supabaseService, paymentService)
+	episodeService := services.NewEpisodeService(supabaseService, subscriptionService, storageService)
+	coinService := services.NewCoinService(supabaseService)
+	packagingService := services.NewPackagingService(cfg, supabaseService, storageService)
+	transcodeService := services.NewTranscodeService(cfg, supabaseService, storageService, packagingService)
+	uploadService := services.NewUploadService(cfg, supabaseService, storageService, transcodeService)
+	coverService := services.NewCoverService(cfg, supabaseService, storageService)
+	statsService := services.NewStatsService(supabaseService)
+	auditService := services.NewAuditService(supabaseService)
+	publishingService := services.NewPublishingService(supabaseService)
+	dripService := services.NewDripService(supabaseService)
+	progressService := services.NewProgressService(supabaseService)
+	analyticsService := services.NewAnalyticsService(supabaseService)
+	searchService := services.NewSearchService(supabaseService)
+	categoryService := services.NewCategoryService(supabaseService)
+	creatorService := services.NewCreatorService(supabaseService)
+	translationService := services.NewTranslationService(supabaseService)
+	libraryService := services.NewLibraryService(supabaseService)
+	reviewService := services.NewReviewService(cfg, supabaseService, subscriptionService)
+	commentService := services.NewCommentService(cfg, supabaseService, episodeService)
+
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(authService)
+	userHandler := handlers.NewUserHandler(userService)
+	seriesHandler := handlers.NewSeriesHandler(seriesService, catalogService)
+	episodeHandler := handlers.NewEpisodeHandler(episodeService, coinService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, coinService, subscriptionService)
+	adminHandler := handlers.NewAdminHandler(seriesService, episodeService, userService, packagingService, uploadService, transcodeService, coverService, statsService, auditService, dripService)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
+	progressHandler := handlers.NewProgressHandler(progressService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	creatorHandler := handlers.NewCreatorHandler(creatorService)
+	translationHandler := handlers.NewTranslationHandler(translationService)
+	libraryHandler := handlers.NewLibraryHandler(libraryService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+
 	// Initialize middleware
 	corsMiddleware := middleware.NewCorsMiddleware(cfg)
+	authMiddleware := middleware.NewAuthMiddleware(authService)
 
 	// Create router
 	router := gin.Default()
@@ -52,17 +134,84 @@ func main() {
 		})
 	})
 
+	// Public buckets on local storage are served from disk; audio stays behind the stream proxy
+	if cfg.StorageBackend == "local" {
+		for _, bucket := range []string{cfg.CoverBucketName, cfg.HLSBucketName} {
+			router.Static("/api/v1/storage/"+bucket, filepath.Join(cfg.LocalStorageDir, bucket))
+		}
+	}
+
+	// API routes
+	routes.SetupRoutes(
+		router,
+		authHandler,
+		userHandler,
+		seriesHandler,
+		episodeHandler,
+		paymentHandler,
+		adminHandler,
+		subscriptionHandler,
+		progressHandler,
+		analyticsHandler,
+		searchHandler,
+		categoryHandler,
+		creatorHandler,
+		translationHandler,
+		libraryHandler,
+		reviewHandler,
+		commentHandler,
+		authMiddleware,
+	)
+
+	// Start background jobs; each returns once ctx is cancelled
+	var workers sync.WaitGroup
+	for _, run := range []func(){
+		func() { publishingService.RunScheduler(ctx, schedulerInterval) },
+		func() { transcodeService.RunWorker(ctx, transcodeInterval) },
+		func() { analyticsService.RunRollup(ctx, rollupInterval) },
+		func() { catalogService.RunRankingRefresh(ctx, rankingRefreshInterval) },
+		func() { dripService.RunPlanner(ctx, dripPlannerInterval) },
+		func() { libraryService.RunNotifier(ctx, notifierInterval) },
+		func() { subscriptionService.RunRenewals(ctx, renewalInterval) },
+	} {
+		workers.Add(1)
+		go func(run func()) {
+			defer workers.Done()
+			run()
+		}(run)
+	}
+
 	// Start server
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "3003"
 	}
 
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+
 	log.Printf("🚀 Server starting on port %s", port)
 	log.Printf("📊 Environment: %s", cfg.Environment)
 	log.Printf("🔗 Supabase URL: %s", cfg.SupabaseURL)
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server gracefully: %v", err)
 	}
+
+	// Running jobs see the cancelled context and stop after their current step
+	workers.Wait()
+	log.Println("Server stopped")
 }
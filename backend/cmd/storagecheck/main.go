@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+// storagecheck lists the objects in a bucket of the configured storage backend,
+// to confirm credentials and bucket names before running the server
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	cfg := config.Load()
+
+	bucket := flag.String("bucket", cfg.AudioBucketName, "bucket to list")
+	prefix := flag.String("prefix", "", "only list keys starting with this prefix")
+	flag.Parse()
+
+	store, err := services.NewBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create storage backend: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	objects, err := store.List(ctx, *bucket, *prefix)
+	if err != nil {
+		log.Fatalf("Failed to list %s: %v", *bucket, err)
+	}
+
+	fmt.Printf("%d objects in %s (%s backend)\n", len(objects), *bucket, cfg.StorageBackend)
+	for _, object := range objects {
+		fmt.Printf("%10d  %s  %s\n", object.Size, object.LastModified.Format(time.RFC3339), object.Key)
+	}
+}
@@ -21,18 +21,57 @@ type Config struct {
 	JWTExpiry string
 
 	// Payment Gateway Configuration
-	RazorpayKeyID     string
-	RazorpayKeySecret string
-	PaystackSecretKey string
-	PaystackPublicKey string
+	RazorpayKeyID         string
+	RazorpayKeySecret     string
+	RazorpayWebhookSecret string
+	PaystackSecretKey     string
+	PaystackPublicKey     string
 
 	// Coin System Configuration
 	WelcomeCoins        int
 	MinCoinsForPurchase int
 
+	// Subscription Configuration
+	SubscriptionGraceDays int
+
 	// Audio Storage Configuration
+	StorageBackend   string // supabase, s3, local
+	LocalStorageDir  string
 	AudioBucketName  string
 	MaxAudioFileSize string
+	AudioURLExpiry   string
+	FFprobePath      string
+
+	// S3-Compatible Storage Configuration
+	S3Endpoint        string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3ForcePathStyle  bool
+	S3PublicURL       string
+
+	// Cover Image Configuration
+	CoverBucketName  string
+	MaxCoverFileSize string
+
+	// HLS Packaging Configuration
+	FFmpegPath    string
+	HLSBucketName string
+	HLSBitrates   []string
+	PublicAPIURL  string
+
+	// Transcoding Configuration
+	LoudnessTarget       string // integrated loudness in LUFS
+	LoudnessTruePeak     string // in dBTP
+	AACBitrate           string
+	OpusBitrate          string
+	TranscodeMaxAttempts int
+	WaveformPeaks        int
+
+	// Comment Configuration
+	BlockedWords      []string // added to the built-in profanity list
+	CommentsPerMinute int
+	CommentsPerHour   int
 
 	// CORS Configuration
 	AllowedOrigins []string
@@ -40,22 +79,49 @@ type Config struct {
 
 func Load() *Config {
 	return &Config{
-		Environment:         getEnv("ENV", "development"),
-		Port:                getEnv("PORT", "3003"),
-		SupabaseURL:         getEnv("SUPABASE_URL", ""),
-		SupabaseAnonKey:     getEnv("SUPABASE_ANON_KEY", ""),
-		SupabaseServiceKey:  getEnv("SUPABASE_SERVICE_ROLE_KEY", ""),
-		JWTSecret:           getEnv("JWT_SECRET", "your-secret-key"),
-		JWTExpiry:           getEnv("JWT_EXPIRY", "24h"),
-		RazorpayKeyID:       getEnv("RAZORPAY_KEY_ID", ""),
-		RazorpayKeySecret:   getEnv("RAZORPAY_KEY_SECRET", ""),
-		PaystackSecretKey:   getEnv("PAYSTACK_SECRET_KEY", ""),
-		PaystackPublicKey:   getEnv("PAYSTACK_PUBLIC_KEY", ""),
-		WelcomeCoins:        getEnvAsInt("WELCOME_COINS", 50),
-		MinCoinsForPurchase: getEnvAsInt("MIN_COINS_FOR_PURCHASE", 10),
-		AudioBucketName:     getEnv("AUDIO_BUCKET_NAME", "audio-episodes"),
-		MaxAudioFileSize:    getEnv("MAX_AUDIO_FILE_SIZE", "100MB"),
-		AllowedOrigins:      getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3004", "http://localhost:3003"}),
+		Environment:           getEnv("ENV", "development"),
+		Port:                  getEnv("PORT", "3003"),
+		SupabaseURL:           getEnv("SUPABASE_URL", ""),
+		SupabaseAnonKey:       getEnv("SUPABASE_ANON_KEY", ""),
+		SupabaseServiceKey:    getEnv("SUPABASE_SERVICE_ROLE_KEY", ""),
+		JWTSecret:             getEnv("JWT_SECRET", "your-secret-key"),
+		JWTExpiry:             getEnv("JWT_EXPIRY", "24h"),
+		RazorpayKeyID:         getEnv("RAZORPAY_KEY_ID", ""),
+		RazorpayKeySecret:     getEnv("RAZORPAY_KEY_SECRET", ""),
+		RazorpayWebhookSecret: getEnv("RAZORPAY_WEBHOOK_SECRET", ""),
+		PaystackSecretKey:     getEnv("PAYSTACK_SECRET_KEY", ""),
+		PaystackPublicKey:     getEnv("PAYSTACK_PUBLIC_KEY", ""),
+		WelcomeCoins:          getEnvAsInt("WELCOME_COINS", 50),
+		MinCoinsForPurchase:   getEnvAsInt("MIN_COINS_FOR_PURCHASE", 10),
+		SubscriptionGraceDays: getEnvAsInt("SUBSCRIPTION_GRACE_DAYS", 3),
+		StorageBackend:        getEnv("STORAGE_BACKEND", "supabase"),
+		LocalStorageDir:       getEnv("LOCAL_STORAGE_DIR", "./storage"),
+		AudioBucketName:       getEnv("AUDIO_BUCKET_NAME", "audio-episodes"),
+		MaxAudioFileSize:      getEnv("MAX_AUDIO_FILE_SIZE", "100MB"),
+		AudioURLExpiry:        getEnv("AUDIO_URL_EXPIRY", "15m"),
+		FFprobePath:           getEnv("FFPROBE_PATH", "ffprobe"),
+		S3Endpoint:            getEnv("S3_ENDPOINT", ""),
+		S3Region:              getEnv("S3_REGION", "us-east-1"),
+		S3AccessKeyID:         getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:     getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3ForcePathStyle:      getEnvAsBool("S3_FORCE_PATH_STYLE", true),
+		S3PublicURL:           getEnv("S3_PUBLIC_URL", ""),
+		CoverBucketName:       getEnv("COVER_BUCKET_NAME", "series-covers"),
+		MaxCoverFileSize:      getEnv("MAX_COVER_FILE_SIZE", "10MB"),
+		FFmpegPath:            getEnv("FFMPEG_PATH", "ffmpeg"),
+		HLSBucketName:         getEnv("HLS_BUCKET_NAME", "audio-hls"),
+		HLSBitrates:           getEnvAsSlice("HLS_BITRATES", []string{"48k", "96k", "160k"}),
+		PublicAPIURL:          getEnv("PUBLIC_API_URL", "http://localhost:3003/api/v1"),
+		LoudnessTarget:        getEnv("LOUDNESS_TARGET", "-16"),
+		LoudnessTruePeak:      getEnv("LOUDNESS_TRUE_PEAK", "-1.5"),
+		AACBitrate:            getEnv("AAC_BITRATE", "128k"),
+		OpusBitrate:           getEnv("OPUS_BITRATE", "64k"),
+		TranscodeMaxAttempts:  getEnvAsInt("TRANSCODE_MAX_ATTEMPTS", 3),
+		WaveformPeaks:         getEnvAsInt("WAVEFORM_PEAKS", 800),
+		BlockedWords:          getEnvAsSlice("BLOCKED_WORDS", nil),
+		CommentsPerMinute:     getEnvAsInt("COMMENTS_PER_MINUTE", 5),
+		CommentsPerHour:       getEnvAsInt("COMMENTS_PER_HOUR", 60),
+		AllowedOrigins:        getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3004", "http://localhost:3003"}),
 	}
 }
 
@@ -103,6 +169,60 @@ func (c *Config) GetSupabaseProjectRef() string {
 	return ""
 }
 
+// GetMaxAudioFileSize returns MaxAudioFileSize in bytes, falling back to 100MB
+func (c *Config) GetMaxAudioFileSize() int64 {
+	return parseFileSize(c.MaxAudioFileSize, 100<<20)
+}
+
+// GetMaxCoverFileSize returns MaxCoverFileSize in bytes, falling back to 10MB
+func (c *Config) GetMaxCoverFileSize() int64 {
+	return parseFileSize(c.MaxCoverFileSize, 10<<20)
+}
+
+// GetStorageURL returns the Supabase Storage API base URL
+func (c *Config) GetStorageURL() string {
+	if storageURL := getEnv("SUPABASE_STORAGE_URL", ""); storageURL != "" {
+		return strings.TrimSuffix(storageURL, "/")
+	}
+
+	if strings.HasPrefix(c.SupabaseURL, "https://") {
+		return strings.TrimSuffix(c.SupabaseURL, "/") + "/storage/v1"
+	}
+
+	// Direct database URLs look like postgresql://postgres:pw@db.<ref>.supabase.co:5432/postgres
+	if c.IsDirectDatabaseURL() {
+		if at := strings.LastIndex(c.SupabaseURL, "@db."); at != -1 {
+			host := c.SupabaseURL[at+len("@db."):]
+			projectRef := strings.Split(host, ".")[0]
+			return fmt.Sprintf("https://%s.supabase.co/storage/v1", projectRef)
+		}
+	}
+
+	return ""
+}
+
+// parseFileSize accepts plain byte counts or KB/MB/GB suffixes (binary multiples)
+func parseFileSize(value string, defaultSize int64) int64 {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			multiplier = unit.size
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		return defaultSize
+	}
+	return size * multiplier
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -119,6 +239,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		return strings.Split(value, ",")
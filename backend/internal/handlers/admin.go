@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"audio-series-app/backend/internal/models"
 	"audio-series-app/backend/internal/services"
@@ -11,16 +14,30 @@ import (
 )
 
 type AdminHandler struct {
-	seriesService  *services.SeriesService
-	episodeService *services.EpisodeService
-	userService    *services.UserService
+	seriesService    *services.SeriesService
+	episodeService   *services.EpisodeService
+	userService      *services.UserService
+	packagingService *services.PackagingService
+	uploadService    *services.UploadService
+	transcodeService *services.TranscodeService
+	coverService     *services.CoverService
+	statsService     *services.StatsService
+	auditService     *services.AuditService
+	dripService      *services.DripService
 }
 
-func NewAdminHandler(seriesService *services.SeriesService, episodeService *services.EpisodeService, userService *services.UserService) *AdminHandler {
+func NewAdminHandler(seriesService *services.SeriesService, episodeService *services.EpisodeService, userService *services.UserService, packagingService *services.PackagingService, uploadService *services.UploadService, transcodeService *services.TranscodeService, coverService *services.CoverService, statsService *services.StatsService, auditService *services.AuditService, dripService *services.DripService) *AdminHandler {
 	return &AdminHandler{
-		seriesService:  seriesService,
-		episodeService: episodeService,
-		userService:    userService,
+		seriesService:    seriesService,
+		episodeService:   episodeService,
+		userService:      userService,
+		packagingService: packagingService,
+		uploadService:    uploadService,
+		transcodeService: transcodeService,
+		coverService:     coverService,
+		statsService:     statsService,
+		auditService:     auditService,
+		dripService:      dripService,
 	}
 }
 
@@ -55,7 +72,11 @@ func (h *AdminHandler) CreateSeries(c *gin.Context) {
 	series.CreatedBy = userUUID
 
 	err = h.seriesService.CreateSeries(c.Request.Context(), &series)
-	if err != nil {
+	switch {
+	case errors.Is(err, services.ErrInvalidPublication):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create series"})
 		return
 	}
@@ -63,6 +84,340 @@ func (h *AdminHandler) CreateSeries(c *gin.Context) {
 	c.JSON(http.StatusCreated, series)
 }
 
+// ListSeries returns every series, drafts included, optionally filtered by status (admin only)
+func (h *AdminHandler) ListSeries(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", "draft", "scheduled", "published", "archived":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	series, err := h.seriesService.GetAllSeries(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": series})
+}
+
+// PreviewSeries returns a series with all its episodes, drafts and scheduled ones included (admin only)
+func (h *AdminHandler) PreviewSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	preview, err := h.seriesService.PreviewSeries(c.Request.Context(), seriesID)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// SetSeriesPublication drafts, schedules, publishes or archives a series (admin only)
+func (h *AdminHandler) SetSeriesPublication(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.PublicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	series, err := h.seriesService.SetPublication(c.Request.Context(), actorID, seriesID, &req)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidPublication):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// UpdateSeries edits a series' details (admin only). PATCH changes only the fields sent;
+// PUT must send them all.
+func (h *AdminHandler) UpdateSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SeriesUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+	if c.Request.Method == http.MethodPut &&
+		(req.Title == nil || req.Description == nil || req.Author == nil || req.Category == nil || req.IsPremium == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "PUT requires title, description, author, category and is_premium"})
+		return
+	}
+
+	series, err := h.seriesService.UpdateSeries(c.Request.Context(), actorID, seriesID, &req)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries soft-deletes a series (admin only)
+func (h *AdminHandler) DeleteSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.seriesService.DeleteSeries(c.Request.Context(), actorID, seriesID)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete series"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderEpisodes renumbers every episode of a series in the order given (admin only)
+func (h *AdminHandler) ReorderEpisodes(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderEpisodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	episodes, err := h.episodeService.ReorderEpisodes(c.Request.Context(), actorID, seriesID, req.EpisodeIDs)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidEpisodeOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder episodes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": episodes})
+}
+
+// GetDripSchedule returns a series' weekly drip release schedule (admin only)
+func (h *AdminHandler) GetDripSchedule(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	schedule, err := h.dripService.GetSchedule(c.Request.Context(), seriesID)
+	switch {
+	case errors.Is(err, services.ErrDripScheduleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Drip schedule not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get drip schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// SetDripSchedule creates or replaces a series' drip schedule and schedules its draft
+// episodes on it (admin only)
+func (h *AdminHandler) SetDripSchedule(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.DripScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	schedule, err := h.dripService.SetSchedule(c.Request.Context(), actorID, seriesID, &req)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidDripSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save drip schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteDripSchedule stops a series' drip releases (admin only). Episodes it had
+// scheduled but not yet released go back to draft.
+func (h *AdminHandler) DeleteDripSchedule(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	err = h.dripService.DeleteSchedule(c.Request.Context(), seriesID)
+	switch {
+	case errors.Is(err, services.ErrDripScheduleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Drip schedule not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete drip schedule"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetEpisodeDripQueue adds a draft episode to its series' drip queue or takes it out (admin only)
+func (h *AdminHandler) SetEpisodeDripQueue(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	var req models.DripQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	err = h.dripService.SetEpisodeQueued(c.Request.Context(), episodeID, *req.Queued)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrEpisodeNotDraft):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update drip queue"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadSeriesCover replaces a series' cover art with an uploaded image (admin only).
+// The image is resized into thumbnail, card and hero variants in WebP and JPEG.
+func (h *AdminHandler) UploadSeriesCover(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	maxSize := h.coverService.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Image file is required"})
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image file too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image file"})
+		return
+	}
+	defer file.Close()
+
+	series, err := h.coverService.UploadSeriesCover(c.Request.Context(), seriesID, file)
+	switch {
+	case errors.Is(err, services.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image file too large"})
+		return
+	case errors.Is(err, services.ErrUnsupportedImageType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update cover image"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
 // CreateEpisode creates a new episode (admin only)
 func (h *AdminHandler) CreateEpisode(c *gin.Context) {
 	var episode models.Episode
@@ -72,7 +427,243 @@ func (h *AdminHandler) CreateEpisode(c *gin.Context) {
 	}
 
 	err := h.episodeService.CreateEpisode(c.Request.Context(), &episode)
+	switch {
+	case errors.Is(err, services.ErrInvalidPublication):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrInvalidAudioLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create episode"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, episode)
+}
+
+// UpdateEpisode edits an episode's details (admin only). PATCH changes only the fields sent;
+// PUT must send them all.
+func (h *AdminHandler) UpdateEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.EpisodeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+	if c.Request.Method == http.MethodPut &&
+		(req.Title == nil || req.Description == nil || req.EpisodeNumber == nil || req.CoinPrice == nil || req.IsLocked == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "PUT requires title, description, episode_number, coin_price and is_locked"})
+		return
+	}
+
+	episode, err := h.episodeService.UpdateEpisode(c.Request.Context(), actorID, episodeID, &req)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrEpisodeNumberTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrInvalidAudioLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update episode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, episode)
+}
+
+// SetEpisodePublication drafts, schedules, publishes or archives an episode (admin only)
+func (h *AdminHandler) SetEpisodePublication(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.PublicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	episode, err := h.episodeService.SetPublication(c.Request.Context(), actorID, episodeID, &req)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrInvalidPublication):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update episode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, episode)
+}
+
+// SetEpisodeEarlyAccess sells an episode with coins until it becomes free for everyone (admin only)
+func (h *AdminHandler) SetEpisodeEarlyAccess(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.EarlyAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	episode, err := h.episodeService.SetEarlyAccess(c.Request.Context(), actorID, episodeID, req.FreeAt)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrInvalidEarlyAccess):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update episode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, episode)
+}
+
+// DeleteEpisode soft-deletes an episode (admin only)
+func (h *AdminHandler) DeleteEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.episodeService.DeleteEpisode(c.Request.Context(), actorID, episodeID)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete episode"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadEpisode creates an episode from an uploaded audio file (admin only).
+// Duration, codec and bitrate are probed from the file rather than entered by hand.
+func (h *AdminHandler) UploadEpisode(c *gin.Context) {
+	// Leave room for the other form fields on top of the audio itself
+	maxSize := h.uploadService.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audio file is required"})
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+		return
+	}
+
+	seriesID, err := uuid.Parse(c.PostForm("series_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	episodeNumber, err := strconv.Atoi(c.PostForm("episode_number"))
+	if err != nil || episodeNumber <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode number"})
+		return
+	}
+
+	coinPrice, err := strconv.Atoi(c.DefaultPostForm("coin_price", "0"))
+	if err != nil || coinPrice < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid coin price"})
+		return
+	}
+
+	isLocked, err := strconv.ParseBool(c.DefaultPostForm("is_locked", "true"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid is_locked value"})
+		return
+	}
+
+	title := c.PostForm("title")
+	if title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read audio file"})
+		return
+	}
+	defer file.Close()
+
+	episode := models.Episode{
+		SeriesID:      seriesID,
+		Title:         title,
+		Description:   c.PostForm("description"),
+		EpisodeNumber: episodeNumber,
+		CoinPrice:     coinPrice,
+		IsLocked:      isLocked,
+		AudioLanguage: c.PostForm("audio_language"),
+	}
+
+	err = h.uploadService.CreateEpisodeFromUpload(c.Request.Context(), &episode, file, fileHeader.Filename)
+	switch {
+	case errors.Is(err, services.ErrInvalidAudioLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrAudioTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+		return
+	case errors.Is(err, services.ErrUnsupportedAudioType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	case err != nil:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create episode"})
 		return
 	}
@@ -80,18 +671,369 @@ func (h *AdminHandler) CreateEpisode(c *gin.Context) {
 	c.JSON(http.StatusCreated, episode)
 }
 
-// GetAdminStats returns admin dashboard statistics
+// UploadAudioTrack adds an uploaded audio file as the episode's track in another language,
+// replacing any it already has in that language (admin only)
+func (h *AdminHandler) UploadAudioTrack(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	// Leave room for the other form fields on top of the audio itself
+	maxSize := h.uploadService.MaxUploadSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audio file is required"})
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+		return
+	}
+
+	language := c.PostForm("language")
+	if language == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Language is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read audio file"})
+		return
+	}
+	defer file.Close()
+
+	track, err := h.uploadService.AddAudioTrack(c.Request.Context(), actorID, episodeID, language, file, fileHeader.Filename)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrInvalidAudioTrack):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrAudioTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Audio file too large"})
+		return
+	case errors.Is(err, services.ErrUnsupportedAudioType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add audio track"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, track)
+}
+
+// DeleteAudioTrack removes the episode's audio track in a language (admin only)
+func (h *AdminHandler) DeleteAudioTrack(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.episodeService.DeleteAudioTrack(c.Request.Context(), actorID, episodeID, c.Param("lang"))
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrAudioTrackNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio track not found"})
+		return
+	case errors.Is(err, services.ErrInvalidAudioTrack):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete audio track"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PackageEpisode starts encrypted HLS packaging for an episode (admin only)
+func (h *AdminHandler) PackageEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	pkg, err := h.packagingService.QueuePackaging(c.Request.Context(), episodeID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, pkg)
+}
+
+// GetEpisodePackage returns the HLS packaging status of an episode (admin only)
+func (h *AdminHandler) GetEpisodePackage(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	pkg, err := h.packagingService.GetPackage(c.Request.Context(), episodeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get episode package"})
+		return
+	}
+
+	if pkg == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode has not been packaged"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg)
+}
+
+// ReplaceChapters sets an episode's chapter markers, replacing any existing ones (admin only)
+func (h *AdminHandler) ReplaceChapters(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	var req models.ChaptersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	chapters, err := h.episodeService.ReplaceChapters(c.Request.Context(), episodeID, req.Chapters)
+	switch {
+	case errors.Is(err, services.ErrInvalidChapters):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"chapters": chapters})
+}
+
+// TranscodeEpisode queues loudness normalization and transcoding for an episode (admin only)
+func (h *AdminHandler) TranscodeEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	job, err := h.transcodeService.EnqueueEpisode(c.Request.Context(), episodeID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetTranscodeJobs lists transcode jobs, newest first, optionally filtered by status (admin only)
+func (h *AdminHandler) GetTranscodeJobs(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", "queued", "running", "completed", "failed":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	jobs, err := h.transcodeService.GetJobs(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get transcode jobs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// GetTranscodeJob returns a single transcode job, including its last error (admin only)
+func (h *AdminHandler) GetTranscodeJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.transcodeService.GetJob(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transcode job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryTranscodeJob puts a failed transcode job back on the queue (admin only)
+func (h *AdminHandler) RetryTranscodeJob(c *gin.Context) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.transcodeService.RetryJob(c.Request.Context(), jobID)
+	switch {
+	case errors.Is(err, services.ErrTranscodeJobNotFailed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transcode job not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetAdminStats returns admin dashboard statistics for a date range
 func (h *AdminHandler) GetAdminStats(c *gin.Context) {
-	// This would typically get stats from the database
-	// For now, we'll return mock data
-	stats := models.AdminStats{
-		TotalUsers:     100,
-		TotalSeries:    5,
-		TotalEpisodes:  25,
-		TotalRevenue:   50000,
-		MonthlyRevenue: 10000,
-		ActiveUsers:    75,
+	to := time.Now()
+	if value := c.Query("to"); value != "" {
+		parsed, err := parseStatsTime(value, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if value := c.Query("from"); value != "" {
+		parsed, err := parseStatsTime(value, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+		from = parsed
+	}
+
+	stats, err := h.statsService.GetDashboardStats(c.Request.Context(), from, to, c.DefaultQuery("interval", "day"))
+	switch {
+	case errors.Is(err, services.ErrInvalidStatsRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get stats"})
+		return
 	}
 
 	c.JSON(http.StatusOK, stats)
 }
+
+// GetAuditLog lists admin changes to series and episodes, newest first (admin only)
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	entityType := c.Query("entity_type")
+	if entityType != "" && entityType != "series" && entityType != "episode" && entityType != "category" && entityType != "creator" && entityType != "review" && entityType != "comment" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity type"})
+		return
+	}
+
+	var entityID *uuid.UUID
+	if value := c.Query("entity_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+			return
+		}
+		entityID = &parsed
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	entries, err := h.auditService.GetAuditLog(c.Request.Context(), entityType, entityID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// currentUserID returns the authenticated user's ID, responding with an error if it is missing
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, false
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// parseStatsTime accepts RFC 3339 timestamps or YYYY-MM-DD dates in UTC. A date used as
+// the end of a range includes that whole day.
+func parseStatsTime(value string, endOfRange bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
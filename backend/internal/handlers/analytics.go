@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// RecordEvents stores a batch of listening events sent by the current user's player
+func (h *AnalyticsHandler) RecordEvents(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req models.EventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	accepted, err := h.analyticsService.RecordEvents(c.Request.Context(), userIDStr, req.Events)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record events"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"accepted": accepted})
+}
+
+// GetSeriesAnalytics returns listening metrics for every episode of a series (admin only)
+func (h *AnalyticsHandler) GetSeriesAnalytics(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetSeriesAnalytics(c.Request.Context(), seriesID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": analytics})
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategoryHandler struct {
+	categoryService *services.CategoryService
+}
+
+func NewCategoryHandler(categoryService *services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+// GetCategories returns the genre and tag taxonomy with series counts
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	kind := c.Query("kind")
+	if kind != "" && kind != "genre" && kind != "tag" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid kind"})
+		return
+	}
+
+	categories, err := h.categoryService.GetCategories(c.Request.Context(), kind, preferredLanguages(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// CreateCategory adds a genre, sub-genre or tag to the taxonomy (admin only)
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Request.Context(), actorID, &req)
+	switch {
+	case errors.Is(err, services.ErrInvalidCategory):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCategorySlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create category"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory replaces a category's slug, names, kind, parent and sort order (admin only)
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Request.Context(), actorID, categoryID, &req)
+	switch {
+	case errors.Is(err, services.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	case errors.Is(err, services.ErrInvalidCategory):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCategorySlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory removes a category and untags its series (admin only)
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.categoryService.DeleteCategory(c.Request.Context(), actorID, categoryID)
+	switch {
+	case errors.Is(err, services.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	case errors.Is(err, services.ErrCategoryHasChildren):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetSeriesCategories replaces a series' genres and tags (admin only)
+func (h *CategoryHandler) SetSeriesCategories(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SeriesCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	categories, err := h.categoryService.SetSeriesCategories(c.Request.Context(), actorID, seriesID, req.CategoryIDs)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidCategory):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CommentHandler struct {
+	commentService *services.CommentService
+}
+
+func NewCommentHandler(commentService *services.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// GetEpisodeComments returns a page of an episode's comments with their first replies
+func (h *CommentHandler) GetEpisodeComments(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sort := c.DefaultQuery("sort", "newest")
+	if sort != "newest" && sort != "oldest" && sort != "top" && sort != "position" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	comments, err := h.commentService.GetEpisodeComments(c.Request.Context(), userID, episodeID, sort, limit, offset)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to see its comments"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get comments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// AddComment comments on an episode as the current user
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	comment, err := h.commentService.AddComment(c.Request.Context(), userID, episodeID, &req)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to comment on it"})
+		return
+	case errors.Is(err, services.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrBlockedContent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Comment contains language that is not allowed"})
+		return
+	case errors.Is(err, services.ErrCommentRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add comment"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetReplies returns a page of the replies to a comment
+func (h *CommentHandler) GetReplies(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	replies, err := h.commentService.GetReplies(c.Request.Context(), userID, commentID, limit, offset)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to see its comments"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get replies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replies": replies})
+}
+
+// ReplyToComment replies to a comment as the current user
+func (h *CommentHandler) ReplyToComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CommentReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	reply, err := h.commentService.Reply(c.Request.Context(), userID, commentID, &req)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to comment on it"})
+		return
+	case errors.Is(err, services.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrBlockedContent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reply contains language that is not allowed"})
+		return
+	case errors.Is(err, services.ErrCommentRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reply to comment"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reply)
+}
+
+// DeleteComment removes one of the current user's comments, keeping a tombstone for its replies
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.commentService.DeleteComment(c.Request.Context(), userID, commentID)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LikeComment adds the current user's like to a comment
+func (h *CommentHandler) LikeComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.commentService.LikeComment(c.Request.Context(), userID, commentID)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to see its comments"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like comment"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnlikeComment removes the current user's like from a comment
+func (h *CommentHandler) UnlikeComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.commentService.UnlikeComment(c.Request.Context(), userID, commentID)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to see its comments"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlike comment"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReportComment reports another listener's comment as abusive
+func (h *CommentHandler) ReportComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	err = h.commentService.ReportComment(c.Request.Context(), userID, commentID, &req)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound), errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case errors.Is(err, services.ErrCommentsLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unlock the episode to see its comments"})
+		return
+	case errors.Is(err, services.ErrInvalidCommentReport):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to report comment"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetModerationQueue lists comments waiting for moderation, or comments with a status (admin only)
+func (h *CommentHandler) GetModerationQueue(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && status != "visible" && status != "flagged" && status != "hidden" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	comments, err := h.commentService.GetModerationQueue(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get comments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// GetCommentReports lists the reports made against a comment (admin only)
+func (h *CommentHandler) GetCommentReports(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	reports, err := h.commentService.GetCommentReports(c.Request.Context(), commentID)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get comment reports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports})
+}
+
+// ModerateComment shows, flags or hides a comment (admin only)
+func (h *CommentHandler) ModerateComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	comment, err := h.commentService.ModerateComment(c.Request.Context(), actorID, commentID, req.Status)
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate comment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CreatorHandler struct {
+	creatorService *services.CreatorService
+}
+
+func NewCreatorHandler(creatorService *services.CreatorService) *CreatorHandler {
+	return &CreatorHandler{
+		creatorService: creatorService,
+	}
+}
+
+// GetCreator returns a creator's profile
+func (h *CreatorHandler) GetCreator(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid creator ID"})
+		return
+	}
+
+	creator, err := h.creatorService.GetCreator(c.Request.Context(), creatorID)
+	switch {
+	case errors.Is(err, services.ErrCreatorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Creator not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get creator"})
+		return
+	}
+
+	c.JSON(http.StatusOK, creator)
+}
+
+// GetCreatorWorks returns the series and episodes a creator is credited on
+func (h *CreatorHandler) GetCreatorWorks(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid creator ID"})
+		return
+	}
+
+	works, err := h.creatorService.GetCreatorWorks(c.Request.Context(), creatorID)
+	switch {
+	case errors.Is(err, services.ErrCreatorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Creator not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get creator works"})
+		return
+	}
+
+	c.JSON(http.StatusOK, works)
+}
+
+// FollowCreator adds the creator's new releases to the current user's feed
+func (h *CreatorHandler) FollowCreator(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid creator ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.creatorService.FollowCreator(c.Request.Context(), userID, creatorID)
+	switch {
+	case errors.Is(err, services.ErrCreatorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Creator not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow creator"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowCreator removes the creator from the current user's follows
+func (h *CreatorHandler) UnfollowCreator(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid creator ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.creatorService.UnfollowCreator(c.Request.Context(), userID, creatorID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow creator"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFollowing returns the creators the current user follows
+func (h *CreatorHandler) GetFollowing(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	creators, err := h.creatorService.GetFollowedCreators(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get followed creators"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"creators": creators})
+}
+
+// GetFeed returns the newest releases from creators the current user follows
+func (h *CreatorHandler) GetFeed(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	items, err := h.creatorService.GetFeed(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get feed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// CreateCreator adds an author, narrator or producer (admin only)
+func (h *CreatorHandler) CreateCreator(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	creator, err := h.creatorService.CreateCreator(c.Request.Context(), actorID, &req)
+	switch {
+	case errors.Is(err, services.ErrInvalidCreator):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCreatorSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create creator"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, creator)
+}
+
+// UpdateCreator replaces a creator's slug, name, bio and avatar (admin only)
+func (h *CreatorHandler) UpdateCreator(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid creator ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	creator, err := h.creatorService.UpdateCreator(c.Request.Context(), actorID, creatorID, &req)
+	switch {
+	case errors.Is(err, services.ErrCreatorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Creator not found"})
+		return
+	case errors.Is(err, services.ErrInvalidCreator):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCreatorSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update creator"})
+		return
+	}
+
+	c.JSON(http.StatusOK, creator)
+}
+
+// SetSeriesCredits replaces who is credited on a series (admin only)
+func (h *CreatorHandler) SetSeriesCredits(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	credits, err := h.creatorService.SetSeriesCredits(c.Request.Context(), actorID, seriesID, req.Credits)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidCreator):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update series credits"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credits": credits})
+}
+
+// SetEpisodeCredits replaces who is credited on an episode (admin only)
+func (h *CreatorHandler) SetEpisodeCredits(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	credits, err := h.creatorService.SetEpisodeCredits(c.Request.Context(), actorID, episodeID, req.Credits)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrInvalidCreator):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update episode credits"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credits": credits})
+}
@@ -1,7 +1,15 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"audio-series-app/backend/internal/services"
 
@@ -36,11 +44,14 @@ func (h *EpisodeHandler) GetEpisode(c *gin.Context) {
 	}
 
 	// Parse UUIDs (simplified for now)
-	episodeWithPurchase, err := h.episodeService.GetEpisodeWithPurchaseStatus(c.Request.Context(), episodeIDStr, userIDStr)
+	episodeWithPurchase, err := h.episodeService.GetEpisodeWithPurchaseStatus(c.Request.Context(), episodeIDStr, userIDStr, preferredLanguages(c), c.Query("audio_language"))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
 		return
 	}
+	if language := episodeWithPurchase.Episode.DisplayLanguage; language != "" {
+		c.Header("Content-Language", language)
+	}
 
 	c.JSON(http.StatusOK, episodeWithPurchase)
 }
@@ -92,3 +103,262 @@ func (h *EpisodeHandler) UnlockSeries(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Series unlocked successfully"})
 }
+
+// CreateStreamToken issues a short-lived token for playing an episode in media players
+func (h *EpisodeHandler) CreateStreamToken(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	token, err := h.episodeService.CreateStreamToken(c.Request.Context(), c.Param("id"), userIDStr)
+	if errors.Is(err, services.ErrEpisodeAccessDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Episode is locked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// StreamEpisode proxies an episode's audio to entitled users with HTTP range support. The
+// language query parameter selects one of its additional audio tracks.
+func (h *EpisodeHandler) StreamEpisode(c *gin.Context) {
+	episodeIDStr := c.Param("id")
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	episode, err := h.episodeService.GetEntitledEpisode(c.Request.Context(), episodeIDStr, userIDStr)
+	if errors.Is(err, services.ErrEpisodeAccessDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Episode is locked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+
+	audioPath, err := h.episodeService.AudioPath(c.Request.Context(), episode, c.Query("language"))
+	if errors.Is(err, services.ErrAudioTrackNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio track not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audio"})
+		return
+	}
+
+	info, err := h.episodeService.StatEpisodeAudio(c.Request.Context(), audioPath)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Audio unavailable"})
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Accept-Ranges", "bytes")
+	// Entitlement is checked per request, so shared caches must never hold the audio
+	header.Set("Cache-Control", "private, no-cache")
+	if info.ETag != "" {
+		header.Set("ETag", info.ETag)
+	}
+	if !info.LastModified.IsZero() {
+		header.Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if isNotModified(c.Request, info) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	status := http.StatusOK
+	offset, length := int64(0), info.Size
+	if rangeHeader := c.GetHeader("Range"); rangeHeader != "" && ifRangeMatches(c.Request, info) {
+		start, size, ok := parseByteRange(rangeHeader, info.Size)
+		if !ok {
+			header.Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			c.Status(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if size >= 0 {
+			status = http.StatusPartialContent
+			offset, length = start, size
+			header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, info.Size))
+		}
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "audio/mpeg"
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Length", strconv.FormatInt(length, 10))
+
+	if c.Request.Method == http.MethodHead {
+		c.Status(status)
+		return
+	}
+
+	readLength := length
+	if status == http.StatusOK {
+		readLength = -1
+	}
+
+	body, err := h.episodeService.OpenEpisodeAudio(c.Request.Context(), audioPath, offset, readLength)
+	if err != nil {
+		header.Del("Content-Range")
+		header.Del("Content-Length")
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Audio unavailable"})
+		return
+	}
+	defer body.Close()
+
+	c.Status(status)
+	written, _ := io.Copy(c.Writer, body)
+
+	// The listener may already have disconnected, so usage is recorded outside the request context
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.episodeService.RecordStreamUsage(ctx, userIDStr, episode.ID, written); err != nil {
+		log.Printf("Failed to record stream usage for episode %s: %v", episode.ID, err)
+	}
+}
+
+// GetEpisodeKey releases the HLS decryption key to entitled users
+func (h *EpisodeHandler) GetEpisodeKey(c *gin.Context) {
+	episodeIDStr := c.Param("id")
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	key, err := h.episodeService.GetEpisodeKey(c.Request.Context(), episodeIDStr, userIDStr)
+	if errors.Is(err, services.ErrEpisodeAccessDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Episode is locked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode key not found"})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "application/octet-stream", key)
+}
+
+// parseByteRange parses a Range header against a resource of the given size.
+// A size of -1 means the header should be ignored and the full body served:
+// multi-range requests and unknown units fall in that case. ok is false when
+// the range cannot be satisfied.
+func parseByteRange(rangeHeader string, size int64) (start, length int64, ok bool) {
+	spec, found := strings.CutPrefix(rangeHeader, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, -1, true
+	}
+
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	if first == "" {
+		// Suffix range: the final N bytes
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 || size == 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, true
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+
+	return start, end - start + 1, true
+}
+
+// ifRangeMatches reports whether a Range request still applies to the current representation
+func ifRangeMatches(r *http.Request, info *services.ObjectInfo) bool {
+	ifRange := r.Header.Get("If-Range")
+	if ifRange == "" {
+		return true
+	}
+
+	// Entity tags must match strongly; weak tags never satisfy If-Range
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return info.ETag != "" && !strings.HasPrefix(info.ETag, "W/") && ifRange == info.ETag
+	}
+
+	since, err := http.ParseTime(ifRange)
+	if err != nil || info.LastModified.IsZero() {
+		return false
+	}
+	return info.LastModified.Truncate(time.Second).Equal(since)
+}
+
+// isNotModified evaluates If-None-Match and If-Modified-Since against the stored object
+func isNotModified(r *http.Request, info *services.ObjectInfo) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if info.ETag == "" {
+			return false
+		}
+		current := strings.TrimPrefix(info.ETag, "W/")
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == current {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !info.LastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !info.LastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import "testing"
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		size       int64
+		wantStart  int64
+		wantLength int64
+		wantOK     bool
+	}{
+		{"closed range", "bytes=0-99", 1000, 0, 100, true},
+		{"open-ended", "bytes=500-", 1000, 500, 500, true},
+		{"end past size", "bytes=900-2000", 1000, 900, 100, true},
+		{"suffix", "bytes=-100", 1000, 900, 100, true},
+		{"suffix larger than size", "bytes=-5000", 1000, 0, 1000, true},
+		{"start past size", "bytes=1000-", 1000, 0, 0, false},
+		{"end before start", "bytes=500-100", 1000, 0, 0, false},
+		{"zero suffix", "bytes=-0", 1000, 0, 0, false},
+		{"suffix of empty object", "bytes=-10", 0, 0, 0, false},
+		{"no dash", "bytes=100", 1000, 0, 0, false},
+		{"not a number", "bytes=abc-", 1000, 0, 0, false},
+		{"multiple ranges ignored", "bytes=0-1,5-9", 1000, 0, -1, true},
+		{"other unit ignored", "items=0-9", 1000, 0, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, length, ok := parseByteRange(tt.header, tt.size)
+			if start != tt.wantStart || length != tt.wantLength || ok != tt.wantOK {
+				t.Errorf("parseByteRange(%q, %d) = %d, %d, %v, want %d, %d, %v",
+					tt.header, tt.size, start, length, ok, tt.wantStart, tt.wantLength, tt.wantOK)
+			}
+		})
+	}
+}
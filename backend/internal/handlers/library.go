@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LibraryHandler struct {
+	libraryService *services.LibraryService
+}
+
+func NewLibraryHandler(libraryService *services.LibraryService) *LibraryHandler {
+	return &LibraryHandler{
+		libraryService: libraryService,
+	}
+}
+
+// GetLibrary returns the current user's followed series, favorites and listen-later queue
+func (h *LibraryHandler) GetLibrary(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	library, err := h.libraryService.GetLibrary(c.Request.Context(), userID, preferredLanguages(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get library"})
+		return
+	}
+
+	c.JSON(http.StatusOK, library)
+}
+
+// GetFollowedSeries lists the series the current user follows
+func (h *LibraryHandler) GetFollowedSeries(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	series, err := h.libraryService.GetFollowedSeries(c.Request.Context(), userID, preferredLanguages(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get followed series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": series})
+}
+
+// FollowSeries adds the series to the current user's follows
+func (h *LibraryHandler) FollowSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.libraryService.FollowSeries(c.Request.Context(), userID, seriesID)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow series"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowSeries removes the series from the current user's follows
+func (h *LibraryHandler) UnfollowSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.libraryService.UnfollowSeries(c.Request.Context(), userID, seriesID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow series"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFavorites lists the current user's favorite episodes
+func (h *LibraryHandler) GetFavorites(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	favorites, err := h.libraryService.GetFavorites(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get favorites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": favorites})
+}
+
+// FavoriteEpisode adds the episode to the current user's favorites
+func (h *LibraryHandler) FavoriteEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.libraryService.FavoriteEpisode(c.Request.Context(), userID, episodeID)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to favorite episode"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfavoriteEpisode removes the episode from the current user's favorites
+func (h *LibraryHandler) UnfavoriteEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.libraryService.UnfavoriteEpisode(c.Request.Context(), userID, episodeID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfavorite episode"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetQueue returns the current user's listen-later queue in order
+func (h *LibraryHandler) GetQueue(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.libraryService.GetQueue(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": queue})
+}
+
+// QueueEpisode adds the episode to the end of the current user's listen-later queue
+func (h *LibraryHandler) QueueEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.libraryService.QueueEpisode(c.Request.Context(), userID, episodeID)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue episode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": queue})
+}
+
+// UnqueueEpisode removes the episode from the current user's listen-later queue
+func (h *LibraryHandler) UnqueueEpisode(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.libraryService.UnqueueEpisode(c.Request.Context(), userID, episodeID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unqueue episode"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderQueue puts the current user's listen-later queue in a new order
+func (h *LibraryHandler) ReorderQueue(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	queue, err := h.libraryService.ReorderQueue(c.Request.Context(), userID, req.EpisodeIDs)
+	switch {
+	case errors.Is(err, services.ErrInvalidQueueOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"episodes": queue})
+}
+
+// GetNotifications lists the current user's new episode notifications, newest first
+func (h *LibraryHandler) GetNotifications(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	unreadOnly, err := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unread value"})
+		return
+	}
+
+	notifications, unread, err := h.libraryService.GetNotifications(c.Request.Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notifications": notifications, "unread_count": unread})
+}
+
+// MarkNotificationRead marks one of the current user's notifications as read
+func (h *LibraryHandler) MarkNotificationRead(c *gin.Context) {
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.libraryService.MarkNotificationRead(c.Request.Context(), userID, notificationID)
+	switch {
+	case errors.Is(err, services.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notification read"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead marks all of the current user's notifications as read
+func (h *LibraryHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.libraryService.MarkAllNotificationsRead(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notifications read"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"audio-series-app/backend/internal/models"
@@ -10,14 +12,16 @@ import (
 )
 
 type PaymentHandler struct {
-	paymentService *services.PaymentService
-	coinService    *services.CoinService
+	paymentService      *services.PaymentService
+	coinService         *services.CoinService
+	subscriptionService *services.SubscriptionService
 }
 
-func NewPaymentHandler(paymentService *services.PaymentService, coinService *services.CoinService) *PaymentHandler {
+func NewPaymentHandler(paymentService *services.PaymentService, coinService *services.CoinService, subscriptionService *services.SubscriptionService) *PaymentHandler {
 	return &PaymentHandler{
-		paymentService: paymentService,
-		coinService:    coinService,
+		paymentService:      paymentService,
+		coinService:         coinService,
+		subscriptionService: subscriptionService,
 	}
 }
 
@@ -61,13 +65,38 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 func (h *PaymentHandler) PaymentCallback(c *gin.Context) {
 	gateway := c.Param("gateway")
 
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment data"})
+		return
+	}
+
+	// The route is public, so only callbacks signed by the gateway are applied
+	signature := c.GetHeader("X-Razorpay-Signature")
+	if gateway == "paystack" {
+		signature = c.GetHeader("X-Paystack-Signature")
+	}
+	err = h.paymentService.VerifyWebhookSignature(gateway, body, signature)
+	switch {
+	case errors.Is(err, services.ErrInvalidWebhookSignature):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid webhook signature"})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var paymentData map[string]interface{}
-	if err := c.ShouldBindJSON(&paymentData); err != nil {
+	if err := json.Unmarshal(body, &paymentData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment data"})
 		return
 	}
 
-	err := h.paymentService.HandlePaymentCallback(c.Request.Context(), gateway, paymentData)
+	// Subscription payments and lifecycle events share the gateway callback URL
+	handled, err := h.subscriptionService.HandlePaymentCallback(c.Request.Context(), gateway, paymentData)
+	if !handled {
+		err = h.paymentService.HandlePaymentCallback(c.Request.Context(), gateway, paymentData)
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ProgressHandler struct {
+	progressService *services.ProgressService
+}
+
+func NewProgressHandler(progressService *services.ProgressService) *ProgressHandler {
+	return &ProgressHandler{
+		progressService: progressService,
+	}
+}
+
+// SaveProgress stores the current user's position in an episode
+func (h *ProgressHandler) SaveProgress(c *gin.Context) {
+	episodeIDStr := c.Param("id")
+	if _, err := uuid.Parse(episodeIDStr); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req models.ProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	progress, applied, err := h.progressService.SaveProgress(c.Request.Context(), userIDStr, episodeIDStr, &req)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrEpisodeAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Episode is locked"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save progress"})
+		return
+	}
+
+	// Another device sent a newer update; hand it back so this client can catch up
+	if !applied {
+		c.JSON(http.StatusConflict, gin.H{"error": "A newer update exists", "progress": progress})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// GetContinueListening returns the current user's unfinished episodes, most recently played first
+func (h *ProgressHandler) GetContinueListening(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	items, err := h.progressService.GetContinueListening(c.Request.Context(), userIDStr, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get continue listening"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetSeriesReviews returns a series' rating and a page of its reviews
+func (h *ReviewHandler) GetSeriesReviews(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	rating, reviews, err := h.reviewService.GetSeriesReviews(c.Request.Context(), seriesID, limit, offset)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rating": rating, "reviews": reviews})
+}
+
+// GetMyReview returns the current user's review of a series
+func (h *ReviewHandler) GetMyReview(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.reviewService.GetUserReview(c.Request.Context(), userID, seriesID)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// SaveReview rates and optionally reviews a series as the current user
+func (h *ReviewHandler) SaveReview(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	review, err := h.reviewService.SaveReview(c.Request.Context(), userID, seriesID, &req)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrReviewNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Listen to, buy or subscribe to an episode of the series before reviewing it"})
+		return
+	case errors.Is(err, services.ErrBlockedContent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Review contains language that is not allowed"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// DeleteReview removes the current user's review of a series
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.reviewService.DeleteReview(c.Request.Context(), userID, seriesID)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete review"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReportReview reports another listener's review as abusive
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	err = h.reviewService.ReportReview(c.Request.Context(), userID, reviewID, &req)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case errors.Is(err, services.ErrInvalidReviewReport):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to report review"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetModerationQueue lists reviews waiting for moderation, or reviews with a status (admin only)
+func (h *ReviewHandler) GetModerationQueue(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && status != "visible" && status != "flagged" && status != "hidden" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	reviews, err := h.reviewService.GetModerationQueue(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reviews": reviews})
+}
+
+// GetReviewReports lists the reports made against a review (admin only)
+func (h *ReviewHandler) GetReviewReports(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	reports, err := h.reviewService.GetReviewReports(c.Request.Context(), reviewID)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get review reports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports})
+}
+
+// ModerateReview shows, flags or hides a review (admin only)
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(c.Request.Context(), actorID, reviewID, req.Status)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ReplyToReview sets the team's public reply to a review (admin only)
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	review, err := h.reviewService.ReplyToReview(c.Request.Context(), actorID, reviewID, req.Reply)
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reply to review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
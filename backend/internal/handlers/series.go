@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"audio-series-app/backend/internal/models"
 	"audio-series-app/backend/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -10,24 +14,64 @@ import (
 )
 
 type SeriesHandler struct {
-	seriesService *services.SeriesService
+	seriesService  *services.SeriesService
+	catalogService *services.CatalogService
 }
 
-func NewSeriesHandler(seriesService *services.SeriesService) *SeriesHandler {
+func NewSeriesHandler(seriesService *services.SeriesService, catalogService *services.CatalogService) *SeriesHandler {
 	return &SeriesHandler{
-		seriesService: seriesService,
+		seriesService:  seriesService,
+		catalogService: catalogService,
 	}
 }
 
-// GetSeries returns all available series
+// GetSeries returns a page of the series catalog, filtered and sorted by the query parameters
 func (h *SeriesHandler) GetSeries(c *gin.Context) {
-	series, err := h.seriesService.GetSeries(c.Request.Context())
-	if err != nil {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	query := &models.CatalogQuery{
+		Category:      c.Query("category"),
+		Author:        c.Query("author"),
+		Language:      c.Query("language"),
+		AudioLanguage: c.Query("audio_language"),
+		Sort:          c.DefaultQuery("sort", "newest"),
+		Cursor:        c.Query("cursor"),
+		Limit:         limit,
+		Languages:     preferredLanguages(c),
+	}
+
+	if premium := c.Query("premium"); premium != "" {
+		isPremium, err := strconv.ParseBool(premium)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid premium value"})
+			return
+		}
+		query.IsPremium = &isPremium
+	}
+
+	if fields := c.Query("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				query.Fields = append(query.Fields, field)
+			}
+		}
+	}
+
+	page, err := h.catalogService.GetCatalog(c.Request.Context(), query)
+	switch {
+	case errors.Is(err, services.ErrInvalidCatalogQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get series"})
 		return
 	}
 
-	c.JSON(http.StatusOK, series)
+	c.JSON(http.StatusOK, page)
 }
 
 // GetSeriesByID returns a specific series with its episodes
@@ -39,11 +83,26 @@ func (h *SeriesHandler) GetSeriesByID(c *gin.Context) {
 		return
 	}
 
-	seriesWithEpisodes, err := h.seriesService.GetSeriesWithEpisodes(c.Request.Context(), seriesID)
+	seriesWithEpisodes, err := h.seriesService.GetSeriesWithEpisodes(c.Request.Context(), seriesID, preferredLanguages(c))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 		return
 	}
 
+	series := seriesWithEpisodes.Series
+	if series.DisplayLanguage != "" {
+		c.Header("Content-Language", series.DisplayLanguage)
+	} else {
+		c.Header("Content-Language", series.Language)
+	}
+
 	c.JSON(http.StatusOK, seriesWithEpisodes)
 }
+
+// preferredLanguages returns the languages the client wants titles and descriptions in, from
+// the lang query parameter and then the Accept-Language header. Responses that depend on it
+// are marked as varying by Accept-Language for caches.
+func preferredLanguages(c *gin.Context) []string {
+	c.Header("Vary", "Accept-Language")
+	return services.PreferredLanguages(c.Query("lang"), c.GetHeader("Accept-Language"))
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"audio-series-app/backend/internal/models"
+	"audio-series-app/backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TranslationHandler struct {
+	translationService *services.TranslationService
+}
+
+func NewTranslationHandler(translationService *services.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+	}
+}
+
+// GetSeriesTranslations lists a series' translated titles and descriptions (admin only)
+func (h *TranslationHandler) GetSeriesTranslations(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	translations, err := h.translationService.GetSeriesTranslations(c.Request.Context(), seriesID)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+// SetSeriesTranslation creates or replaces a series' title and description in one language (admin only)
+func (h *TranslationHandler) SetSeriesTranslation(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	translation, err := h.translationService.SetSeriesTranslation(c.Request.Context(), actorID, seriesID, c.Param("lang"), &req)
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrInvalidTranslation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// DeleteSeriesTranslation removes a series' translation for one language (admin only)
+func (h *TranslationHandler) DeleteSeriesTranslation(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.translationService.DeleteSeriesTranslation(c.Request.Context(), actorID, seriesID, c.Param("lang"))
+	switch {
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+		return
+	case errors.Is(err, services.ErrTranslationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete translation"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEpisodeTranslations lists an episode's translated titles and descriptions (admin only)
+func (h *TranslationHandler) GetEpisodeTranslations(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	translations, err := h.translationService.GetEpisodeTranslations(c.Request.Context(), episodeID)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+// SetEpisodeTranslation creates or replaces an episode's title and description in one language (admin only)
+func (h *TranslationHandler) SetEpisodeTranslation(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data"})
+		return
+	}
+
+	translation, err := h.translationService.SetEpisodeTranslation(c.Request.Context(), actorID, episodeID, c.Param("lang"), &req)
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrInvalidTranslation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// DeleteEpisodeTranslation removes an episode's translation for one language (admin only)
+func (h *TranslationHandler) DeleteEpisodeTranslation(c *gin.Context) {
+	episodeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.translationService.DeleteEpisodeTranslation(c.Request.Context(), actorID, episodeID, c.Param("lang"))
+	switch {
+	case errors.Is(err, services.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	case errors.Is(err, services.ErrTranslationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete translation"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -8,6 +8,7 @@ import (
 	"audio-series-app/backend/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthMiddleware struct {
@@ -37,17 +38,36 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		token := tokenParts[1]
+		m.authenticateToken(c, tokenParts[1])
+	}
+}
+
+// AuthenticateMedia is Authenticate for media endpoints under /episodes/:id. Browsers cannot
+// attach headers to <audio> requests, so a stream token for the episode may be passed as the
+// stream_token query parameter instead. Session tokens are never accepted in URLs, where
+// they would end up in access logs and Referer headers.
+func (m *AuthMiddleware) AuthenticateMedia() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("stream_token")
+		if token == "" || c.GetHeader("Authorization") != "" {
+			m.Authenticate()(c)
+			return
+		}
+
+		episodeID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode ID"})
+			c.Abort()
+			return
+		}
 
-		// Validate token
-		user, err := m.authService.ValidateToken(token)
+		user, err := m.authService.ValidateStreamToken(token, episodeID)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid stream token"})
 			c.Abort()
 			return
 		}
 
-		// Set user info in context
 		c.Set("user_id", user.ID.String())
 		c.Set("user", user)
 
@@ -55,6 +75,22 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	}
 }
 
+func (m *AuthMiddleware) authenticateToken(c *gin.Context, token string) {
+	// Validate token
+	user, err := m.authService.ValidateToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return
+	}
+
+	// Set user info in context
+	c.Set("user_id", user.ID.String())
+	c.Set("user", user)
+
+	c.Next()
+}
+
 func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user, exists := c.Get("user")
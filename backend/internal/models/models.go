@@ -23,34 +23,461 @@ type User struct {
 
 // Series represents an audio series
 type Series struct {
+	ID          uuid.UUID          `json:"id" db:"id"`
+	Title       string             `json:"title" db:"title"`
+	Description string             `json:"description" db:"description"`
+	CoverImage  string             `json:"cover_image" db:"cover_image"`
+	CoverImages CoverImageVariants `json:"cover_images,omitempty" db:"cover_images"`
+	Author      string             `json:"author" db:"author"` // names of the credited authors, kept for older clients
+	Credits     []*Credit          `json:"credits,omitempty" db:"-"`
+	Category    string             `json:"category" db:"category"`      // name of the primary genre, kept for older clients
+	Categories  []*Category        `json:"categories,omitempty" db:"-"` // genres first, then tags
+	Language    string             `json:"language" db:"language"`      // BCP 47 tag, e.g. en, hi, pt-BR
+	// DisplayLanguage is the language the title and description were served in, when a
+	// translation was picked for the listener
+	DisplayLanguage string         `json:"display_language,omitempty" db:"-"`
+	Translations    []*Translation `json:"translations,omitempty" db:"-"` // admin previews only
+	IsPremium       bool           `json:"is_premium" db:"is_premium"`
+	TotalEpisodes   int            `json:"total_episodes" db:"total_episodes"`
+	FollowerCount   int            `json:"follower_count" db:"-"`
+	RatingAverage   float64        `json:"rating_average" db:"-"` // 1 to 5, or 0 before the first rating
+	RatingCount     int            `json:"rating_count" db:"-"`
+	CreatedBy       uuid.UUID      `json:"created_by" db:"created_by"`
+	Status          string         `json:"status" db:"status"` // draft, scheduled, published, archived
+	PublishAt       *time.Time     `json:"publish_at,omitempty" db:"publish_at"`
+	DeletedAt       *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// CatalogQuery filters, sorts and pages the public series catalog. Empty filters match every series.
+type CatalogQuery struct {
+	Category      string
+	Author        string
+	Language      string
+	AudioLanguage string // series with at least one released episode spoken in this language
+	IsPremium     *bool
+	Sort          string   // newest, popular, trending, rating
+	Cursor        string   // opaque; next_cursor of the previous page
+	Limit         int      // page size
+	Fields        []string // sparse fieldset; empty returns every field
+	Languages     []string // languages to show titles in, most preferred first
+}
+
+// SeriesPage is one page of the series catalog
+type SeriesPage struct {
+	Data       interface{} `json:"data"` // []*Series, or field maps when a sparse fieldset was asked for
+	Pagination PageInfo    `json:"pagination"`
+}
+
+// PageInfo describes where a cursor-paginated page ends
+type PageInfo struct {
+	Limit      int     `json:"limit"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor *string `json:"next_cursor"` // null on the last page
+}
+
+// SearchResults holds the series and episodes matching a search, each group best match first
+type SearchResults struct {
+	Query    string                 `json:"query"`
+	Series   []*SeriesSearchResult  `json:"series"`
+	Episodes []*EpisodeSearchResult `json:"episodes"`
+}
+
+// SeriesSearchResult is a series matching a search by title, author or description
+type SeriesSearchResult struct {
+	ID            uuid.UUID          `json:"id"`
+	Title         string             `json:"title"`
+	Author        string             `json:"author"`
+	Category      string             `json:"category"`
+	Language      string             `json:"language"`
+	CoverImage    string             `json:"cover_image"`
+	CoverImages   CoverImageVariants `json:"cover_images,omitempty"`
+	IsPremium     bool               `json:"is_premium"`
+	TotalEpisodes int                `json:"total_episodes"`
+	Rank          float64            `json:"rank"`
+}
+
+// EpisodeSearchResult is an episode matching a search by title or description
+type EpisodeSearchResult struct {
+	ID            uuid.UUID `json:"id"`
+	SeriesID      uuid.UUID `json:"series_id"`
+	SeriesTitle   string    `json:"series_title"`
+	Title         string    `json:"title"`
+	EpisodeNumber int       `json:"episode_number"`
+	Duration      int       `json:"duration"` // in seconds
+	Rank          float64   `json:"rank"`
+}
+
+// Category is a genre, sub-genre or tag in the admin-managed taxonomy
+type Category struct {
+	ID           uuid.UUID         `json:"id" db:"id"`
+	Slug         string            `json:"slug" db:"slug"`
+	Name         string            `json:"name" db:"name"` // localized when a language is asked for
+	Kind         string            `json:"kind" db:"kind"` // genre, tag
+	ParentID     *uuid.UUID        `json:"parent_id,omitempty" db:"parent_id"`
+	DisplayNames map[string]string `json:"display_names,omitempty" db:"display_names"` // language tag to name
+	SortOrder    int               `json:"sort_order" db:"sort_order"`
+	SeriesCount  *int              `json:"series_count,omitempty" db:"-"` // published series, sub-genres included
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// CategoryRequest creates or replaces a category. The slug is derived from the name when left out.
+type CategoryRequest struct {
+	Slug         string            `json:"slug" binding:"omitempty,max=100"`
+	Name         string            `json:"name" binding:"required,max=100"`
+	Kind         string            `json:"kind" binding:"required,oneof=genre tag"`
+	ParentID     *uuid.UUID        `json:"parent_id"`
+	DisplayNames map[string]string `json:"display_names"`
+	SortOrder    int               `json:"sort_order"`
+}
+
+// SeriesCategoriesRequest replaces a series' categories. The first genre listed is its primary one.
+type SeriesCategoriesRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids" binding:"max=20"`
+}
+
+// Creator is an author, narrator or producer with a public page
+type Creator struct {
 	ID            uuid.UUID `json:"id" db:"id"`
-	Title         string    `json:"title" db:"title"`
-	Description   string    `json:"description" db:"description"`
-	CoverImage    string    `json:"cover_image" db:"cover_image"`
-	Author        string    `json:"author" db:"author"`
-	Category      string    `json:"category" db:"category"`
-	IsPremium     bool      `json:"is_premium" db:"is_premium"`
-	TotalEpisodes int       `json:"total_episodes" db:"total_episodes"`
-	CreatedBy     uuid.UUID `json:"created_by" db:"created_by"`
+	Slug          string    `json:"slug" db:"slug"`
+	Name          string    `json:"name" db:"name"`
+	Bio           string    `json:"bio" db:"bio"`
+	AvatarURL     string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	FollowerCount int       `json:"follower_count" db:"-"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// CreatorRequest creates or replaces a creator. The slug is derived from the name when left out.
+type CreatorRequest struct {
+	Slug      string `json:"slug" binding:"omitempty,max=100"`
+	Name      string `json:"name" binding:"required,max=255"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url" binding:"omitempty,url"`
+}
+
+// Credit names a creator and the role they had on a series or episode
+type Credit struct {
+	CreatorID uuid.UUID `json:"creator_id" db:"creator_id"`
+	Slug      string    `json:"slug" db:"slug"`
+	Name      string    `json:"name" db:"name"`
+	AvatarURL string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	Role      string    `json:"role" db:"role"` // author, narrator, producer
+}
+
+// CreditInput is one credit in a CreditsRequest
+type CreditInput struct {
+	CreatorID uuid.UUID `json:"creator_id" binding:"required"`
+	Role      string    `json:"role" binding:"required,oneof=author narrator producer"`
+}
+
+// CreditsRequest replaces the credits of a series or episode, in display order
+type CreditsRequest struct {
+	Credits []CreditInput `json:"credits" binding:"max=50,dive"`
+}
+
+// CreatorWorks lists what a creator worked on: published series and released episodes,
+// newest first, each with the creator's roles on it
+type CreatorWorks struct {
+	Creator  *Creator           `json:"creator"`
+	Series   []*CreditedSeries  `json:"series"`
+	Episodes []*CreditedEpisode `json:"episodes"`
+}
+
+// CreditedSeries is a series a creator is credited on
+type CreditedSeries struct {
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	CoverImage    string    `json:"cover_image"`
+	TotalEpisodes int       `json:"total_episodes"`
+	Roles         []string  `json:"roles"`
+}
+
+// CreditedEpisode is an episode a creator is credited on, such as one they narrated
+type CreditedEpisode struct {
+	ID            uuid.UUID `json:"id"`
+	SeriesID      uuid.UUID `json:"series_id"`
+	SeriesTitle   string    `json:"series_title"`
+	Title         string    `json:"title"`
+	EpisodeNumber int       `json:"episode_number"`
+	Roles         []string  `json:"roles"`
+}
+
+// FeedItem is a newly released episode from a creator the user follows
+type FeedItem struct {
+	EpisodeID     uuid.UUID `json:"episode_id"`
+	Title         string    `json:"title"`
+	EpisodeNumber int       `json:"episode_number"`
+	SeriesID      uuid.UUID `json:"series_id"`
+	SeriesTitle   string    `json:"series_title"`
+	CoverImage    string    `json:"cover_image"`
+	CreatorID     uuid.UUID `json:"creator_id"`
+	CreatorName   string    `json:"creator_name"`
+	ReleasedAt    time.Time `json:"released_at"`
+}
+
+// LibraryEpisode is an episode a user has favorited or queued to listen to later
+type LibraryEpisode struct {
+	EpisodeID     uuid.UUID `json:"episode_id"`
+	Title         string    `json:"title"`
+	EpisodeNumber int       `json:"episode_number"`
+	Duration      int       `json:"duration"` // in seconds
+	SeriesID      uuid.UUID `json:"series_id"`
+	SeriesTitle   string    `json:"series_title"`
+	CoverImage    string    `json:"cover_image"`
+	Position      int       `json:"position,omitempty"` // place in the listen-later queue, from 1
+	AddedAt       time.Time `json:"added_at"`
+}
+
+// Library is everything a user has saved: followed series, favorite episodes and their
+// listen-later queue
+type Library struct {
+	FollowedSeries []*Series         `json:"followed_series"`
+	Favorites      []*LibraryEpisode `json:"favorites"`
+	Queue          []*LibraryEpisode `json:"queue"`
+}
+
+// Notification tells a user about a new episode of a series they follow
+type Notification struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Kind          string     `json:"kind" db:"kind"` // new_episode
+	SeriesID      uuid.UUID  `json:"series_id" db:"series_id"`
+	SeriesTitle   string     `json:"series_title" db:"-"`
+	CoverImage    string     `json:"cover_image" db:"-"`
+	EpisodeID     uuid.UUID  `json:"episode_id" db:"episode_id"`
+	EpisodeTitle  string     `json:"episode_title" db:"-"`
+	EpisodeNumber int        `json:"episode_number" db:"-"`
+	ReadAt        *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Review is a listener's 1 to 5 star rating of a series, with an optional written review
+type Review struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	SeriesID    uuid.UUID  `json:"series_id" db:"series_id"`
+	SeriesTitle string     `json:"series_title,omitempty" db:"-"` // moderation queue only
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	AuthorName  string     `json:"author_name" db:"-"` // first name and last initial
+	Rating      int        `json:"rating" db:"rating"`
+	Body        string     `json:"body" db:"body"`
+	Status      string     `json:"status,omitempty" db:"status"`             // visible, flagged, hidden; shown to admins and the author
+	ReportCount int        `json:"report_count,omitempty" db:"report_count"` // shown to admins
+	Reply       string     `json:"reply,omitempty" db:"reply"`               // public reply from the team
+	RepliedAt   *time.Time `json:"replied_at,omitempty" db:"replied_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// StreamToken lets a media player stream one episode, and fetch its HLS key, without an
+// Authorization header
+type StreamToken struct {
+	Token     string    `json:"stream_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	StreamURL string    `json:"stream_url"`
+}
+
+// ReviewReport is a listener's report of an abusive review
+type ReviewReport struct {
+	ReviewID  uuid.UUID `json:"review_id" db:"review_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Reason    string    `json:"reason" db:"reason"` // spam, abuse, spoiler, other
+	Details   string    `json:"details,omitempty" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Comment is a listener's comment on an episode, optionally at a moment in it. Replies have
+// a ParentID and no position, and cannot be replied to.
+type Comment struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	EpisodeID    uuid.UUID  `json:"episode_id" db:"episode_id"`
+	EpisodeTitle string     `json:"episode_title,omitempty" db:"-"` // moderation queue only
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	AuthorName   string     `json:"author_name" db:"-"` // first name and last initial
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	Position     *int       `json:"position,omitempty" db:"position"` // in seconds from the start of the episode
+	Body         string     `json:"body" db:"body"`
+	Status       string     `json:"status,omitempty" db:"status"`             // visible, flagged, hidden; shown to admins and the author
+	ReportCount  int        `json:"report_count,omitempty" db:"report_count"` // shown to admins
+	LikeCount    int        `json:"like_count" db:"like_count"`
+	LikedByMe    bool       `json:"liked_by_me" db:"-"`
+	Deleted      bool       `json:"deleted,omitempty" db:"-"` // deleted by its author but kept for its replies
+	ReplyCount   int        `json:"reply_count" db:"-"`       // visible replies, top-level comments only
+	Replies      []*Comment `json:"replies,omitempty" db:"-"` // the first few replies, top-level comments only
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CommentReport is a listener's report of an abusive comment
+type CommentReport struct {
+	CommentID uuid.UUID `json:"comment_id" db:"comment_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Reason    string    `json:"reason" db:"reason"` // spam, abuse, spoiler, other
+	Details   string    `json:"details,omitempty" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RatingSummary is the average rating of a series and how many ratings it is based on
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// AudioTrack is an episode's audio in another language, such as a Hindi dub of an English episode
+type AudioTrack struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	EpisodeID         uuid.UUID `json:"episode_id" db:"episode_id"`
+	Language          string    `json:"language" db:"language"` // BCP 47 tag
+	AudioURL          string    `json:"-" db:"audio_url"`       // storage path; never sent to listeners
+	Duration          int       `json:"duration" db:"duration"` // in seconds
+	AudioCodec        string    `json:"audio_codec,omitempty" db:"audio_codec"`
+	AudioBitrate      int       `json:"audio_bitrate,omitempty" db:"audio_bitrate"` // in bits per second
+	ProcessingStatus  string    `json:"processing_status" db:"processing_status"`   // unprocessed, queued, processing, ready, failed
+	ProcessedAudioURL string    `json:"-" db:"processed_audio_url"`                 // normalized AAC rendition's storage path
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PlaybackTrack is one language an episode can be played in
+type PlaybackTrack struct {
+	Language          string     `json:"language"`
+	IsPrimary         bool       `json:"is_primary"` // the episode's own audio; the only one with Opus and HLS renditions
+	Duration          int        `json:"duration"`
+	AudioURL          string     `json:"audio_url,omitempty"` // signed, only when the user has access
+	AudioURLExpiresAt *time.Time `json:"audio_url_expires_at,omitempty"`
+}
+
+// Translation is a series' or episode's title and description in another language
+type Translation struct {
+	Language    string    `json:"language" db:"language"` // BCP 47 tag
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TranslationRequest creates or replaces the translation for one language
+type TranslationRequest struct {
+	Title       string `json:"title" binding:"required,max=255"`
+	Description string `json:"description"`
+}
+
+// CoverImageVariants maps a cover size (thumbnail, card, hero) to its URL per format (webp, jpeg)
+type CoverImageVariants map[string]map[string]string
+
 // Episode represents an individual episode in a series
 type Episode struct {
-	ID            uuid.UUID `json:"id" db:"id"`
-	SeriesID      uuid.UUID `json:"series_id" db:"series_id"`
-	Title         string    `json:"title" db:"title"`
-	Description   string    `json:"description" db:"description"`
-	AudioURL      string    `json:"audio_url" db:"audio_url"`
-	Duration      int       `json:"duration" db:"duration"` // in seconds
-	EpisodeNumber int       `json:"episode_number" db:"episode_number"`
-	CoinPrice     int       `json:"coin_price" db:"coin_price"`
-	IsLocked      bool      `json:"is_locked" db:"is_locked"`
+	ID               uuid.UUID      `json:"id" db:"id"`
+	SeriesID         uuid.UUID      `json:"series_id" db:"series_id"`
+	Title            string         `json:"title" db:"title"`
+	Description      string         `json:"description" db:"description"`
+	AudioURL         string         `json:"audio_url,omitempty" db:"audio_url"` // storage path; never sent to listeners
+	Duration         int            `json:"duration" db:"duration"`             // in seconds
+	AudioCodec       string         `json:"audio_codec,omitempty" db:"audio_codec"`
+	AudioBitrate     int            `json:"audio_bitrate,omitempty" db:"audio_bitrate"`   // in bits per second
+	ProcessingStatus string         `json:"processing_status" db:"processing_status"`     // unprocessed, queued, processing, ready, failed
+	AACAudioURL      string         `json:"aac_audio_url,omitempty" db:"aac_audio_url"`   // loudness-normalized rendition; storage path
+	OpusAudioURL     string         `json:"opus_audio_url,omitempty" db:"opus_audio_url"` // loudness-normalized rendition; storage path
+	EpisodeNumber    int            `json:"episode_number" db:"episode_number"`
+	CoinPrice        int            `json:"coin_price" db:"coin_price"`
+	IsLocked         bool           `json:"is_locked" db:"is_locked"`
+	Status           string         `json:"status" db:"status"` // draft, scheduled, published, archived
+	PublishAt        *time.Time     `json:"publish_at,omitempty" db:"publish_at"`
+	FreeAt           *time.Time     `json:"free_at,omitempty" db:"free_at"`     // end of early access; coins only until then, free for everyone after
+	AudioLanguage    string         `json:"audio_language" db:"audio_language"` // BCP 47 tag of the spoken audio
+	AudioLanguages   []string       `json:"audio_languages,omitempty" db:"-"`   // every track's language, the episode's own first
+	DisplayLanguage  string         `json:"display_language,omitempty" db:"-"`  // language of the title and description, when translated
+	Translations     []*Translation `json:"translations,omitempty" db:"-"`      // admin previews only
+	Credits          []*Credit      `json:"credits,omitempty" db:"-"`
+	DeletedAt        *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// EpisodePackage represents the encrypted HLS packaging of an episode
+type EpisodePackage struct {
+	EpisodeID     uuid.UUID `json:"episode_id" db:"episode_id"`
+	Status        string    `json:"status" db:"status"`   // pending, processing, ready, failed; of the latest run
+	Version       int       `json:"version" db:"version"` // live version, 0 until the first run succeeds
+	PlaylistPath  string    `json:"playlist_path" db:"playlist_path"`
+	Bitrates      string    `json:"bitrates" db:"bitrates"` // comma separated, e.g. 48k,96k,160k
+	EncryptionKey []byte    `json:"-" db:"encryption_key"`
+	EncryptionIV  []byte    `json:"-" db:"encryption_iv"`
+	Error         *string   `json:"error,omitempty" db:"error"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Chapter marks the start of a named section within an episode
+type Chapter struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	EpisodeID uuid.UUID `json:"episode_id" db:"episode_id"`
+	StartTime int       `json:"start_time" db:"start_time"` // in seconds from the start of the episode
+	Title     string    `json:"title" db:"title"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// EpisodeWaveform holds the downsampled peak amplitudes drawn by the audio player
+type EpisodeWaveform struct {
+	EpisodeID uuid.UUID `json:"episode_id" db:"episode_id"`
+	Peaks     []float64 `json:"peaks" db:"peaks"` // 0 to 1, evenly spaced over the episode
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ListeningProgress is how far a user got in an episode, synced across their devices
+type ListeningProgress struct {
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	EpisodeID       uuid.UUID `json:"episode_id" db:"episode_id"`
+	Position        int       `json:"position" db:"position"` // in seconds
+	Duration        int       `json:"duration" db:"duration"` // in seconds, as reported by the player
+	Completed       bool      `json:"completed" db:"completed"`
+	ClientUpdatedAt time.Time `json:"client_updated_at" db:"client_updated_at"` // decides which device's update wins
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ListeningEvent is a single playback event reported by a client. Events are append-only.
+type ListeningEvent struct {
+	ID              int64     `json:"id" db:"id"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	EpisodeID       uuid.UUID `json:"episode_id" db:"episode_id"`
+	SessionID       uuid.UUID `json:"session_id" db:"session_id"` // one playback of an episode on one device
+	Type            string    `json:"type" db:"type"`             // play, pause, seek, complete, drop_off
+	Position        int       `json:"position" db:"position"`     // in seconds
+	ClientTimestamp time.Time `json:"client_timestamp" db:"client_timestamp"`
+	ReceivedAt      time.Time `json:"received_at" db:"received_at"`
+}
+
+// EpisodeMetrics are the rolled-up listening metrics of an episode
+type EpisodeMetrics struct {
+	EpisodeID        uuid.UUID `json:"episode_id" db:"episode_id"`
+	Starts           int       `json:"starts" db:"starts"`
+	Completions      int       `json:"completions" db:"completions"`
+	AvgListenThrough float64   `json:"avg_listen_through" db:"avg_listen_through"` // average share of the episode heard, 0 to 1
+	CompletionRate   float64   `json:"completion_rate" db:"completion_rate"`
+	DropOffCurve     []float64 `json:"drop_off_curve" db:"drop_off_curve"` // share of starts still listening at 0%, 5%, ... 100%
+	RolledUpAt       time.Time `json:"rolled_up_at" db:"rolled_up_at"`
+}
+
+// TranscodeJob is a queued job on an episode's audio: loudness normalization and transcoding
+// of its own audio or of one of its audio tracks, or HLS packaging
+type TranscodeJob struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	EpisodeID   uuid.UUID  `json:"episode_id" db:"episode_id"`
+	Kind        string     `json:"kind" db:"kind"`                   // transcode, package, track
+	TrackID     *uuid.UUID `json:"track_id,omitempty" db:"track_id"` // the audio track a track job normalizes
+	Status      string     `json:"status" db:"status"`               // queued, running, completed, failed
+	Attempts    int        `json:"attempts" db:"attempts"`
+	MaxAttempts int        `json:"max_attempts" db:"max_attempts"`
+	LastError   *string    `json:"last_error,omitempty" db:"last_error"`
+	RunAt       time.Time  `json:"run_at" db:"run_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
 // Purchase represents a user's purchase of an episode or series
 type Purchase struct {
 	ID        uuid.UUID  `json:"id" db:"id"`
@@ -78,17 +505,44 @@ type CoinTransaction struct {
 
 // Payment represents a payment transaction
 type Payment struct {
-	ID          uuid.UUID `json:"id" db:"id"`
-	UserID      uuid.UUID `json:"user_id" db:"user_id"`
-	Amount      int       `json:"amount" db:"amount"` // in smallest currency unit
-	Currency    string    `json:"currency" db:"currency"`
-	Coins       int       `json:"coins" db:"coins"`
-	Gateway     string    `json:"gateway" db:"gateway"` // razorpay, paystack
-	GatewayRef  string    `json:"gateway_ref" db:"gateway_ref"`
-	Status      string    `json:"status" db:"status"`             // pending, completed, failed
-	PaymentData string    `json:"payment_data" db:"payment_data"` // JSON string
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Amount         int        `json:"amount" db:"amount"` // in smallest currency unit
+	Currency       string     `json:"currency" db:"currency"`
+	Coins          int        `json:"coins" db:"coins"`
+	Gateway        string     `json:"gateway" db:"gateway"` // razorpay, paystack
+	GatewayRef     string     `json:"gateway_ref" db:"gateway_ref"`
+	Status         string     `json:"status" db:"status"`             // pending, completed, failed
+	PaymentData    string     `json:"payment_data" db:"payment_data"` // JSON string
+	SubscriptionID *uuid.UUID `json:"subscription_id,omitempty" db:"subscription_id"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SubscriptionPlan represents a VIP subscription plan
+type SubscriptionPlan struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Interval  string    `json:"interval" db:"interval"` // month, year
+	Price     int       `json:"price" db:"price"`       // in smallest currency unit
+	Currency  string    `json:"currency" db:"currency"`
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Subscription represents a user's subscription to a plan
+type Subscription struct {
+	ID                 uuid.UUID  `json:"id" db:"id"`
+	UserID             uuid.UUID  `json:"user_id" db:"user_id"`
+	PlanID             uuid.UUID  `json:"plan_id" db:"plan_id"`
+	Status             string     `json:"status" db:"status"` // pending, active, past_due, cancelled, expired
+	Gateway            string     `json:"gateway" db:"gateway"`
+	CurrentPeriodStart *time.Time `json:"current_period_start,omitempty" db:"current_period_start"`
+	CurrentPeriodEnd   *time.Time `json:"current_period_end,omitempty" db:"current_period_end"`
+	GraceUntil         *time.Time `json:"grace_until,omitempty" db:"grace_until"`
+	CancelAtPeriodEnd  bool       `json:"cancel_at_period_end" db:"cancel_at_period_end"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // AuthRequest represents authentication request
@@ -114,15 +568,45 @@ type RegisterRequest struct {
 
 // SeriesWithEpisodes represents a series with its episodes
 type SeriesWithEpisodes struct {
-	Series   *Series    `json:"series"`
-	Episodes []*Episode `json:"episodes"`
+	Series   *Series            `json:"series"`
+	Episodes []*Episode         `json:"episodes"`
+	Upcoming []*UpcomingEpisode `json:"upcoming,omitempty"` // scheduled releases, shown as coming soon
+}
+
+// UpcomingEpisode is a placeholder for a scheduled episode that has not been released yet
+type UpcomingEpisode struct {
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	EpisodeNumber int       `json:"episode_number"`
+	ReleaseAt     time.Time `json:"release_at"`
+}
+
+// DripSchedule releases a series' draft episodes automatically, in episode order, one per
+// slot. Slots fall on the given weekdays at ReleaseTime in Timezone.
+type DripSchedule struct {
+	SeriesID    uuid.UUID `json:"series_id" db:"series_id"`
+	Weekdays    []int     `json:"weekdays" db:"weekdays"`         // 0 = Sunday ... 6 = Saturday
+	ReleaseTime string    `json:"release_time" db:"release_time"` // HH:MM, local to Timezone
+	Timezone    string    `json:"timezone" db:"timezone"`         // IANA name, e.g. Asia/Kolkata
+	CreatedBy   uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // EpisodeWithPurchase represents an episode with purchase status
 type EpisodeWithPurchase struct {
-	Episode   *Episode `json:"episode"`
-	IsOwned   bool     `json:"is_owned"`
-	CanUnlock bool     `json:"can_unlock"`
+	Episode           *Episode         `json:"episode"`
+	IsOwned           bool             `json:"is_owned"`
+	AccessSource      string           `json:"access_source,omitempty"` // free, purchase, subscription
+	CanUnlock         bool             `json:"can_unlock"`
+	IsEarlyAccess     bool             `json:"is_early_access"`     // coins only until the episode's free_at
+	AudioURL          string           `json:"audio_url,omitempty"` // signed, only when the user has access
+	AudioURLExpiresAt *time.Time       `json:"audio_url_expires_at,omitempty"`
+	OpusURL           string           `json:"opus_url,omitempty"` // signed Opus rendition, once transcoded
+	AudioTracks       []*PlaybackTrack `json:"audio_tracks"`       // the listener's preferred language first
+	Chapters          []*Chapter       `json:"chapters"`
+	Waveform          []float64        `json:"waveform,omitempty"`     // peak amplitudes, once generated
+	PlaylistURL       string           `json:"playlist_url,omitempty"` // encrypted HLS master playlist
 }
 
 // CoinBundle represents available coin bundles for purchase
@@ -142,6 +626,153 @@ type PaymentRequest struct {
 	Currency string `json:"currency" binding:"required"` // INR, NGN
 }
 
+// SubscriptionRequest represents a subscription checkout request
+type SubscriptionRequest struct {
+	PlanID string `json:"plan_id" binding:"required"`
+}
+
+// SeriesUpdateRequest changes a series' details. PATCH applies the fields that are set;
+// PUT requires all of them.
+type SeriesUpdateRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=255"`
+	Description *string `json:"description"`
+	Author      *string `json:"author" binding:"omitempty,min=1,max=255"`
+	Category    *string `json:"category" binding:"omitempty,max=100"`
+	Language    *string `json:"language" binding:"omitempty,min=2,max=10"`
+	IsPremium   *bool   `json:"is_premium"`
+}
+
+// EpisodeUpdateRequest changes an episode's details. PATCH applies the fields that are set;
+// PUT requires all of them.
+type EpisodeUpdateRequest struct {
+	Title         *string `json:"title" binding:"omitempty,min=1,max=255"`
+	Description   *string `json:"description"`
+	AudioLanguage *string `json:"audio_language" binding:"omitempty,min=2,max=10"`
+	EpisodeNumber *int    `json:"episode_number" binding:"omitempty,min=1"`
+	CoinPrice     *int    `json:"coin_price" binding:"omitempty,min=0"`
+	IsLocked      *bool   `json:"is_locked"`
+}
+
+// PublicationRequest moves a series or episode through its lifecycle. PublishAt is required
+// for scheduled content and defaults to now when publishing.
+type PublicationRequest struct {
+	Status    string     `json:"status" binding:"required,oneof=draft scheduled published archived"`
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// EarlyAccessRequest sets when an episode's early access ends and it becomes free for
+// everyone. A null free_at ends early access for the episode.
+type EarlyAccessRequest struct {
+	FreeAt *time.Time `json:"free_at"`
+}
+
+// DripScheduleRequest sets a series' drip schedule
+type DripScheduleRequest struct {
+	Weekdays    []int  `json:"weekdays" binding:"required,min=1,max=7,dive,min=0,max=6"`
+	ReleaseTime string `json:"release_time" binding:"required"`
+	Timezone    string `json:"timezone" binding:"required"`
+}
+
+// DripQueueRequest adds an episode to its series' drip queue or takes it out
+type DripQueueRequest struct {
+	Queued *bool `json:"queued" binding:"required"`
+}
+
+// ReorderEpisodesRequest lists every episode of a series in its new order
+type ReorderEpisodesRequest struct {
+	EpisodeIDs []string `json:"episode_ids" binding:"required,min=1,dive,uuid"`
+}
+
+// ReorderQueueRequest lists every episode of the user's listen-later queue in its new order
+type ReorderQueueRequest struct {
+	EpisodeIDs []string `json:"episode_ids" binding:"required,dive,uuid"`
+}
+
+// ReviewRequest rates a series and optionally reviews it
+type ReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Body   string `json:"body" binding:"max=2000"`
+}
+
+// ReportRequest reports a review or comment as abusive
+type ReportRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam abuse spoiler other"`
+	Details string `json:"details" binding:"max=500"`
+}
+
+// ModerationRequest shows, flags or hides a review or comment
+type ModerationRequest struct {
+	Status string `json:"status" binding:"required,oneof=visible flagged hidden"`
+}
+
+// ReviewReplyRequest sets the team's public reply to a review; an empty reply removes it
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" binding:"max=2000"`
+}
+
+// CommentRequest comments on an episode, optionally at a moment in it
+type CommentRequest struct {
+	Body     string `json:"body" binding:"required,max=1000"`
+	Position *int   `json:"position" binding:"omitempty,min=0"` // in seconds
+}
+
+// CommentReplyRequest replies to a comment
+type CommentReplyRequest struct {
+	Body string `json:"body" binding:"required,max=1000"`
+}
+
+// ChaptersRequest replaces an episode's chapter markers
+type ChaptersRequest struct {
+	Chapters []ChapterInput `json:"chapters" binding:"dive"`
+}
+
+// ChapterInput is a single chapter marker in a ChaptersRequest
+type ChapterInput struct {
+	StartTime int    `json:"start_time" binding:"min=0"`
+	Title     string `json:"title" binding:"required,max=255"`
+}
+
+// ProgressRequest represents a listening progress update from a player
+type ProgressRequest struct {
+	Position        int       `json:"position" binding:"min=0"`
+	Duration        int       `json:"duration" binding:"min=0"`
+	Completed       bool      `json:"completed"`
+	ClientTimestamp time.Time `json:"client_timestamp" binding:"required"`
+}
+
+// ContinueListeningItem is an in-progress episode with its series
+type ContinueListeningItem struct {
+	Episode  *Episode           `json:"episode"`
+	Series   *Series            `json:"series"`
+	Progress *ListeningProgress `json:"progress"`
+}
+
+// EventsRequest is a batch of listening events from a client
+type EventsRequest struct {
+	Events []ListeningEventInput `json:"events" binding:"required,min=1,max=100,dive"`
+}
+
+// ListeningEventInput is a single event in an EventsRequest
+type ListeningEventInput struct {
+	EpisodeID       string    `json:"episode_id" binding:"required,uuid"`
+	SessionID       string    `json:"session_id" binding:"required,uuid"`
+	Type            string    `json:"type" binding:"required,oneof=play pause seek complete drop_off"`
+	Position        int       `json:"position" binding:"min=0"`
+	ClientTimestamp time.Time `json:"client_timestamp" binding:"required"`
+}
+
+// EpisodeAnalytics pairs an episode with its listening metrics
+type EpisodeAnalytics struct {
+	Episode *Episode        `json:"episode"`
+	Metrics *EpisodeMetrics `json:"metrics"` // nil until the first rollup after its first play
+}
+
+// SubscriptionResponse represents a subscription with its pending payment
+type SubscriptionResponse struct {
+	Subscription *Subscription    `json:"subscription"`
+	Payment      *PaymentResponse `json:"payment,omitempty"`
+}
+
 // PaymentResponse represents payment gateway response
 type PaymentResponse struct {
 	PaymentID   string `json:"payment_id"`
@@ -152,12 +783,77 @@ type PaymentResponse struct {
 	RedirectURL string `json:"redirect_url,omitempty"`
 }
 
-// AdminStats represents admin dashboard statistics
+// AdminStats represents admin dashboard statistics. Money is never summed across
+// currencies; every revenue figure is per currency, in its smallest unit.
 type AdminStats struct {
-	TotalUsers     int `json:"total_users"`
-	TotalSeries    int `json:"total_series"`
-	TotalEpisodes  int `json:"total_episodes"`
-	TotalRevenue   int `json:"total_revenue"`
-	MonthlyRevenue int `json:"monthly_revenue"`
-	ActiveUsers    int `json:"active_users"`
+	TotalUsers      int                 `json:"total_users"`
+	TotalSeries     int                 `json:"total_series"`
+	TotalEpisodes   int                 `json:"total_episodes"`
+	ActiveUsers     int                 `json:"active_users"` // users with a purchase in the last 30 days
+	From            time.Time           `json:"from"`
+	To              time.Time           `json:"to"`
+	Interval        string              `json:"interval"` // day, week, month
+	Revenue         []*RevenueBreakdown `json:"revenue"`
+	RevenueSeries   []*RevenuePoint     `json:"revenue_series"`
+	ARPPU           []*CurrencyARPPU    `json:"arppu"`
+	CoinSellThrough *CoinSellThrough    `json:"coin_sell_through"`
+	Cohorts         []*SignupCohort     `json:"cohorts"`
+}
+
+// AuditLogEntry records a change an admin made to a series, episode, category or creator
+type AuditLogEntry struct {
+	ID         uuid.UUID              `json:"id" db:"id"`
+	ActorID    *uuid.UUID             `json:"actor_id" db:"actor_id"`       // nil once the admin's account is deleted
+	Action     string                 `json:"action" db:"action"`           // create, update, delete, reorder
+	EntityType string                 `json:"entity_type" db:"entity_type"` // series, episode, category, creator
+	EntityID   uuid.UUID              `json:"entity_id" db:"entity_id"`
+	Changes    map[string]AuditChange `json:"changes" db:"changes"`
+	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+}
+
+// AuditChange is the value of one field before and after a change
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// RevenueBreakdown is the completed payment revenue of one currency through one gateway
+type RevenueBreakdown struct {
+	Currency    string `json:"currency"`
+	Gateway     string `json:"gateway"`
+	Amount      int64  `json:"amount"` // in smallest currency unit
+	Payments    int    `json:"payments"`
+	PayingUsers int    `json:"paying_users"`
+}
+
+// RevenuePoint is the revenue of one currency in one time-series bucket
+type RevenuePoint struct {
+	PeriodStart time.Time `json:"period_start"`
+	Currency    string    `json:"currency"`
+	Amount      int64     `json:"amount"` // in smallest currency unit
+	Payments    int       `json:"payments"`
+}
+
+// CurrencyARPPU is the average revenue per paying user in one currency
+type CurrencyARPPU struct {
+	Currency    string  `json:"currency"`
+	Amount      int64   `json:"amount"` // in smallest currency unit
+	PayingUsers int     `json:"paying_users"`
+	ARPPU       float64 `json:"arppu"`
+}
+
+// CoinSellThrough compares coins bought with money against coins spent on unlocks
+type CoinSellThrough struct {
+	Purchased int64   `json:"purchased"`
+	Spent     int64   `json:"spent"`
+	Rate      float64 `json:"rate"` // spent / purchased
+}
+
+// SignupCohort tracks how many users who signed up in a period went on to pay
+type SignupCohort struct {
+	PeriodStart            time.Time `json:"period_start"`
+	Signups                int       `json:"signups"`
+	Converted              int       `json:"converted"`
+	ConversionRate         float64   `json:"conversion_rate"`
+	AvgDaysToFirstPurchase *float64  `json:"avg_days_to_first_purchase"`
 }
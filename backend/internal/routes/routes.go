@@ -15,6 +15,16 @@ func SetupRoutes(
 	episodeHandler *handlers.EpisodeHandler,
 	paymentHandler *handlers.PaymentHandler,
 	adminHandler *handlers.AdminHandler,
+	subscriptionHandler *handlers.SubscriptionHandler,
+	progressHandler *handlers.ProgressHandler,
+	analyticsHandler *handlers.AnalyticsHandler,
+	searchHandler *handlers.SearchHandler,
+	categoryHandler *handlers.CategoryHandler,
+	creatorHandler *handlers.CreatorHandler,
+	translationHandler *handlers.TranslationHandler,
+	libraryHandler *handlers.LibraryHandler,
+	reviewHandler *handlers.ReviewHandler,
+	commentHandler *handlers.CommentHandler,
 	authMiddleware *middleware.AuthMiddleware,
 ) {
 	// Root route
@@ -46,9 +56,23 @@ func SetupRoutes(
 		// Series (public read access)
 		public.GET("/series", seriesHandler.GetSeries)
 		public.GET("/series/:id", seriesHandler.GetSeriesByID)
+		public.GET("/series/:id/reviews", reviewHandler.GetSeriesReviews)
+
+		// Categories
+		public.GET("/categories", categoryHandler.GetCategories)
+
+		// Creators
+		public.GET("/creators/:id", creatorHandler.GetCreator)
+		public.GET("/creators/:id/works", creatorHandler.GetCreatorWorks)
+
+		// Search
+		public.GET("/search", searchHandler.Search)
 
 		// Payment bundles
 		public.GET("/payment/bundles", paymentHandler.GetCoinBundles)
+
+		// Subscription plans
+		public.GET("/subscriptions/plans", subscriptionHandler.GetPlans)
 	}
 
 	// Protected routes (require authentication)
@@ -62,11 +86,71 @@ func SetupRoutes(
 
 		// Episodes
 		protected.GET("/episodes/:id", episodeHandler.GetEpisode)
+		protected.POST("/episodes/:id/stream-token", episodeHandler.CreateStreamToken)
 		protected.POST("/episodes/:id/unlock", episodeHandler.UnlockEpisode)
 		protected.POST("/series/:id/unlock", episodeHandler.UnlockSeries)
 
+		// Listening progress
+		protected.PUT("/episodes/:id/progress", progressHandler.SaveProgress)
+		protected.GET("/user/continue-listening", progressHandler.GetContinueListening)
+
+		// Creator follows
+		protected.POST("/creators/:id/follow", creatorHandler.FollowCreator)
+		protected.DELETE("/creators/:id/follow", creatorHandler.UnfollowCreator)
+		protected.GET("/user/following", creatorHandler.GetFollowing)
+		protected.GET("/user/feed", creatorHandler.GetFeed)
+
+		// Library
+		protected.GET("/user/library", libraryHandler.GetLibrary)
+		protected.GET("/user/library/series", libraryHandler.GetFollowedSeries)
+		protected.POST("/user/library/series/:id", libraryHandler.FollowSeries)
+		protected.DELETE("/user/library/series/:id", libraryHandler.UnfollowSeries)
+		protected.GET("/user/library/favorites", libraryHandler.GetFavorites)
+		protected.POST("/user/library/favorites/:id", libraryHandler.FavoriteEpisode)
+		protected.DELETE("/user/library/favorites/:id", libraryHandler.UnfavoriteEpisode)
+		protected.GET("/user/library/queue", libraryHandler.GetQueue)
+		protected.PUT("/user/library/queue", libraryHandler.ReorderQueue)
+		protected.POST("/user/library/queue/:id", libraryHandler.QueueEpisode)
+		protected.DELETE("/user/library/queue/:id", libraryHandler.UnqueueEpisode)
+		protected.GET("/user/notifications", libraryHandler.GetNotifications)
+		protected.POST("/user/notifications/read", libraryHandler.MarkAllNotificationsRead)
+		protected.POST("/user/notifications/:id/read", libraryHandler.MarkNotificationRead)
+
+		// Reviews
+		protected.GET("/series/:id/review", reviewHandler.GetMyReview)
+		protected.PUT("/series/:id/review", reviewHandler.SaveReview)
+		protected.DELETE("/series/:id/review", reviewHandler.DeleteReview)
+		protected.POST("/reviews/:id/report", reviewHandler.ReportReview)
+
+		// Comments
+		protected.GET("/episodes/:id/comments", commentHandler.GetEpisodeComments)
+		protected.POST("/episodes/:id/comments", commentHandler.AddComment)
+		protected.GET("/comments/:id/replies", commentHandler.GetReplies)
+		protected.POST("/comments/:id/replies", commentHandler.ReplyToComment)
+		protected.DELETE("/comments/:id", commentHandler.DeleteComment)
+		protected.POST("/comments/:id/like", commentHandler.LikeComment)
+		protected.DELETE("/comments/:id/like", commentHandler.UnlikeComment)
+		protected.POST("/comments/:id/report", commentHandler.ReportComment)
+
+		// Listening analytics
+		protected.POST("/events", analyticsHandler.RecordEvents)
+
 		// Payments
 		protected.POST("/payment/initiate", paymentHandler.InitiatePayment)
+
+		// Subscriptions
+		protected.GET("/user/subscription", subscriptionHandler.GetSubscription)
+		protected.POST("/subscriptions", subscriptionHandler.Subscribe)
+		protected.POST("/subscriptions/cancel", subscriptionHandler.CancelSubscription)
+	}
+
+	// Audio streaming and HLS keys (accept a stream token as a query parameter for media elements)
+	media := api.Group("/")
+	media.Use(authMiddleware.AuthenticateMedia())
+	{
+		media.GET("/episodes/:id/stream", episodeHandler.StreamEpisode)
+		media.HEAD("/episodes/:id/stream", episodeHandler.StreamEpisode)
+		media.GET("/episodes/:id/hls/key", episodeHandler.GetEpisodeKey)
 	}
 
 	// Admin routes (require admin role)
@@ -74,9 +158,59 @@ func SetupRoutes(
 	admin.Use(authMiddleware.Authenticate())
 	admin.Use(authMiddleware.RequireAdmin())
 	{
+		admin.GET("/series", adminHandler.ListSeries)
 		admin.POST("/series", adminHandler.CreateSeries)
+		admin.GET("/series/:id", adminHandler.PreviewSeries)
+		admin.PUT("/series/:id", adminHandler.UpdateSeries)
+		admin.PATCH("/series/:id", adminHandler.UpdateSeries)
+		admin.DELETE("/series/:id", adminHandler.DeleteSeries)
+		admin.PUT("/series/:id/publication", adminHandler.SetSeriesPublication)
+		admin.PUT("/series/:id/episode-order", adminHandler.ReorderEpisodes)
+		admin.PUT("/series/:id/categories", categoryHandler.SetSeriesCategories)
+		admin.PUT("/series/:id/credits", creatorHandler.SetSeriesCredits)
+		admin.GET("/series/:id/translations", translationHandler.GetSeriesTranslations)
+		admin.PUT("/series/:id/translations/:lang", translationHandler.SetSeriesTranslation)
+		admin.DELETE("/series/:id/translations/:lang", translationHandler.DeleteSeriesTranslation)
+		admin.GET("/series/:id/drip-schedule", adminHandler.GetDripSchedule)
+		admin.PUT("/series/:id/drip-schedule", adminHandler.SetDripSchedule)
+		admin.DELETE("/series/:id/drip-schedule", adminHandler.DeleteDripSchedule)
+		admin.POST("/series/:id/cover", adminHandler.UploadSeriesCover)
+		admin.GET("/series/:id/analytics", analyticsHandler.GetSeriesAnalytics)
 		admin.POST("/episodes", adminHandler.CreateEpisode)
+		admin.POST("/episodes/upload", adminHandler.UploadEpisode)
+		admin.PUT("/episodes/:id", adminHandler.UpdateEpisode)
+		admin.PATCH("/episodes/:id", adminHandler.UpdateEpisode)
+		admin.DELETE("/episodes/:id", adminHandler.DeleteEpisode)
+		admin.PUT("/episodes/:id/publication", adminHandler.SetEpisodePublication)
+		admin.PUT("/episodes/:id/early-access", adminHandler.SetEpisodeEarlyAccess)
+		admin.PUT("/episodes/:id/drip-queue", adminHandler.SetEpisodeDripQueue)
+		admin.PUT("/episodes/:id/credits", creatorHandler.SetEpisodeCredits)
+		admin.GET("/episodes/:id/translations", translationHandler.GetEpisodeTranslations)
+		admin.PUT("/episodes/:id/translations/:lang", translationHandler.SetEpisodeTranslation)
+		admin.DELETE("/episodes/:id/translations/:lang", translationHandler.DeleteEpisodeTranslation)
+		admin.POST("/episodes/:id/tracks", adminHandler.UploadAudioTrack)
+		admin.DELETE("/episodes/:id/tracks/:lang", adminHandler.DeleteAudioTrack)
+		admin.POST("/categories", categoryHandler.CreateCategory)
+		admin.PUT("/categories/:id", categoryHandler.UpdateCategory)
+		admin.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+		admin.POST("/creators", creatorHandler.CreateCreator)
+		admin.PUT("/creators/:id", creatorHandler.UpdateCreator)
+		admin.GET("/reviews", reviewHandler.GetModerationQueue)
+		admin.GET("/reviews/:id/reports", reviewHandler.GetReviewReports)
+		admin.PUT("/reviews/:id/status", reviewHandler.ModerateReview)
+		admin.PUT("/reviews/:id/reply", reviewHandler.ReplyToReview)
+		admin.GET("/comments", commentHandler.GetModerationQueue)
+		admin.GET("/comments/:id/reports", commentHandler.GetCommentReports)
+		admin.PUT("/comments/:id/status", commentHandler.ModerateComment)
 		admin.GET("/stats", adminHandler.GetAdminStats)
+		admin.GET("/audit-log", adminHandler.GetAuditLog)
+		admin.POST("/episodes/:id/package", adminHandler.PackageEpisode)
+		admin.GET("/episodes/:id/package", adminHandler.GetEpisodePackage)
+		admin.POST("/episodes/:id/transcode", adminHandler.TranscodeEpisode)
+		admin.PUT("/episodes/:id/chapters", adminHandler.ReplaceChapters)
+		admin.GET("/transcode-jobs", adminHandler.GetTranscodeJobs)
+		admin.GET("/transcode-jobs/:id", adminHandler.GetTranscodeJob)
+		admin.POST("/transcode-jobs/:id/retry", adminHandler.RetryTranscodeJob)
 	}
 
 	// Payment callbacks (public)
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// episodeMetricsRollup names the watermark of the per-episode metrics rollup
+	episodeMetricsRollup = "episode_metrics"
+	// rollupOverlap is re-read on every run so events committed just behind the watermark are not missed
+	rollupOverlap = time.Minute
+	// dropOffBuckets is the number of 5% steps in a drop-off curve; the curve has one more point
+	dropOffBuckets = 20
+)
+
+// reachBucket counts the playback sessions whose reach fell into one 5% step of an episode
+type reachBucket struct {
+	Bucket      int
+	Sessions    int
+	Completions int
+	ReachSum    float64
+}
+
+type AnalyticsService struct {
+	supabase *SupabaseService
+}
+
+func NewAnalyticsService(supabase *SupabaseService) *AnalyticsService {
+	return &AnalyticsService{
+		supabase: supabase,
+	}
+}
+
+// RecordEvents stores a batch of the user's listening events. Events are append-only; the
+// returned count excludes events for episodes that do not exist or that the user cannot
+// currently play, which are dropped. Access to the whole batch is checked in one query.
+func (s *AnalyticsService) RecordEvents(ctx context.Context, userIDStr string, inputs []models.ListeningEventInput) (int64, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	latest := time.Now().Add(maxClientClockSkew)
+	events := make([]*models.ListeningEvent, 0, len(inputs))
+	var episodeIDs []uuid.UUID
+	for _, input := range inputs {
+		episodeID, err := uuid.Parse(input.EpisodeID)
+		if err != nil {
+			return 0, fmt.Errorf("invalid episode ID: %w", err)
+		}
+
+		sessionID, err := uuid.Parse(input.SessionID)
+		if err != nil {
+			return 0, fmt.Errorf("invalid session ID: %w", err)
+		}
+
+		clientTimestamp := input.ClientTimestamp
+		if clientTimestamp.After(latest) {
+			clientTimestamp = latest
+		}
+
+		events = append(events, &models.ListeningEvent{
+			UserID:          userID,
+			EpisodeID:       episodeID,
+			SessionID:       sessionID,
+			Type:            input.Type,
+			Position:        input.Position,
+			ClientTimestamp: clientTimestamp,
+		})
+		episodeIDs = append(episodeIDs, episodeID)
+	}
+
+	access, err := s.supabase.GetEpisodeAccess(ctx, userID, episodeIDs)
+	if err != nil {
+		return 0, err
+	}
+	playable := events[:0]
+	for _, event := range events {
+		if access[event.EpisodeID] {
+			playable = append(playable, event)
+		}
+	}
+
+	return s.supabase.InsertListeningEvents(ctx, playable)
+}
+
+// GetSeriesAnalytics returns the rolled-up metrics of every episode in the series
+func (s *AnalyticsService) GetSeriesAnalytics(ctx context.Context, seriesID uuid.UUID) ([]*models.EpisodeAnalytics, error) {
+	if _, err := s.supabase.GetSeriesByID(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	return s.supabase.GetSeriesAnalytics(ctx, seriesID)
+}
+
+// RollupEpisodeMetrics folds the events received since the last successful rollup into
+// per-session summaries, then recomputes the metrics of the episodes those sessions belong
+// to from their summaries. Raw events are only read once, apart from a short overlap, and
+// merging is idempotent, so re-running a rollup is harmless. The watermark only moves once
+// every episode has been saved, so a failed run is retried in full next time.
+func (s *AnalyticsService) RollupEpisodeMetrics(ctx context.Context) (int, error) {
+	startedAt := time.Now()
+
+	watermark, err := s.supabase.GetRollupWatermark(ctx, episodeMetricsRollup)
+	if err != nil {
+		return 0, err
+	}
+
+	since := watermark
+	if !since.IsZero() {
+		since = since.Add(-rollupOverlap)
+	}
+
+	episodeIDs, err := s.supabase.MergeListeningSessions(ctx, since, startedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, episodeID := range episodeIDs {
+		if err := s.rollupEpisode(ctx, episodeID, startedAt); err != nil {
+			return 0, fmt.Errorf("failed to roll up episode %s: %w", episodeID, err)
+		}
+	}
+
+	if err := s.supabase.SetRollupWatermark(ctx, episodeMetricsRollup, startedAt); err != nil {
+		return 0, err
+	}
+
+	return len(episodeIDs), nil
+}
+
+func (s *AnalyticsService) rollupEpisode(ctx context.Context, episodeID uuid.UUID, rolledUpAt time.Time) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+
+	buckets, err := s.supabase.GetEpisodeReachHistogram(ctx, episode.ID, episode.Duration)
+	if err != nil {
+		return err
+	}
+
+	metrics := episodeMetrics(episode.ID, buckets, rolledUpAt)
+	return s.supabase.UpsertEpisodeMetrics(ctx, metrics)
+}
+
+// episodeMetrics computes an episode's starts, completions, average listen-through and
+// drop-off curve from its reach histogram. Point i of the curve is the share of sessions
+// still listening at i*5% of the episode.
+func episodeMetrics(episodeID uuid.UUID, buckets []reachBucket, rolledUpAt time.Time) *models.EpisodeMetrics {
+	metrics := &models.EpisodeMetrics{
+		EpisodeID:    episodeID,
+		DropOffCurve: make([]float64, dropOffBuckets+1),
+		RolledUpAt:   rolledUpAt,
+	}
+
+	// sessions[i] is how many sessions stopped in step i; a session that reached step i
+	// was still listening at every earlier point of the curve
+	sessions := make([]int, dropOffBuckets+1)
+	var reachSum float64
+	for _, bucket := range buckets {
+		sessions[bucket.Bucket] = bucket.Sessions
+		metrics.Starts += bucket.Sessions
+		metrics.Completions += bucket.Completions
+		reachSum += bucket.ReachSum
+	}
+
+	if metrics.Starts > 0 {
+		metrics.AvgListenThrough = reachSum / float64(metrics.Starts)
+		metrics.CompletionRate = float64(metrics.Completions) / float64(metrics.Starts)
+
+		remaining := metrics.Starts
+		for i := range metrics.DropOffCurve {
+			metrics.DropOffCurve[i] = float64(remaining) / float64(metrics.Starts)
+			remaining -= sessions[i]
+		}
+	}
+
+	return metrics
+}
+
+// RunRollup rolls up episode metrics every interval until ctx is cancelled
+func (s *AnalyticsService) RunRollup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if rolledUp, err := s.RollupEpisodeMetrics(ctx); err != nil {
+			log.Printf("Analytics rollup failed: %v", err)
+		} else if rolledUp > 0 {
+			log.Printf("Analytics rollup updated %d episodes", rolledUp)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEpisodeMetricsNoSessions(t *testing.T) {
+	episodeID := uuid.New()
+	rolledUpAt := time.Date(2024, 3, 1, 4, 0, 0, 0, time.UTC)
+
+	metrics := episodeMetrics(episodeID, nil, rolledUpAt)
+
+	if metrics.EpisodeID != episodeID || !metrics.RolledUpAt.Equal(rolledUpAt) {
+		t.Errorf("episodeMetrics() = %v at %v, want %v at %v", metrics.EpisodeID, metrics.RolledUpAt, episodeID, rolledUpAt)
+	}
+	if metrics.Starts != 0 || metrics.Completions != 0 || metrics.AvgListenThrough != 0 || metrics.CompletionRate != 0 {
+		t.Errorf("episodeMetrics() = %+v, want zero metrics", metrics)
+	}
+	if len(metrics.DropOffCurve) != dropOffBuckets+1 {
+		t.Fatalf("len(DropOffCurve) = %d, want %d", len(metrics.DropOffCurve), dropOffBuckets+1)
+	}
+	for i, point := range metrics.DropOffCurve {
+		if point != 0 {
+			t.Errorf("DropOffCurve[%d] = %v, want 0", i, point)
+		}
+	}
+}
+
+func TestEpisodeMetricsDropOffCurve(t *testing.T) {
+	// 10 sessions: 4 stopped in the first 5%, 2 around the halfway point, 4 finished
+	buckets := []reachBucket{
+		{Bucket: 0, Sessions: 4, ReachSum: 0.1},
+		{Bucket: 10, Sessions: 2, ReachSum: 1.0},
+		{Bucket: 20, Sessions: 4, Completions: 4, ReachSum: 4},
+	}
+
+	metrics := episodeMetrics(uuid.New(), buckets, time.Now())
+
+	if metrics.Starts != 10 || metrics.Completions != 4 {
+		t.Errorf("Starts, Completions = %d, %d, want 10, 4", metrics.Starts, metrics.Completions)
+	}
+	if !approxEqual(metrics.CompletionRate, 0.4) {
+		t.Errorf("CompletionRate = %v, want 0.4", metrics.CompletionRate)
+	}
+	if !approxEqual(metrics.AvgListenThrough, 0.51) {
+		t.Errorf("AvgListenThrough = %v, want 0.51", metrics.AvgListenThrough)
+	}
+
+	want := map[int]float64{0: 1, 1: 0.6, 10: 0.6, 11: 0.4, 19: 0.4, 20: 0.4}
+	for i, point := range want {
+		if !approxEqual(metrics.DropOffCurve[i], point) {
+			t.Errorf("DropOffCurve[%d] = %v, want %v", i, metrics.DropOffCurve[i], point)
+		}
+	}
+	for i := 1; i < len(metrics.DropOffCurve); i++ {
+		if metrics.DropOffCurve[i] > metrics.DropOffCurve[i-1] {
+			t.Errorf("DropOffCurve rises at %d: %v > %v", i, metrics.DropOffCurve[i], metrics.DropOffCurve[i-1])
+		}
+	}
+}
+
+func TestEpisodeMetricsEveryoneFinished(t *testing.T) {
+	metrics := episodeMetrics(uuid.New(), []reachBucket{{Bucket: dropOffBuckets, Sessions: 3, Completions: 3, ReachSum: 3}}, time.Now())
+
+	if metrics.CompletionRate != 1 || metrics.AvgListenThrough != 1 {
+		t.Errorf("CompletionRate, AvgListenThrough = %v, %v, want 1, 1", metrics.CompletionRate, metrics.AvgListenThrough)
+	}
+	for i, point := range metrics.DropOffCurve {
+		if point != 1 {
+			t.Errorf("DropOffCurve[%d] = %v, want 1", i, point)
+		}
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
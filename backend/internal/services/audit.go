@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type AuditService struct {
+	supabase *SupabaseService
+}
+
+func NewAuditService(supabase *SupabaseService) *AuditService {
+	return &AuditService{
+		supabase: supabase,
+	}
+}
+
+// GetAuditLog returns admin changes, newest first, optionally limited to one entity type or entity
+func (s *AuditService) GetAuditLog(ctx context.Context, entityType string, entityID *uuid.UUID, limit, offset int) ([]*models.AuditLogEntry, error) {
+	return s.supabase.GetAuditLog(ctx, entityType, entityID, limit, offset)
+}
+
+// newAuditLogEntry starts an audit log entry for a change made by actorID
+func newAuditLogEntry(actorID uuid.UUID, action, entityType string, entityID uuid.UUID) *models.AuditLogEntry {
+	return &models.AuditLogEntry{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    map[string]models.AuditChange{},
+	}
+}
+
+// recordChange adds field to the entry's changes if its value differs. Values must be comparable.
+func recordChange(entry *models.AuditLogEntry, field string, oldValue, newValue interface{}) {
+	if oldValue != newValue {
+		entry.Changes[field] = models.AuditChange{Old: oldValue, New: newValue}
+	}
+}
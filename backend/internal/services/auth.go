@@ -98,6 +98,11 @@ func (s *AuthService) ValidateToken(tokenString string) (*models.User, error) {
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		// Scoped tokens, such as stream tokens, are not sessions
+		if _, scoped := claims["scope"]; scoped {
+			return nil, fmt.Errorf("invalid token")
+		}
+
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
 			return nil, fmt.Errorf("invalid token claims")
@@ -120,6 +125,21 @@ func (s *AuthService) ValidateToken(tokenString string) (*models.User, error) {
 	return nil, fmt.Errorf("invalid token")
 }
 
+// ValidateStreamToken returns the user a stream token was issued to, if it is valid for the episode
+func (s *AuthService) ValidateStreamToken(tokenString string, episodeID uuid.UUID) (*models.User, error) {
+	userID, err := parseStreamToken(s.config.JWTSecret, tokenString, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.supabase.GetUserByID(context.Background(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	return user, nil
+}
+
 func (s *AuthService) generateJWT(userID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
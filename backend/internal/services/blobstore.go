@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+)
+
+// ErrSignedURLUnsupported is returned when the storage backend cannot issue signed URLs
+var ErrSignedURLUnsupported = errors.New("signed URLs are not supported by this storage backend")
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// BlobStore is the object storage used for audio, HLS renditions and cover art.
+// Keys are slash-separated paths inside a bucket.
+type BlobStore interface {
+	// Put stores body at key, replacing any existing object
+	Put(ctx context.Context, bucket, key, contentType string, body io.Reader) error
+	// Get reads a whole object
+	Get(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	// GetRange reads length bytes from offset; a negative length reads to the end
+	GetRange(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error)
+	// Stat returns the object's size and cache validators
+	Stat(ctx context.Context, bucket, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, bucket, key string) error
+	// List returns the objects whose keys start with prefix
+	List(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+	// SignedURL returns a URL granting temporary read access to a private object
+	SignedURL(ctx context.Context, bucket, key string, expiresIn time.Duration) (string, error)
+	// PublicURL returns the URL of an object in a public bucket
+	PublicURL(bucket, key string) string
+}
+
+// NewBlobStore returns the BlobStore selected by STORAGE_BACKEND
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.StorageBackend {
+	case "", "supabase":
+		return NewSupabaseBlobStore(cfg), nil
+	case "s3":
+		return NewS3BlobStore(cfg), nil
+	case "local":
+		return NewLocalBlobStore(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", cfg.StorageBackend)
+	}
+}
+
+// escapeObjectPath escapes each segment of a key for use in a URL path
+func escapeObjectPath(objectPath string) string {
+	segments := strings.Split(objectPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
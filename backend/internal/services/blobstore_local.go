@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+)
+
+// LocalBlobStore keeps objects on the local filesystem under LocalStorageDir/<bucket>.
+// It is meant for development; it cannot issue signed URLs, so audio is served through the
+// authenticated stream proxy instead. Public buckets are served by the API server under
+// /storage/<bucket>/.
+type LocalBlobStore struct {
+	rootDir   string
+	publicURL string
+}
+
+func NewLocalBlobStore(cfg *config.Config) *LocalBlobStore {
+	return &LocalBlobStore{
+		rootDir:   cfg.LocalStorageDir,
+		publicURL: strings.TrimSuffix(cfg.PublicAPIURL, "/") + "/storage",
+	}
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, bucket, key, contentType string, body io.Reader) error {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.GetRange(ctx, bucket, key, 0, -1)
+}
+
+func (s *LocalBlobStore) GetRange(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %v", err)
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek object: %v", err)
+	}
+
+	if length < 0 {
+		return file, nil
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+func (s *LocalBlobStore) Stat(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object: %v", err)
+	}
+
+	return localObjectInfo(key, path, stat), nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, bucket, key string) error {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object: %v", err)
+	}
+
+	return nil
+}
+
+func (s *LocalBlobStore) List(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	root := filepath.Join(s.rootDir, bucket)
+
+	var objects []ObjectInfo
+	err := filepath.Walk(root, func(path string, stat os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if stat.IsDir() || strings.HasPrefix(stat.Name(), ".upload-") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(relPath)
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, *localObjectInfo(key, path, stat))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %v", err)
+	}
+
+	return objects, nil
+}
+
+func (s *LocalBlobStore) SignedURL(ctx context.Context, bucket, key string, expiresIn time.Duration) (string, error) {
+	return "", ErrSignedURLUnsupported
+}
+
+func (s *LocalBlobStore) PublicURL(bucket, key string) string {
+	return s.publicURL + "/" + bucket + "/" + escapeObjectPath(key)
+}
+
+// path maps a bucket key onto the filesystem, refusing keys that escape the bucket
+func (s *LocalBlobStore) path(bucket, key string) (string, error) {
+	root := filepath.Join(s.rootDir, bucket)
+	path := filepath.Join(root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key: %s", key)
+	}
+	return path, nil
+}
+
+func localObjectInfo(key, path string, stat os.FileInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  mime.TypeByExtension(filepath.Ext(path)),
+		ETag:         fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
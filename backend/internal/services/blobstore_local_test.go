@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"audio-series-app/backend/internal/config"
+)
+
+func TestLocalBlobStorePathContainment(t *testing.T) {
+	store := &LocalBlobStore{rootDir: t.TempDir()}
+
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{"series/episode.mp3", false},
+		{"series/../episode.mp3", false},
+		{"/series/episode.mp3", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../audio-other/episode.mp3", true},
+		{"series/../../escape.mp3", true},
+		{"series/../../../etc/passwd", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			path, err := store.path("audio", tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("path(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+			root := filepath.Join(store.rootDir, "audio") + string(filepath.Separator)
+			if err == nil && !strings.HasPrefix(path, root) {
+				t.Errorf("path(%q) = %s, outside %s", tt.key, path, root)
+			}
+		})
+	}
+}
+
+func TestLocalBlobStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalBlobStore(&config.Config{LocalStorageDir: t.TempDir(), PublicAPIURL: "http://localhost:3003/api/v1/"})
+
+	for _, key := range []string{"ep/1/master.m3u8", "ep/1/48k/segment_0000.ts", "ep/10/master.m3u8"} {
+		if err := store.Put(ctx, "hls", key, "", strings.NewReader(key)); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+	// A write that never finished must not show up as an object
+	if err := os.WriteFile(filepath.Join(store.rootDir, "hls", "ep", "1", ".upload-123"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := store.GetRange(ctx, "hls", "ep/1/master.m3u8", 3, 5)
+	if err != nil {
+		t.Fatalf("GetRange() error = %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if string(got) != "1/mas" {
+		t.Errorf("GetRange() = %q, want %q", got, "1/mas")
+	}
+
+	objects, err := store.List(ctx, "hls", "ep/1/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("List(ep/1/) returned %d objects, want 2: %v", len(objects), objects)
+	}
+
+	if err := store.Delete(ctx, "hls", "ep/1/master.m3u8"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "hls", "ep/1/master.m3u8"); err != nil {
+		t.Errorf("Delete() of a missing object = %v, want nil", err)
+	}
+	if _, err := store.Stat(ctx, "hls", "ep/1/master.m3u8"); err == nil {
+		t.Error("Stat() after Delete() succeeded")
+	}
+
+	if got, want := store.PublicURL("covers", "s/cover 1.jpg"), "http://localhost:3003/api/v1/storage/covers/s/cover%201.jpg"; got != want {
+		t.Errorf("PublicURL() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+)
+
+// S3BlobStore stores objects in an S3-compatible service such as AWS S3, MinIO or
+// Cloudflare R2. Requests are signed with AWS Signature Version 4.
+type S3BlobStore struct {
+	client          *http.Client
+	endpoint        *url.URL
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+	publicURL       string
+}
+
+func NewS3BlobStore(cfg *config.Config) *S3BlobStore {
+	endpoint, err := url.Parse(cfg.S3Endpoint)
+	if err != nil || endpoint.Host == "" {
+		endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("s3.%s.amazonaws.com", cfg.S3Region)}
+	}
+
+	return &S3BlobStore{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		endpoint:        endpoint,
+		region:          cfg.S3Region,
+		accessKeyID:     cfg.S3AccessKeyID,
+		secretAccessKey: cfg.S3SecretAccessKey,
+		pathStyle:       cfg.S3ForcePathStyle,
+		publicURL:       strings.TrimSuffix(cfg.S3PublicURL, "/"),
+	}
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, bucket, key, contentType string, body io.Reader) error {
+	// S3 rejects chunked uploads without a declared length, so unknown-length bodies are spooled first
+	size, ok := readerSize(body)
+	if !ok {
+		tmp, err := os.CreateTemp("", "s3-upload-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %v", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if size, err = io.Copy(tmp, body); err != nil {
+			return fmt.Errorf("failed to buffer upload: %v", err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind upload: %v", err)
+		}
+		body = tmp
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", s.objectURL(bucket, key, nil).String(), io.NopCloser(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req, true)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.GetRange(ctx, bucket, key, 0, -1)
+}
+
+func (s *S3BlobStore) GetRange(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.objectURL(bucket, key, nil).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if byteRange := rangeHeader(offset, length); byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+
+	resp, err := s.do(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *S3BlobStore) Stat(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "HEAD", s.objectURL(bucket, key, nil).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := s.do(req, false)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return objectInfoFromHeaders(key, resp), nil
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, bucket, key string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", s.objectURL(bucket, key, nil).String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := s.do(req, false)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *S3BlobStore) List(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	continuationToken := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", s.objectURL(bucket, "", query).String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+
+		resp, err := s.do(req, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+			Contents              []struct {
+				Key          string    `xml:"Key"`
+				LastModified time.Time `xml:"LastModified"`
+				ETag         string    `xml:"ETag"`
+				Size         int64     `xml:"Size"`
+			} `xml:"Contents"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list response: %v", err)
+		}
+
+		for _, item := range result.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          item.Key,
+				Size:         item.Size,
+				ETag:         item.ETag,
+				LastModified: item.LastModified,
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// SignedURL returns a presigned GET URL
+func (s *S3BlobStore) SignedURL(ctx context.Context, bucket, key string, expiresIn time.Duration) (string, error) {
+	now := time.Now().UTC()
+	query := url.Values{
+		"X-Amz-Algorithm":     {s3Algorithm},
+		"X-Amz-Credential":    {s.accessKeyID + "/" + s.credentialScope(now)},
+		"X-Amz-Date":          {now.Format(s3TimeFormat)},
+		"X-Amz-Expires":       {strconv.Itoa(int(expiresIn.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+
+	objectURL := s.objectURL(bucket, key, query)
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		awsURIEncode(objectURL.Path, false),
+		canonicalQueryString(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, canonicalRequest))
+	objectURL.RawQuery = canonicalQueryString(query)
+
+	return objectURL.String(), nil
+}
+
+func (s *S3BlobStore) PublicURL(bucket, key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + bucket + "/" + escapeObjectPath(key)
+	}
+	return s.objectURL(bucket, key, nil).String()
+}
+
+// objectURL builds the path-style or virtual-hosted-style URL for a key
+func (s *S3BlobStore) objectURL(bucket, key string, query url.Values) *url.URL {
+	objectURL := *s.endpoint
+	if s.pathStyle {
+		objectURL.Path = "/" + bucket + "/" + key
+	} else {
+		objectURL.Host = bucket + "." + s.endpoint.Host
+		objectURL.Path = "/" + key
+	}
+	objectURL.RawPath = awsURIEncode(objectURL.Path, false)
+	if query != nil {
+		objectURL.RawQuery = canonicalQueryString(query)
+	}
+	return &objectURL
+}
+
+// do signs and sends the request. Streaming requests skip the client timeout; the
+// request context ends them instead.
+func (s *S3BlobStore) do(req *http.Request, streaming bool) (*http.Response, error) {
+	s.sign(req)
+
+	client := s.client
+	if streaming {
+		client = &http.Client{Transport: s.client.Transport}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp, nil
+}
+
+// sign adds a Signature Version 4 Authorization header. Payloads are left unsigned
+// so uploads can stream without being hashed first.
+func (s *S3BlobStore) sign(req *http.Request) {
+	now := time.Now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+		"x-amz-date:" + now.Format(s3TimeFormat) + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsURIEncode(req.URL.Path, false),
+		canonicalQueryString(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, s.credentialScope(now), signedHeaders, s.signature(now, canonicalRequest)))
+}
+
+func (s *S3BlobStore) credentialScope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", now.Format(s3DateFormat), s.region)
+}
+
+func (s *S3BlobStore) signature(now time.Time, canonicalRequest string) string {
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeFormat),
+		s.credentialScope(now),
+		hex.EncodeToString(hashedRequest[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQueryString sorts and encodes query parameters as SigV4 requires
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, awsURIEncode(key, true)+"="+awsURIEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode percent-encodes everything except unreserved characters, and slashes unless encodeSlash is set
+func awsURIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// readerSize returns the number of bytes left in body when it can be known without reading it
+func readerSize(body io.Reader) (int64, bool) {
+	switch b := body.(type) {
+	case interface{ Len() int }:
+		return int64(b.Len()), true
+	case io.Seeker:
+		current, err := b.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := b.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		}
+		if _, err := b.Seek(current, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - current, true
+	}
+	return 0, false
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+)
+
+// supabaseListPageSize is the largest page the Storage list endpoint returns
+const supabaseListPageSize = 1000
+
+// SupabaseBlobStore stores objects through the Supabase Storage REST API
+type SupabaseBlobStore struct {
+	client     *http.Client
+	storageURL string
+	apiKey     string
+}
+
+func NewSupabaseBlobStore(cfg *config.Config) *SupabaseBlobStore {
+	return &SupabaseBlobStore{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		storageURL: cfg.GetStorageURL(),
+		apiKey:     cfg.SupabaseServiceKey,
+	}
+}
+
+func (s *SupabaseBlobStore) Put(ctx context.Context, bucket, key, contentType string, body io.Reader) error {
+	req, err := s.newRequest(ctx, "POST", fmt.Sprintf("/object/%s/%s", bucket, escapeObjectPath(key)), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("x-upsert", "true")
+
+	resp, err := s.do(req, true)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *SupabaseBlobStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.GetRange(ctx, bucket, key, 0, -1)
+}
+
+func (s *SupabaseBlobStore) GetRange(ctx context.Context, bucket, key string, offset, length int64) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, "GET", fmt.Sprintf("/object/authenticated/%s/%s", bucket, escapeObjectPath(key)), nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange := rangeHeader(offset, length); byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+
+	resp, err := s.do(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *SupabaseBlobStore) Stat(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	req, err := s.newRequest(ctx, "HEAD", fmt.Sprintf("/object/authenticated/%s/%s", bucket, escapeObjectPath(key)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, false)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return objectInfoFromHeaders(key, resp), nil
+}
+
+func (s *SupabaseBlobStore) Delete(ctx context.Context, bucket, key string) error {
+	body, err := json.Marshal(map[string][]string{"prefixes": {key}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	req, err := s.newRequest(ctx, "DELETE", "/object/"+bucket, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req, false)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (s *SupabaseBlobStore) List(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for offset := 0; ; offset += supabaseListPageSize {
+		body, err := json.Marshal(map[string]interface{}{
+			"prefix": prefix,
+			"limit":  supabaseListPageSize,
+			"offset": offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %v", err)
+		}
+
+		req, err := s.newRequest(ctx, "POST", "/object/list/"+bucket, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.do(req, false)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []struct {
+			Name      string `json:"name"`
+			UpdatedAt string `json:"updated_at"`
+			Metadata  *struct {
+				Size     int64  `json:"size"`
+				Mimetype string `json:"mimetype"`
+				ETag     string `json:"eTag"`
+			} `json:"metadata"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list response: %v", err)
+		}
+
+		for _, item := range page {
+			// Entries without metadata are folders
+			if item.Metadata == nil {
+				continue
+			}
+			info := ObjectInfo{
+				Key:         joinKey(prefix, item.Name),
+				Size:        item.Metadata.Size,
+				ContentType: item.Metadata.Mimetype,
+				ETag:        item.Metadata.ETag,
+			}
+			if updatedAt, err := time.Parse(time.RFC3339, item.UpdatedAt); err == nil {
+				info.LastModified = updatedAt
+			}
+			objects = append(objects, info)
+		}
+
+		if len(page) < supabaseListPageSize {
+			return objects, nil
+		}
+	}
+}
+
+func (s *SupabaseBlobStore) SignedURL(ctx context.Context, bucket, key string, expiresIn time.Duration) (string, error) {
+	body, err := json.Marshal(map[string]int{"expiresIn": int(expiresIn.Seconds())})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	req, err := s.newRequest(ctx, "POST", fmt.Sprintf("/object/sign/%s/%s", bucket, escapeObjectPath(key)), bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		SignedURL string `json:"signedURL"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode sign response: %v", err)
+	}
+
+	// Supabase returns a path relative to the storage API root
+	return s.storageURL + result.SignedURL, nil
+}
+
+func (s *SupabaseBlobStore) PublicURL(bucket, key string) string {
+	return fmt.Sprintf("%s/object/public/%s/%s", s.storageURL, bucket, escapeObjectPath(key))
+}
+
+func (s *SupabaseBlobStore) newRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	if s.storageURL == "" {
+		return nil, fmt.Errorf("storage URL is not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.storageURL+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("apikey", s.apiKey)
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	return req, nil
+}
+
+// do sends the request and turns non-2xx responses into errors. Streaming requests
+// skip the client timeout, since audio bodies and uploads can outlive it; the request
+// context ends them instead.
+func (s *SupabaseBlobStore) do(req *http.Request, streaming bool) (*http.Response, error) {
+	client := s.client
+	if streaming {
+		client = &http.Client{Transport: s.client.Transport}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return resp, nil
+}
+
+// rangeHeader builds a Range header value; it is empty when the whole object is wanted
+func rangeHeader(offset, length int64) string {
+	if length >= 0 {
+		return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+	if offset > 0 {
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+	return ""
+}
+
+// objectInfoFromHeaders reads object metadata from a HEAD response
+func objectInfoFromHeaders(key string, resp *http.Response) *ObjectInfo {
+	info := &ObjectInfo{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ETag:        resp.Header.Get("ETag"),
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = lastModified
+	}
+	if info.Size < 0 {
+		info.Size, _ = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	}
+	return info
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	if prefix[len(prefix)-1] == '/' {
+		return prefix + name
+	}
+	return prefix + "/" + name
+}
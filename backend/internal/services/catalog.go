@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCatalogQuery is returned for an unknown sort or field, or a cursor that cannot be used
+var ErrInvalidCatalogQuery = errors.New("invalid catalog query")
+
+const (
+	defaultCatalogLimit = 20
+	maxCatalogLimit     = 100
+
+	// trendingWindow is how far back play events count towards a series' trending score
+	trendingWindow = 7 * 24 * time.Hour
+	// trendingHalfLife is how quickly a play's weight in the trending score decays
+	trendingHalfLife = 48 * time.Hour
+)
+
+// catalogSort is the SQL expression a catalog sort orders by, and its type for cursor comparisons
+type catalogSort struct {
+	expr    string
+	sqlType string
+}
+
+var catalogSorts = map[string]catalogSort{
+	"newest":   {expr: "s.created_at", sqlType: "timestamptz"},
+	"popular":  {expr: "COALESCE(r.play_count, 0)", sqlType: "bigint"},
+	"trending": {expr: "COALESCE(r.trending_score, 0)", sqlType: "double precision"},
+	"rating":   {expr: "COALESCE(r.rating_average, 0)", sqlType: "numeric"},
+}
+
+// catalogFields are the series fields a sparse fieldset may ask for
+var catalogFields = map[string]bool{
+	"id": true, "title": true, "description": true, "cover_image": true, "cover_images": true,
+	"author": true, "category": true, "categories": true, "language": true, "display_language": true, "is_premium": true, "total_episodes": true, "follower_count": true,
+	"rating_average": true, "rating_count": true,
+	"publish_at": true, "created_at": true, "updated_at": true,
+}
+
+// catalogCursor is the position after the last series of a page. It is handed to clients
+// base64-encoded and only valid for the sort it was made with.
+type catalogCursor struct {
+	Sort string    `json:"s"`
+	Key  string    `json:"k"`
+	ID   uuid.UUID `json:"id"`
+}
+
+type CatalogService struct {
+	supabase *SupabaseService
+}
+
+func NewCatalogService(supabase *SupabaseService) *CatalogService {
+	return &CatalogService{
+		supabase: supabase,
+	}
+}
+
+// GetCatalog returns a page of published series matching the query's filters
+func (s *CatalogService) GetCatalog(ctx context.Context, query *models.CatalogQuery) (*models.SeriesPage, error) {
+	if query.Sort == "" {
+		query.Sort = "newest"
+	}
+	sort, ok := catalogSorts[query.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: sort must be newest, popular, trending or rating", ErrInvalidCatalogQuery)
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultCatalogLimit
+	}
+	if query.Limit > maxCatalogLimit {
+		query.Limit = maxCatalogLimit
+	}
+
+	// Languages are matched by base language, so hi-IN finds Hindi series tagged hi and pt finds pt-BR
+	for _, filter := range []*string{&query.Language, &query.AudioLanguage} {
+		*filter = strings.TrimSpace(*filter)
+		if *filter == "" {
+			continue
+		}
+		if !languageTagPattern.MatchString(*filter) {
+			return nil, fmt.Errorf("%w: malformed language tag %q", ErrInvalidCatalogQuery, *filter)
+		}
+		*filter = baseLanguage(*filter)
+	}
+
+	for _, field := range query.Fields {
+		if !catalogFields[field] {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidCatalogQuery, field)
+		}
+	}
+
+	var cursorKey *string
+	var cursorID uuid.UUID
+	if query.Cursor != "" {
+		cursor, err := decodeCatalogCursor(query.Cursor)
+		if err != nil || cursor.Sort != query.Sort {
+			return nil, fmt.Errorf("%w: cursor does not belong to this sort", ErrInvalidCatalogQuery)
+		}
+		cursorKey = &cursor.Key
+		cursorID = cursor.ID
+	}
+
+	// One extra row tells whether another page follows
+	series, sortKeys, err := s.supabase.GetCatalogSeries(ctx, query, sort.expr, sort.sqlType, cursorKey, cursorID, query.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.SeriesPage{Pagination: models.PageInfo{Limit: query.Limit}}
+	if len(series) > query.Limit {
+		series = series[:query.Limit]
+		last := series[len(series)-1]
+		next, err := encodeCatalogCursor(&catalogCursor{Sort: query.Sort, Key: sortKeys[len(series)-1], ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+		page.Pagination.HasMore = true
+		page.Pagination.NextCursor = &next
+	}
+
+	if err := attachSeriesCategories(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := attachFollowerCounts(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := attachRatings(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := localizeSeries(ctx, s.supabase, series, query.Languages); err != nil {
+		return nil, err
+	}
+
+	if len(query.Fields) == 0 {
+		page.Data = series
+		return page, nil
+	}
+
+	sparse, err := selectSeriesFields(series, query.Fields)
+	if err != nil {
+		return nil, err
+	}
+	page.Data = sparse
+
+	return page, nil
+}
+
+// RefreshRankings recomputes the play counts, trending scores and ratings the popular,
+// trending and rating sorts order by
+func (s *CatalogService) RefreshRankings(ctx context.Context) error {
+	now := time.Now()
+	return s.supabase.RefreshSeriesRankings(ctx, now, now.Add(-trendingWindow), trendingHalfLife)
+}
+
+// RunRankingRefresh refreshes the catalog rankings every interval until ctx is cancelled
+func (s *CatalogService) RunRankingRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RefreshRankings(ctx); err != nil {
+			log.Printf("Catalog ranking refresh failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func encodeCatalogCursor(cursor *catalogCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCatalogCursor(encoded string) (*catalogCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &catalogCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Key == "" || cursor.ID == uuid.Nil {
+		return nil, errors.New("incomplete cursor")
+	}
+
+	return cursor, nil
+}
+
+// selectSeriesFields returns each series as a map holding only the given fields, plus its ID
+func selectSeriesFields(series []*models.Series, fields []string) ([]map[string]interface{}, error) {
+	sparse := make([]map[string]interface{}, 0, len(series))
+	for _, item := range series {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode series: %w", err)
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(data, &full); err != nil {
+			return nil, fmt.Errorf("failed to decode series: %w", err)
+		}
+
+		selected := map[string]interface{}{"id": full["id"]}
+		for _, field := range fields {
+			if value, ok := full[field]; ok {
+				selected[field] = value
+			}
+		}
+		sparse = append(sparse, selected)
+	}
+
+	return sparse, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCatalogCursorRoundTrip(t *testing.T) {
+	tests := []catalogCursor{
+		{Sort: "newest", Key: "2024-03-01 12:00:00+00", ID: uuid.New()},
+		{Sort: "popular", Key: "1234", ID: uuid.New()},
+		{Sort: "rating", Key: "4.25", ID: uuid.New()},
+	}
+
+	for _, want := range tests {
+		t.Run(want.Sort, func(t *testing.T) {
+			encoded, err := encodeCatalogCursor(&want)
+			if err != nil {
+				t.Fatalf("encodeCatalogCursor() error = %v", err)
+			}
+
+			got, err := decodeCatalogCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCatalogCursor() error = %v", err)
+			}
+			if *got != want {
+				t.Errorf("decodeCatalogCursor() = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeCatalogCursorInvalid(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"newest"}`))},
+		{"not JSON", encode("newest|1234")},
+		{"missing key", encode(`{"s":"popular","id":"` + uuid.New().String() + `"}`)},
+		{"missing ID", encode(`{"s":"popular","k":"1234"}`)},
+		{"malformed ID", encode(`{"s":"popular","k":"1234","id":"abc"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := decodeCatalogCursor(tt.encoded); err == nil {
+				t.Errorf("decodeCatalogCursor(%q) = %+v, want error", tt.encoded, cursor)
+			}
+		})
+	}
+}
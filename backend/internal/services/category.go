@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a category does not exist
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrInvalidCategory is returned for a malformed slug or an impossible place in the taxonomy
+	ErrInvalidCategory = errors.New("invalid category")
+	// ErrCategorySlugTaken is returned when another category already uses the slug
+	ErrCategorySlugTaken = errors.New("category slug already taken")
+	// ErrCategoryHasChildren is returned when deleting a genre that still has sub-genres
+	ErrCategoryHasChildren = errors.New("category has sub-categories")
+)
+
+// defaultCategoryLanguage is the language of categories' default names
+const defaultCategoryLanguage = "en"
+
+// categorySlugPattern matches lowercase words joined by hyphens, e.g. true-crime
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type CategoryService struct {
+	supabase *SupabaseService
+}
+
+func NewCategoryService(supabase *SupabaseService) *CategoryService {
+	return &CategoryService{
+		supabase: supabase,
+	}
+}
+
+// GetCategories returns the taxonomy with series counts, optionally limited to one kind.
+// Names are given in the first of languages the category has a display name for.
+func (s *CategoryService) GetCategories(ctx context.Context, kind string, languages []string) ([]*models.Category, error) {
+	categories, err := s.supabase.GetCategories(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(languages) > 0 {
+		for _, category := range categories {
+			category.Name = localizedCategoryName(category, languages)
+		}
+	}
+
+	return categories, nil
+}
+
+// CreateCategory adds a category to the taxonomy and records who added it
+func (s *CategoryService) CreateCategory(ctx context.Context, actorID uuid.UUID, req *models.CategoryRequest) (*models.Category, error) {
+	category := &models.Category{}
+	if err := s.applyCategoryRequest(ctx, category, req); err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "create", "category", uuid.Nil)
+	recordChange(entry, "slug", "", category.Slug)
+	recordChange(entry, "name", "", category.Name)
+	recordChange(entry, "kind", "", category.Kind)
+
+	if err := s.supabase.CreateCategory(ctx, category, entry); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// UpdateCategory replaces a category's details and records who changed what
+func (s *CategoryService) UpdateCategory(ctx context.Context, actorID, categoryID uuid.UUID, req *models.CategoryRequest) (*models.Category, error) {
+	category, err := s.supabase.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	previous := *category
+
+	if err := s.applyCategoryRequest(ctx, category, req); err != nil {
+		return nil, err
+	}
+
+	// A genre with sub-genres must stay a top-level genre
+	if req.Kind != "genre" || req.ParentID != nil {
+		genres, err := s.supabase.GetCategories(ctx, "genre")
+		if err != nil {
+			return nil, err
+		}
+		for _, genre := range genres {
+			if genre.ParentID != nil && *genre.ParentID == category.ID {
+				return nil, fmt.Errorf("%w: %s has sub-genres", ErrInvalidCategory, previous.Slug)
+			}
+		}
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "category", category.ID)
+	recordChange(entry, "slug", previous.Slug, category.Slug)
+	recordChange(entry, "name", previous.Name, category.Name)
+	recordChange(entry, "kind", previous.Kind, category.Kind)
+	recordChange(entry, "parent_id", uuidString(previous.ParentID), uuidString(category.ParentID))
+	recordChange(entry, "sort_order", previous.SortOrder, category.SortOrder)
+	if fmt.Sprint(previous.DisplayNames) != fmt.Sprint(category.DisplayNames) {
+		entry.Changes["display_names"] = models.AuditChange{Old: previous.DisplayNames, New: category.DisplayNames}
+	}
+	if len(entry.Changes) == 0 {
+		return category, nil
+	}
+
+	if err := s.supabase.UpdateCategory(ctx, category, entry); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory removes a category and untags its series. Genres must lose their
+// sub-genres first.
+func (s *CategoryService) DeleteCategory(ctx context.Context, actorID, categoryID uuid.UUID) error {
+	return s.supabase.DeleteCategory(ctx, categoryID, newAuditLogEntry(actorID, "delete", "category", categoryID))
+}
+
+// SetSeriesCategories replaces the series' genres and tags. Genres are stored ahead of tags;
+// the first genre becomes the series' primary one and its legacy category label.
+func (s *CategoryService) SetSeriesCategories(ctx context.Context, actorID, seriesID uuid.UUID, categoryIDs []uuid.UUID) ([]*models.Category, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	var genres, tags []*models.Category
+	seen := map[uuid.UUID]bool{}
+	for _, categoryID := range categoryIDs {
+		if seen[categoryID] {
+			continue
+		}
+		seen[categoryID] = true
+
+		category, err := s.supabase.GetCategoryByID(ctx, categoryID)
+		if errors.Is(err, ErrCategoryNotFound) {
+			return nil, fmt.Errorf("%w: category %s does not exist", ErrInvalidCategory, categoryID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if category.Kind == "genre" {
+			genres = append(genres, category)
+		} else {
+			tags = append(tags, category)
+		}
+	}
+	categories := append(genres, tags...)
+
+	current, err := s.supabase.GetSeriesCategories(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "series", series.ID)
+	recordChange(entry, "categories", categorySlugs(current[series.ID]), categorySlugs(categories))
+	if len(entry.Changes) == 0 {
+		return categories, nil
+	}
+
+	var primaryName string
+	if len(genres) > 0 {
+		primaryName = genres[0].Name
+	}
+
+	ids := make([]uuid.UUID, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	if err := s.supabase.SetSeriesCategories(ctx, series.ID, ids, primaryName, entry); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// applyCategoryRequest validates req and copies it onto category
+func (s *CategoryService) applyCategoryRequest(ctx context.Context, category *models.Category, req *models.CategoryRequest) error {
+	slug := req.Slug
+	if slug == "" {
+		slug = slugify(req.Name)
+	}
+	if !categorySlugPattern.MatchString(slug) {
+		return fmt.Errorf("%w: slug must be lowercase letters, digits and hyphens", ErrInvalidCategory)
+	}
+
+	existing, err := s.supabase.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != category.ID {
+		return fmt.Errorf("%w: %s", ErrCategorySlugTaken, slug)
+	}
+
+	if req.ParentID != nil {
+		if req.Kind != "genre" {
+			return fmt.Errorf("%w: only genres can have a parent", ErrInvalidCategory)
+		}
+		if *req.ParentID == category.ID {
+			return fmt.Errorf("%w: a category cannot be its own parent", ErrInvalidCategory)
+		}
+
+		parent, err := s.supabase.GetCategoryByID(ctx, *req.ParentID)
+		if errors.Is(err, ErrCategoryNotFound) {
+			return fmt.Errorf("%w: parent does not exist", ErrInvalidCategory)
+		}
+		if err != nil {
+			return err
+		}
+		// The taxonomy is two levels deep: genres and their sub-genres
+		if parent.Kind != "genre" || parent.ParentID != nil {
+			return fmt.Errorf("%w: parent must be a top-level genre", ErrInvalidCategory)
+		}
+	}
+
+	for lang, name := range req.DisplayNames {
+		if len(lang) < 2 || len(lang) > 10 || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: display names need a language tag and a name", ErrInvalidCategory)
+		}
+	}
+
+	category.Slug = slug
+	category.Name = strings.TrimSpace(req.Name)
+	category.Kind = req.Kind
+	category.ParentID = req.ParentID
+	category.DisplayNames = req.DisplayNames
+	if category.DisplayNames == nil {
+		category.DisplayNames = map[string]string{}
+	}
+	category.SortOrder = req.SortOrder
+
+	return nil
+}
+
+// attachSeriesCategories loads the categories of every series in one query
+func attachSeriesCategories(ctx context.Context, supabase *SupabaseService, series []*models.Series) error {
+	ids := make([]uuid.UUID, len(series))
+	for i, item := range series {
+		ids[i] = item.ID
+	}
+
+	categories, err := supabase.GetSeriesCategories(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range series {
+		item.Categories = categories[item.ID]
+	}
+
+	return nil
+}
+
+// localizedCategoryName returns the category's name in the best match for languages, falling
+// back from a regional tag to its base language (pt-BR to pt) and then to the default name,
+// which is in English
+func localizedCategoryName(category *models.Category, languages []string) string {
+	available := []string{defaultCategoryLanguage}
+	for tag := range category.DisplayNames {
+		available = append(available, tag)
+	}
+	sort.Strings(available[1:])
+
+	if tag, ok := matchLanguage(available, languages); ok && tag != defaultCategoryLanguage {
+		return category.DisplayNames[tag]
+	}
+	return category.Name
+}
+
+// slugify lowercases ASCII letters and digits and joins the words between them with hyphens.
+// Names without any, such as ones written only in Devanagari, need an explicit slug.
+func slugify(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
+
+// categorySlugs lists the categories' slugs, in order, for the audit log
+func categorySlugs(categories []*models.Category) string {
+	slugs := make([]string, len(categories))
+	for i, category := range categories {
+		slugs[i] = category.Slug
+	}
+	return strings.Join(slugs, ",")
+}
+
+// uuidString formats an optional ID for the audit log; unset IDs are empty
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
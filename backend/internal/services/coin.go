@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"audio-series-app/backend/internal/models"
 
@@ -37,6 +38,19 @@ func (s *CoinService) UnlockEpisode(ctx context.Context, userIDStr, episodeIDStr
 		return fmt.Errorf("failed to get episode: %w", err)
 	}
 
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get series: %w", err)
+	}
+	now := time.Now()
+	if !isReleased(episode, series, now) {
+		return ErrEpisodeNotFound
+	}
+	// Coins buy access to locked episodes and to early access; nothing once the episode is free
+	if isFree(episode, now) {
+		return ErrEpisodeFree
+	}
+
 	// Check if user already owns the episode
 	isOwned, err := s.supabase.HasUserPurchasedEpisode(ctx, userID, episodeID)
 	if err != nil {
@@ -108,15 +122,27 @@ func (s *CoinService) UnlockSeries(ctx context.Context, userIDStr, seriesIDStr s
 		return fmt.Errorf("invalid series ID: %w", err)
 	}
 
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get series: %w", err)
+	}
+	now := time.Now()
+	if series.DeletedAt != nil || !isPublished(series.Status, series.PublishAt, now) {
+		return ErrSeriesNotFound
+	}
+
 	// Get all episodes in the series
 	episodes, err := s.supabase.GetEpisodesBySeriesID(ctx, seriesID)
 	if err != nil {
 		return fmt.Errorf("failed to get series episodes: %w", err)
 	}
 
-	// Calculate total cost
+	// Calculate total cost; free episodes, including those past their early access, cost nothing
 	totalCost := 0
 	for _, episode := range episodes {
+		if isFree(episode, now) {
+			continue
+		}
 		isOwned, err := s.supabase.HasUserPurchasedEpisode(ctx, userID, episode.ID)
 		if err != nil {
 			return fmt.Errorf("failed to check episode ownership: %w", err)
@@ -149,6 +175,9 @@ func (s *CoinService) UnlockSeries(ctx context.Context, userIDStr, seriesIDStr s
 
 	// Create purchase records for each episode
 	for _, episode := range episodes {
+		if isFree(episode, now) {
+			continue
+		}
 		isOwned, err := s.supabase.HasUserPurchasedEpisode(ctx, userID, episode.ID)
 		if err != nil {
 			return fmt.Errorf("failed to check episode ownership: %w", err)
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrCommentNotFound is returned when a comment does not exist or is hidden from the caller
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrInvalidComment is returned for a comment positioned after the episode ends, a reply to
+	// a reply, or a comment with no text
+	ErrInvalidComment = errors.New("invalid comment")
+	// ErrCommentsLocked is returned when a user without access to a locked episode tries to
+	// read or join its comments
+	ErrCommentsLocked = errors.New("comments locked")
+	// ErrCommentRateLimited is returned when a user posts more comments than the rate limits allow
+	ErrCommentRateLimited = errors.New("comment rate limit exceeded")
+	// ErrInvalidCommentReport is returned when users report their own comment
+	ErrInvalidCommentReport = errors.New("invalid comment report")
+)
+
+const (
+	defaultCommentLimit = 20
+	maxCommentLimit     = 100
+
+	// commentReplyPreview is how many replies are returned with each top-level comment
+	commentReplyPreview = 3
+	// commentFlagThreshold is how many reports flag a comment for moderators
+	commentFlagThreshold = 3
+	// deletedCommentBody replaces the body of a deleted comment that is kept for its replies
+	deletedCommentBody = "[deleted]"
+)
+
+// commentSorts are the orders top-level comments can be listed in. "position" follows the
+// episode's timeline, with comments on the whole episode last.
+var commentSorts = map[string]string{
+	"newest":   "c.created_at DESC, c.id",
+	"oldest":   "c.created_at, c.id",
+	"top":      "c.like_count DESC, c.created_at DESC, c.id",
+	"position": "c.position NULLS LAST, c.created_at, c.id",
+}
+
+// commentRateLimit caps how many comments and replies a user may post within a window
+type commentRateLimit struct {
+	Name   string
+	Window time.Duration
+	Max    int
+}
+
+type CommentService struct {
+	supabase          *SupabaseService
+	episodeService    *EpisodeService
+	filter            *ContentFilter
+	commentsPerMinute int
+	commentsPerHour   int
+}
+
+func NewCommentService(cfg *config.Config, supabase *SupabaseService, episodeService *EpisodeService) *CommentService {
+	return &CommentService{
+		supabase:          supabase,
+		episodeService:    episodeService,
+		filter:            NewContentFilter(cfg.BlockedWords),
+		commentsPerMinute: cfg.CommentsPerMinute,
+		commentsPerHour:   cfg.CommentsPerHour,
+	}
+}
+
+// GetEpisodeComments returns a page of the episode's top-level comments in the sort order,
+// each with its first few replies. Comments on a locked episode are only shown to users
+// who have access to it.
+func (s *CommentService) GetEpisodeComments(ctx context.Context, userID, episodeID uuid.UUID, sort string, limit, offset int) ([]*models.Comment, error) {
+	if _, err := s.accessibleEpisode(ctx, userID, episodeID); err != nil {
+		return nil, err
+	}
+
+	orderBy, ok := commentSorts[sort]
+	if !ok {
+		orderBy = commentSorts["newest"]
+	}
+	limit, offset = commentPage(limit, offset)
+
+	comments, err := s.supabase.GetEpisodeComments(ctx, episodeID, userID, orderBy, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	parentIDs := make([]uuid.UUID, len(comments))
+	byID := make(map[uuid.UUID]*models.Comment, len(comments))
+	for i, comment := range comments {
+		parentIDs[i] = comment.ID
+		byID[comment.ID] = comment
+		viewerComment(comment, userID)
+	}
+
+	replies, err := s.supabase.GetCommentReplies(ctx, parentIDs, userID, commentReplyPreview, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		viewerComment(reply, userID)
+		parent := byID[*reply.ParentID]
+		parent.Replies = append(parent.Replies, reply)
+	}
+
+	return comments, nil
+}
+
+// GetReplies returns a page of the replies to a top-level comment, oldest first
+func (s *CommentService) GetReplies(ctx context.Context, userID, commentID uuid.UUID, limit, offset int) ([]*models.Comment, error) {
+	comment, err := s.visibleComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.ParentID != nil {
+		return []*models.Comment{}, nil
+	}
+
+	limit, offset = commentPage(limit, offset)
+	replies, err := s.supabase.GetCommentReplies(ctx, []uuid.UUID{comment.ID}, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		viewerComment(reply, userID)
+	}
+
+	return replies, nil
+}
+
+// AddComment posts the user's comment on an episode they have access to, at position
+// seconds into it or on the whole episode when position is nil
+func (s *CommentService) AddComment(ctx context.Context, userID, episodeID uuid.UUID, req *models.CommentRequest) (*models.Comment, error) {
+	episode, err := s.accessibleEpisode(ctx, userID, episodeID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Position != nil && episode.Duration > 0 && *req.Position >= episode.Duration {
+		return nil, fmt.Errorf("%w: position is after the episode ends", ErrInvalidComment)
+	}
+
+	return s.create(ctx, &models.Comment{
+		EpisodeID: episode.ID,
+		UserID:    userID,
+		Position:  req.Position,
+		Body:      strings.TrimSpace(req.Body),
+	})
+}
+
+// Reply posts the user's reply to a top-level comment. Replies cannot be replied to.
+func (s *CommentService) Reply(ctx context.Context, userID, commentID uuid.UUID, req *models.CommentReplyRequest) (*models.Comment, error) {
+	parent, err := s.visibleComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.ParentID != nil {
+		return nil, fmt.Errorf("%w: replies cannot be replied to", ErrInvalidComment)
+	}
+
+	return s.create(ctx, &models.Comment{
+		EpisodeID: parent.EpisodeID,
+		UserID:    userID,
+		ParentID:  &parent.ID,
+		Body:      strings.TrimSpace(req.Body),
+	})
+}
+
+// DeleteComment removes the user's own comment. A comment with replies stays in the thread
+// as a tombstone, so the replies are kept.
+func (s *CommentService) DeleteComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	return s.supabase.DeleteComment(ctx, userID, commentID, deletedCommentBody)
+}
+
+// LikeComment records the user's like of a comment they can see
+func (s *CommentService) LikeComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := s.visibleComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	return s.supabase.LikeComment(ctx, userID, comment.ID)
+}
+
+// UnlikeComment removes the user's like of a comment they can see
+func (s *CommentService) UnlikeComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := s.visibleComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	return s.supabase.UnlikeComment(ctx, userID, comment.ID)
+}
+
+// ReportComment records the user's report of someone else's comment. Enough reports flag the
+// comment for moderators.
+func (s *CommentService) ReportComment(ctx context.Context, userID, commentID uuid.UUID, req *models.ReportRequest) error {
+	comment, err := s.visibleComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted {
+		return ErrCommentNotFound
+	}
+	if comment.UserID == userID {
+		return fmt.Errorf("%w: you cannot report your own comment", ErrInvalidCommentReport)
+	}
+
+	report := &models.CommentReport{
+		CommentID: comment.ID,
+		UserID:    userID,
+		Reason:    req.Reason,
+		Details:   strings.TrimSpace(req.Details),
+	}
+
+	return s.supabase.ReportComment(ctx, report, commentFlagThreshold)
+}
+
+// GetModerationQueue returns a page of comments for moderators. An empty status returns the
+// flagged and reported comments still waiting for a decision.
+func (s *CommentService) GetModerationQueue(ctx context.Context, status string, limit, offset int) ([]*models.Comment, error) {
+	limit, offset = commentPage(limit, offset)
+	return s.supabase.GetCommentModerationQueue(ctx, status, limit, offset)
+}
+
+// GetCommentReports returns the comment's reports, newest first
+func (s *CommentService) GetCommentReports(ctx context.Context, commentID uuid.UUID) ([]*models.CommentReport, error) {
+	if _, err := s.supabase.GetCommentByID(ctx, commentID, uuid.Nil); err != nil {
+		return nil, err
+	}
+	return s.supabase.GetCommentReports(ctx, commentID)
+}
+
+// ModerateComment shows, flags or hides a comment and records who changed it. Hidden
+// comments, and the replies to them, leave the public thread.
+func (s *CommentService) ModerateComment(ctx context.Context, actorID, commentID uuid.UUID, status string) (*models.Comment, error) {
+	comment, err := s.supabase.GetCommentByID(ctx, commentID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == status {
+		return comment, nil
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "comment", comment.ID)
+	recordChange(entry, "status", comment.Status, status)
+	comment.Status = status
+
+	if err := s.supabase.SetCommentStatus(ctx, comment, entry); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// create checks a new comment or reply against the content filter and the user's rate
+// limits, then saves it and returns it as its author sees it
+func (s *CommentService) create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	if comment.Body == "" {
+		return nil, fmt.Errorf("%w: comment is empty", ErrInvalidComment)
+	}
+	if err := s.filter.Check(comment.Body); err != nil {
+		return nil, err
+	}
+
+	if err := s.supabase.CreateComment(ctx, comment, s.rateLimits()); err != nil {
+		return nil, err
+	}
+
+	created, err := s.supabase.GetCommentByID(ctx, comment.ID, comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	viewerComment(created, comment.UserID)
+
+	return created, nil
+}
+
+// rateLimits returns how many comments and replies a user may post in the last minute and
+// the last hour. A limit of zero or less is not enforced.
+func (s *CommentService) rateLimits() []commentRateLimit {
+	var limits []commentRateLimit
+	for _, limit := range []commentRateLimit{
+		{"minute", time.Minute, s.commentsPerMinute},
+		{"hour", time.Hour, s.commentsPerHour},
+	} {
+		if limit.Max > 0 {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// accessibleEpisode returns the released episode if the user has access to it: it is free,
+// they bought it, or an active subscription covers its premium series outside early access.
+// ErrCommentsLocked is returned otherwise.
+func (s *CommentService) accessibleEpisode(ctx context.Context, userID, episodeID uuid.UUID) (*models.Episode, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if !isReleased(episode, series, time.Now()) {
+		return nil, ErrEpisodeNotFound
+	}
+
+	source, err := s.episodeService.accessSource(ctx, userID, episode, series)
+	if err != nil {
+		return nil, err
+	}
+	if source == "" {
+		return nil, ErrCommentsLocked
+	}
+
+	return episode, nil
+}
+
+// visibleComment returns the comment if it is not hidden and the user has access to its episode
+func (s *CommentService) visibleComment(ctx context.Context, userID, commentID uuid.UUID) (*models.Comment, error) {
+	comment, err := s.supabase.GetCommentByID(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == "hidden" {
+		return nil, ErrCommentNotFound
+	}
+
+	if _, err := s.accessibleEpisode(ctx, userID, comment.EpisodeID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// commentPage clamps a page of comments to the allowed limits
+func commentPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultCommentLimit
+	}
+	if limit > maxCommentLimit {
+		limit = maxCommentLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// viewerComment clears what only admins, and the author, may see
+func viewerComment(comment *models.Comment, viewerID uuid.UUID) {
+	comment.EpisodeTitle = ""
+	comment.ReportCount = 0
+	if comment.Deleted {
+		comment.AuthorName = ""
+	}
+	if comment.UserID != viewerID {
+		comment.Status = ""
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrImageTooLarge is returned when a cover upload exceeds MaxCoverFileSize
+	ErrImageTooLarge = errors.New("image file too large")
+	// ErrUnsupportedImageType is returned when a cover upload is not a supported image format
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+)
+
+// allowedImageTypes are the sniffed content types accepted for cover art
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// coverVariants are the fixed sizes covers are produced in. Images are scaled to fill
+// the box and centre-cropped, so every variant has exactly these dimensions.
+var coverVariants = []struct {
+	name          string
+	width, height int
+}{
+	{"thumbnail", 160, 160},
+	{"card", 480, 480},
+	{"hero", 1280, 720},
+}
+
+// coverFormats are the encodings each variant is stored in
+var coverFormats = []struct {
+	name        string
+	extension   string
+	contentType string
+	codecArgs   []string
+}{
+	{"webp", ".webp", "image/webp", []string{"-c:v", "libwebp", "-quality", "80"}},
+	{"jpeg", ".jpg", "image/jpeg", []string{"-c:v", "mjpeg", "-pix_fmt", "yuvj420p", "-q:v", "3"}},
+}
+
+type CoverService struct {
+	config         *config.Config
+	supabase       *SupabaseService
+	storageService *StorageService
+}
+
+func NewCoverService(cfg *config.Config, supabase *SupabaseService, storageService *StorageService) *CoverService {
+	return &CoverService{
+		config:         cfg,
+		supabase:       supabase,
+		storageService: storageService,
+	}
+}
+
+// MaxUploadSize returns the largest cover image accepted, in bytes
+func (s *CoverService) MaxUploadSize() int64 {
+	return s.config.GetMaxCoverFileSize()
+}
+
+// UploadSeriesCover validates an uploaded image, resizes it into every cover variant and
+// format, stores them in the cover bucket and saves their URLs on the series. Each upload
+// goes under a new version prefix so clients never see a stale cached cover.
+func (s *CoverService) UploadSeriesCover(ctx context.Context, seriesID uuid.UUID, file io.Reader) (*models.Series, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "cover-"+seriesID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	sourcePath := filepath.Join(workDir, "source")
+	if err := s.saveUpload(file, sourcePath); err != nil {
+		return nil, err
+	}
+
+	version := uuid.New().String()
+	variants := models.CoverImageVariants{}
+	for _, variant := range coverVariants {
+		variants[variant.name] = map[string]string{}
+
+		for _, format := range coverFormats {
+			outputPath := filepath.Join(workDir, variant.name+format.extension)
+			filter := fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=increase,crop=%d:%d",
+				variant.width, variant.height, variant.width, variant.height)
+
+			args := []string{"-hide_banner", "-loglevel", "error", "-y", "-i", sourcePath, "-frames:v", "1", "-vf", filter}
+			args = append(args, format.codecArgs...)
+			args = append(args, outputPath)
+
+			cmd := exec.CommandContext(ctx, s.config.FFmpegPath, args...)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				return nil, fmt.Errorf("ffmpeg failed for %s %s: %v: %s", variant.name, format.name, err, strings.TrimSpace(string(output)))
+			}
+
+			objectPath := fmt.Sprintf("%s/%s/%s%s", seriesID, version, variant.name, format.extension)
+			if err := s.uploadVariant(ctx, outputPath, objectPath, format.contentType); err != nil {
+				return nil, err
+			}
+			variants[variant.name][format.name] = s.storageService.PublicObjectURL(s.config.CoverBucketName, objectPath)
+		}
+	}
+
+	// Older clients only read cover_image, so it points at the largest square variant
+	series.CoverImage = variants["card"]["jpeg"]
+	series.CoverImages = variants
+	if err := s.supabase.UpdateSeriesCover(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// saveUpload spools the upload to disk, enforcing the size limit, and checks that it is
+// an image ffprobe can read
+func (s *CoverService) saveUpload(file io.Reader, path string) error {
+	maxSize := s.MaxUploadSize()
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %v", err)
+	}
+	defer out.Close()
+
+	written, err := io.Copy(out, io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read upload: %v", err)
+	}
+	if written > maxSize {
+		return ErrImageTooLarge
+	}
+
+	header := make([]byte, 512)
+	n, err := out.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read upload: %v", err)
+	}
+
+	contentType := http.DetectContentType(header[:n])
+	if !allowedImageTypes[contentType] {
+		return fmt.Errorf("%w: %s", ErrUnsupportedImageType, contentType)
+	}
+
+	cmd := exec.Command(s.config.FFprobePath,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height",
+		"-of", "csv=p=0",
+		filepath.Clean(path),
+	)
+	if output, err := cmd.Output(); err != nil || strings.TrimSpace(string(output)) == "" {
+		return fmt.Errorf("%w: ffprobe could not read the image", ErrUnsupportedImageType)
+	}
+
+	return nil
+}
+
+func (s *CoverService) uploadVariant(ctx context.Context, path, objectPath, contentType string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	if err := s.storageService.UploadObject(ctx, s.config.CoverBucketName, objectPath, contentType, file); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrCreatorNotFound is returned when a creator does not exist
+	ErrCreatorNotFound = errors.New("creator not found")
+	// ErrInvalidCreator is returned for a malformed creator slug or a credit to an unknown creator
+	ErrInvalidCreator = errors.New("invalid creator")
+	// ErrCreatorSlugTaken is returned when another creator already uses the slug
+	ErrCreatorSlugTaken = errors.New("creator slug already taken")
+)
+
+const (
+	// maxCreatorEpisodes bounds how many individually credited episodes a creator page lists
+	maxCreatorEpisodes = 50
+
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+)
+
+type CreatorService struct {
+	supabase *SupabaseService
+}
+
+func NewCreatorService(supabase *SupabaseService) *CreatorService {
+	return &CreatorService{
+		supabase: supabase,
+	}
+}
+
+// GetCreator returns the creator's profile with their follower count
+func (s *CreatorService) GetCreator(ctx context.Context, creatorID uuid.UUID) (*models.Creator, error) {
+	return s.supabase.GetCreatorByID(ctx, creatorID)
+}
+
+// GetCreatorWorks returns the creator with the published series they are credited on and the
+// released episodes credited to them individually
+func (s *CreatorService) GetCreatorWorks(ctx context.Context, creatorID uuid.UUID) (*models.CreatorWorks, error) {
+	creator, err := s.supabase.GetCreatorByID(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.supabase.GetCreatorSeries(ctx, creator.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.supabase.GetCreatorEpisodes(ctx, creator.ID, maxCreatorEpisodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreatorWorks{
+		Creator:  creator,
+		Series:   series,
+		Episodes: episodes,
+	}, nil
+}
+
+// CreateCreator adds a creator and records who added them
+func (s *CreatorService) CreateCreator(ctx context.Context, actorID uuid.UUID, req *models.CreatorRequest) (*models.Creator, error) {
+	creator := &models.Creator{}
+	if err := s.applyCreatorRequest(ctx, creator, req); err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "create", "creator", uuid.Nil)
+	recordChange(entry, "slug", "", creator.Slug)
+	recordChange(entry, "name", "", creator.Name)
+
+	if err := s.supabase.CreateCreator(ctx, creator, entry); err != nil {
+		return nil, err
+	}
+
+	return creator, nil
+}
+
+// UpdateCreator replaces a creator's profile and records who changed what
+func (s *CreatorService) UpdateCreator(ctx context.Context, actorID, creatorID uuid.UUID, req *models.CreatorRequest) (*models.Creator, error) {
+	creator, err := s.supabase.GetCreatorByID(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	previous := *creator
+
+	if err := s.applyCreatorRequest(ctx, creator, req); err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "creator", creator.ID)
+	recordChange(entry, "slug", previous.Slug, creator.Slug)
+	recordChange(entry, "name", previous.Name, creator.Name)
+	recordChange(entry, "bio", previous.Bio, creator.Bio)
+	recordChange(entry, "avatar_url", previous.AvatarURL, creator.AvatarURL)
+	if len(entry.Changes) == 0 {
+		return creator, nil
+	}
+
+	if err := s.supabase.UpdateCreator(ctx, creator, entry); err != nil {
+		return nil, err
+	}
+
+	return creator, nil
+}
+
+// SetSeriesCredits replaces who is credited on the series. The credited authors' names also
+// become the series' author label, which older clients still show.
+func (s *CreatorService) SetSeriesCredits(ctx context.Context, actorID, seriesID uuid.UUID, inputs []models.CreditInput) ([]*models.Credit, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	inputs, credits, err := s.resolveCredits(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.supabase.GetSeriesCredits(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "series", series.ID)
+	recordChange(entry, "credits", creditSummary(current[series.ID]), creditSummary(credits))
+	if len(entry.Changes) == 0 {
+		return credits, nil
+	}
+
+	var authors []string
+	for _, credit := range credits {
+		if credit.Role == "author" {
+			authors = append(authors, credit.Name)
+		}
+	}
+
+	if err := s.supabase.SetSeriesCredits(ctx, series.ID, inputs, strings.Join(authors, ", "), entry); err != nil {
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+// SetEpisodeCredits replaces who is credited on the episode, such as a guest narrator
+func (s *CreatorService) SetEpisodeCredits(ctx context.Context, actorID, episodeID uuid.UUID, inputs []models.CreditInput) ([]*models.Credit, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, ErrEpisodeNotFound
+	}
+
+	inputs, credits, err := s.resolveCredits(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.supabase.GetEpisodeCredits(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	recordChange(entry, "credits", creditSummary(current[episode.ID]), creditSummary(credits))
+	if len(entry.Changes) == 0 {
+		return credits, nil
+	}
+
+	if err := s.supabase.SetEpisodeCredits(ctx, episode.ID, inputs, entry); err != nil {
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+// FollowCreator makes the user follow the creator so their new releases reach the user's feed
+func (s *CreatorService) FollowCreator(ctx context.Context, userID, creatorID uuid.UUID) error {
+	if _, err := s.supabase.GetCreatorByID(ctx, creatorID); err != nil {
+		return err
+	}
+	return s.supabase.FollowCreator(ctx, userID, creatorID)
+}
+
+// UnfollowCreator stops the user following the creator
+func (s *CreatorService) UnfollowCreator(ctx context.Context, userID, creatorID uuid.UUID) error {
+	return s.supabase.UnfollowCreator(ctx, userID, creatorID)
+}
+
+// GetFollowedCreators returns the creators the user follows
+func (s *CreatorService) GetFollowedCreators(ctx context.Context, userID uuid.UUID) ([]*models.Creator, error) {
+	return s.supabase.GetFollowedCreators(ctx, userID)
+}
+
+// GetFeed returns the latest released episodes from creators the user follows
+func (s *CreatorService) GetFeed(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.FeedItem, error) {
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.supabase.GetCreatorFeed(ctx, userID, limit, offset)
+}
+
+// applyCreatorRequest validates req and copies it onto creator
+func (s *CreatorService) applyCreatorRequest(ctx context.Context, creator *models.Creator, req *models.CreatorRequest) error {
+	slug := req.Slug
+	if slug == "" {
+		slug = slugify(req.Name)
+	}
+	if !categorySlugPattern.MatchString(slug) {
+		return fmt.Errorf("%w: slug must be lowercase letters, digits and hyphens", ErrInvalidCreator)
+	}
+
+	existing, err := s.supabase.GetCreatorBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != creator.ID {
+		return fmt.Errorf("%w: %s", ErrCreatorSlugTaken, slug)
+	}
+
+	creator.Slug = slug
+	creator.Name = strings.TrimSpace(req.Name)
+	creator.Bio = strings.TrimSpace(req.Bio)
+	creator.AvatarURL = req.AvatarURL
+
+	return nil
+}
+
+// resolveCredits drops repeated credits and looks up each credited creator, returning the
+// credits to store and how they will be shown
+func (s *CreatorService) resolveCredits(ctx context.Context, inputs []models.CreditInput) ([]models.CreditInput, []*models.Credit, error) {
+	unique := []models.CreditInput{}
+	credits := []*models.Credit{}
+	seen := map[models.CreditInput]bool{}
+	for _, input := range inputs {
+		if seen[input] {
+			continue
+		}
+		seen[input] = true
+
+		creator, err := s.supabase.GetCreatorByID(ctx, input.CreatorID)
+		if errors.Is(err, ErrCreatorNotFound) {
+			return nil, nil, fmt.Errorf("%w: creator %s does not exist", ErrInvalidCreator, input.CreatorID)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		unique = append(unique, input)
+		credits = append(credits, &models.Credit{
+			CreatorID: creator.ID,
+			Slug:      creator.Slug,
+			Name:      creator.Name,
+			AvatarURL: creator.AvatarURL,
+			Role:      input.Role,
+		})
+	}
+
+	return unique, credits, nil
+}
+
+// attachCredits loads the credits of the series and of each episode
+func attachCredits(ctx context.Context, supabase *SupabaseService, series *models.Series, episodes []*models.Episode) error {
+	seriesCredits, err := supabase.GetSeriesCredits(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return err
+	}
+	series.Credits = seriesCredits[series.ID]
+
+	ids := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		ids[i] = episode.ID
+	}
+
+	episodeCredits, err := supabase.GetEpisodeCredits(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, episode := range episodes {
+		episode.Credits = episodeCredits[episode.ID]
+	}
+
+	return nil
+}
+
+// creditSummary lists the credits, in order, as slug:role for the audit log
+func creditSummary(credits []*models.Credit) string {
+	parts := make([]string, len(credits))
+	for i, credit := range credits {
+		parts[i] = credit.Slug + ":" + credit.Role
+	}
+	return strings.Join(parts, ",")
+}
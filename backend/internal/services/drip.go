@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+	// Embedded so schedule time zones resolve on hosts without a zoneinfo database
+	_ "time/tzdata"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidDripSchedule is returned when a drip schedule has an unusable time, time zone or weekday
+	ErrInvalidDripSchedule = errors.New("invalid drip schedule")
+	// ErrDripScheduleNotFound is returned when a series has no drip schedule
+	ErrDripScheduleNotFound = errors.New("drip schedule not found")
+	// ErrEpisodeNotDraft is returned when queueing an episode that is already scheduled or published
+	ErrEpisodeNotDraft = errors.New("only draft episodes can be queued for drip release")
+)
+
+type DripService struct {
+	supabase *SupabaseService
+}
+
+func NewDripService(supabase *SupabaseService) *DripService {
+	return &DripService{
+		supabase: supabase,
+	}
+}
+
+// GetSchedule returns the series' drip schedule
+func (s *DripService) GetSchedule(ctx context.Context, seriesID uuid.UUID) (*models.DripSchedule, error) {
+	schedule, err := s.supabase.GetDripSchedule(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, ErrDripScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+// SetSchedule creates or replaces the series' drip schedule and immediately plans its
+// queued episodes on the new slots
+func (s *DripService) SetSchedule(ctx context.Context, actorID, seriesID uuid.UUID, req *models.DripScheduleRequest) (*models.DripSchedule, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	if _, err := time.Parse("15:04", req.ReleaseTime); err != nil {
+		return nil, fmt.Errorf("%w: release_time must be HH:MM", ErrInvalidDripSchedule)
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "Local" {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidDripSchedule, req.Timezone)
+	}
+
+	seen := map[int]bool{}
+	weekdays := make([]int, 0, len(req.Weekdays))
+	for _, weekday := range req.Weekdays {
+		if !seen[weekday] {
+			seen[weekday] = true
+			weekdays = append(weekdays, weekday)
+		}
+	}
+	sort.Ints(weekdays)
+
+	schedule := &models.DripSchedule{
+		SeriesID:    series.ID,
+		Weekdays:    weekdays,
+		ReleaseTime: req.ReleaseTime,
+		Timezone:    req.Timezone,
+		CreatedBy:   actorID,
+	}
+	if err := s.supabase.UpsertDripSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.PlanSeries(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// DeleteSchedule stops the series' drip releases. Episodes it had scheduled but not yet
+// released go back to draft.
+func (s *DripService) DeleteSchedule(ctx context.Context, seriesID uuid.UUID) error {
+	deleted, err := s.supabase.DeleteDripSchedule(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDripScheduleNotFound
+	}
+
+	return nil
+}
+
+// SetEpisodeQueued adds a draft episode to its series' drip queue or takes it out, and
+// replans the series if it has a drip schedule. Taking out an episode the schedule planned
+// but has not released returns it to draft.
+func (s *DripService) SetEpisodeQueued(ctx context.Context, episodeID uuid.UUID, queued bool) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return ErrEpisodeNotFound
+	}
+
+	updated, err := s.supabase.SetEpisodeDripQueued(ctx, episode.ID, queued)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrEpisodeNotDraft
+	}
+
+	schedule, err := s.supabase.GetDripSchedule(ctx, episode.SeriesID)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return nil
+	}
+
+	_, err = s.PlanSeries(ctx, schedule)
+	return err
+}
+
+// PlanSeries assigns the series' queued episodes, in episode order, to the schedule's next
+// release slots. Slots already planned are recomputed, so a changed schedule moves them.
+// It returns how many episodes are scheduled.
+func (s *DripService) PlanSeries(ctx context.Context, schedule *models.DripSchedule) (int, error) {
+	now := time.Now()
+
+	queue, err := s.supabase.GetDripQueue(ctx, schedule.SeriesID, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(queue) == 0 {
+		return 0, nil
+	}
+
+	slots, err := nextReleaseSlots(schedule, now, len(queue))
+	if err != nil {
+		return 0, err
+	}
+
+	releases := make([]*models.UpcomingEpisode, 0, len(queue))
+	for i, episode := range queue {
+		if episode.Status == "scheduled" && episode.PublishAt != nil && episode.PublishAt.Equal(slots[i]) {
+			continue
+		}
+		releases = append(releases, &models.UpcomingEpisode{ID: episode.ID, ReleaseAt: slots[i]})
+	}
+	if len(releases) == 0 {
+		return len(queue), nil
+	}
+
+	if err := s.supabase.ScheduleDripReleases(ctx, releases); err != nil {
+		return 0, err
+	}
+
+	return len(queue), nil
+}
+
+// PlanAll plans every series with a drip schedule, so drafts queued since the last run get a slot
+func (s *DripService) PlanAll(ctx context.Context) error {
+	schedules, err := s.supabase.GetDripSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, schedule := range schedules {
+		if _, err := s.PlanSeries(ctx, schedule); err != nil {
+			log.Printf("Failed to plan drip releases for series %s: %v", schedule.SeriesID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to plan %d of %d drip schedules", failed, len(schedules))
+	}
+
+	return nil
+}
+
+// RunPlanner plans drip releases every interval until ctx is cancelled. Releasing the
+// planned episodes is left to the PublishingService scheduler.
+func (s *DripService) RunPlanner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.PlanAll(ctx); err != nil {
+			log.Printf("Drip planner failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// nextReleaseSlots returns the first n release times strictly after after. Slots are built
+// from wall-clock time in the schedule's time zone, so they stay at the same local time
+// across daylight saving changes.
+func nextReleaseSlots(schedule *models.DripSchedule, after time.Time, n int) ([]time.Time, error) {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidDripSchedule, schedule.Timezone)
+	}
+
+	releaseTime, err := time.Parse("15:04", schedule.ReleaseTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: release_time must be HH:MM", ErrInvalidDripSchedule)
+	}
+
+	weekdays := map[time.Weekday]bool{}
+	for _, weekday := range schedule.Weekdays {
+		weekdays[time.Weekday(weekday)] = true
+	}
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("%w: no weekdays", ErrInvalidDripSchedule)
+	}
+
+	local := after.In(location)
+	slots := make([]time.Time, 0, n)
+	for day := 0; len(slots) < n; day++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+day, releaseTime.Hour(), releaseTime.Minute(), 0, 0, location)
+		if weekdays[date.Weekday()] && date.After(after) {
+			slots = append(slots, date)
+		}
+	}
+
+	return slots, nil
+}
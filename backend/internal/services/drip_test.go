@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+)
+
+func TestNextReleaseSlots(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule models.DripSchedule
+		after    time.Time
+		n        int
+		want     []time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "monday and thursday",
+			schedule: models.DripSchedule{Weekdays: []int{1, 4}, ReleaseTime: "18:00", Timezone: "UTC"},
+			after:    time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC), // Tuesday
+			n:        3,
+			want: []time.Time{
+				time.Date(2024, 3, 7, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 11, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 14, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "later today",
+			schedule: models.DripSchedule{Weekdays: []int{2}, ReleaseTime: "18:00", Timezone: "UTC"},
+			after:    time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+			n:        1,
+			want:     []time.Time{time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "slot at after is skipped",
+			schedule: models.DripSchedule{Weekdays: []int{2}, ReleaseTime: "18:00", Timezone: "UTC"},
+			after:    time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC),
+			n:        1,
+			want:     []time.Time{time.Date(2024, 3, 12, 18, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "keeps local time across daylight saving",
+			schedule: models.DripSchedule{Weekdays: []int{6}, ReleaseTime: "18:00", Timezone: "Europe/London"},
+			after:    time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+			n:        2,
+			want: []time.Time{
+				time.Date(2024, 3, 30, 18, 0, 0, 0, london), // GMT
+				time.Date(2024, 4, 6, 18, 0, 0, 0, london),  // BST
+			},
+		},
+		{
+			name:     "no weekdays",
+			schedule: models.DripSchedule{ReleaseTime: "18:00", Timezone: "UTC"},
+			after:    time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+			n:        1,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown time zone",
+			schedule: models.DripSchedule{Weekdays: []int{1}, ReleaseTime: "18:00", Timezone: "Mars/Olympus"},
+			after:    time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+			n:        1,
+			wantErr:  true,
+		},
+		{
+			name:     "malformed release time",
+			schedule: models.DripSchedule{Weekdays: []int{1}, ReleaseTime: "6pm", Timezone: "UTC"},
+			after:    time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+			n:        1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextReleaseSlots(&tt.schedule, tt.after, tt.n)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDripSchedule) {
+					t.Errorf("nextReleaseSlots() error = %v, want %v", err, ErrInvalidDripSchedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextReleaseSlots() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("nextReleaseSlots() returned %d slots, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("slot %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,27 +2,85 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
 
 	"audio-series-app/backend/internal/models"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrEpisodeAccessDenied is returned when a user asks for audio they are not entitled to
+	ErrEpisodeAccessDenied = errors.New("episode access denied")
+	// ErrInvalidChapters is returned when chapter markers overlap or fall outside the episode
+	ErrInvalidChapters = errors.New("invalid chapters")
+	// ErrEpisodeNotFound is returned when an episode does not exist or has been deleted
+	ErrEpisodeNotFound = errors.New("episode not found")
+	// ErrEpisodeNumberTaken is returned when another episode of the series already has the number
+	ErrEpisodeNumberTaken = errors.New("episode number already taken")
+	// ErrInvalidEpisodeOrder is returned when a reorder does not list every episode of the series exactly once
+	ErrInvalidEpisodeOrder = errors.New("invalid episode order")
+	// ErrInvalidEarlyAccess is returned when an early access window would end before it starts
+	ErrInvalidEarlyAccess = errors.New("invalid early access")
+	// ErrEpisodeFree is returned when coins are spent on an episode everyone can already play
+	ErrEpisodeFree = errors.New("episode is free")
+	// ErrInvalidAudioLanguage is returned when an episode's audio language is not a BCP 47 tag
+	ErrInvalidAudioLanguage = errors.New("invalid audio language")
+	// ErrAudioTrackNotFound is returned when an episode has no audio track in the requested language
+	ErrAudioTrackNotFound = errors.New("audio track not found")
+	// ErrInvalidAudioTrack is returned when an audio track's language is malformed or is the episode's own
+	ErrInvalidAudioTrack = errors.New("invalid audio track")
+)
+
 type EpisodeService struct {
-	supabase *SupabaseService
+	supabase            *SupabaseService
+	subscriptionService *SubscriptionService
+	storageService      *StorageService
 }
 
-func NewEpisodeService(supabase *SupabaseService) *EpisodeService {
+func NewEpisodeService(supabase *SupabaseService, subscriptionService *SubscriptionService, storageService *StorageService) *EpisodeService {
 	return &EpisodeService{
-		supabase: supabase,
+		supabase:            supabase,
+		subscriptionService: subscriptionService,
+		storageService:      storageService,
 	}
 }
 
+// CreateEpisode saves a new episode. It starts as a draft unless a status is given, and its
+// audio is taken to be in the series' language unless an audio language is given.
 func (s *EpisodeService) CreateEpisode(ctx context.Context, episode *models.Episode) error {
+	if err := normalizeAudioLanguage(episode); err != nil {
+		return err
+	}
+
+	if episode.Status != "" {
+		publishAt, err := resolvePublication("", nil, &models.PublicationRequest{Status: episode.Status, PublishAt: episode.PublishAt}, time.Now())
+		if err != nil {
+			return err
+		}
+		episode.PublishAt = publishAt
+	}
+
 	return s.supabase.CreateEpisode(ctx, episode)
 }
 
+// normalizeAudioLanguage checks the episode's audio language tag, if set, and normalizes its case
+func normalizeAudioLanguage(episode *models.Episode) error {
+	if episode.AudioLanguage == "" {
+		return nil
+	}
+	if len(episode.AudioLanguage) > 10 || !languageTagPattern.MatchString(episode.AudioLanguage) {
+		return fmt.Errorf("%w: %q is not a language tag such as hi or yo", ErrInvalidAudioLanguage, episode.AudioLanguage)
+	}
+	episode.AudioLanguage = canonicalLanguageTag(episode.AudioLanguage)
+	return nil
+}
+
 func (s *EpisodeService) GetEpisodeByID(ctx context.Context, episodeID uuid.UUID) (*models.Episode, error) {
 	return s.supabase.GetEpisodeByID(ctx, episodeID)
 }
@@ -31,7 +89,10 @@ func (s *EpisodeService) GetEpisodesBySeriesID(ctx context.Context, seriesID uui
 	return s.supabase.GetEpisodesBySeriesID(ctx, seriesID)
 }
 
-func (s *EpisodeService) GetEpisodeWithPurchaseStatus(ctx context.Context, episodeIDStr, userIDStr string) (*models.EpisodeWithPurchase, error) {
+// GetEpisodeWithPurchaseStatus returns the episode localized for the languages, with the
+// user's access to it and the audio tracks it can be played in. The track matching
+// audioLanguage, or failing that the text languages, is listed first.
+func (s *EpisodeService) GetEpisodeWithPurchaseStatus(ctx context.Context, episodeIDStr, userIDStr string, languages []string, audioLanguage string) (*models.EpisodeWithPurchase, error) {
 	// Parse UUIDs
 	episodeID, err := uuid.Parse(episodeIDStr)
 	if err != nil {
@@ -48,9 +109,21 @@ func (s *EpisodeService) GetEpisodeWithPurchaseStatus(ctx context.Context, episo
 		return nil, err
 	}
 
-	isOwned, err := s.supabase.HasUserPurchasedEpisode(ctx, userID, episodeID)
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check purchase status: %w", err)
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	accessSource, err := s.accessSource(ctx, userID, episode, series)
+	if err != nil {
+		return nil, err
+	}
+	isOwned := accessSource != ""
+
+	// Unreleased and deleted episodes stay visible only to listeners who already have them
+	now := time.Now()
+	if !isOwned && !isReleased(episode, series, now) {
+		return nil, ErrEpisodeNotFound
 	}
 
 	// Check if user can unlock (has enough coins)
@@ -61,9 +134,651 @@ func (s *EpisodeService) GetEpisodeWithPurchaseStatus(ctx context.Context, episo
 
 	canUnlock := !isOwned && user.CoinBalance >= episode.CoinPrice
 
-	return &models.EpisodeWithPurchase{
-		Episode:   episode,
-		IsOwned:   isOwned,
-		CanUnlock: canUnlock,
+	chapters, err := s.supabase.GetEpisodeChapters(ctx, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := s.supabase.GetEpisodeCredits(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return nil, err
+	}
+	episode.Credits = credits[episode.ID]
+
+	if err := localizeEpisodes(ctx, s.supabase, []*models.Episode{episode}, series.Language, languages); err != nil {
+		return nil, err
+	}
+
+	response := &models.EpisodeWithPurchase{
+		Episode:       episode,
+		IsOwned:       isOwned,
+		AccessSource:  accessSource,
+		CanUnlock:     canUnlock,
+		IsEarlyAccess: inEarlyAccess(episode, now),
+		Chapters:      chapters,
+	}
+
+	waveform, err := s.supabase.GetEpisodeWaveform(ctx, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+	if waveform != nil {
+		response.Waveform = waveform.Peaks
+	}
+
+	tracks, err := s.supabase.GetEpisodeAudioTracks(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return nil, err
+	}
+	audioTracks := tracks[episode.ID]
+
+	// The raw storage locations are never returned; entitled users get short-lived signed URLs
+	audioURL := playbackAudioPath(episode)
+	opusURL := episode.OpusAudioURL
+	episode.AudioURL = ""
+	episode.AACAudioURL = ""
+	episode.OpusAudioURL = ""
+	if isOwned {
+		signedURL, expiresAt, err := s.storageService.CreateSignedAudioURL(ctx, audioURL)
+		switch {
+		case errors.Is(err, ErrSignedURLUnsupported):
+			// Backends without signed URLs are served through the authenticated stream proxy
+			streamURL, expiresAt, err := s.storageService.SignedStreamURL(userID, episode.ID, "")
+			if err != nil {
+				return nil, err
+			}
+			response.AudioURL = streamURL
+			response.AudioURLExpiresAt = &expiresAt
+		case err != nil:
+			return nil, fmt.Errorf("failed to sign audio URL: %w", err)
+		default:
+			response.AudioURL = signedURL
+			response.AudioURLExpiresAt = &expiresAt
+
+			if opusURL != "" {
+				signedOpusURL, _, err := s.storageService.CreateSignedAudioURL(ctx, opusURL)
+				if err != nil {
+					return nil, fmt.Errorf("failed to sign Opus URL: %w", err)
+				}
+				response.OpusURL = signedOpusURL
+			}
+		}
+
+		pkg, err := s.supabase.GetEpisodePackage(ctx, episode.ID)
+		if err != nil {
+			return nil, err
+		}
+		// A version is live once a run has succeeded, even while a newer run is in progress
+		if pkg != nil && pkg.Version > 0 {
+			response.PlaylistURL = s.storageService.HLSPlaylistURL(pkg.PlaylistPath)
+		}
+	}
+
+	response.AudioTracks, err = s.playbackTracks(ctx, userID, episode, audioTracks, response, isOwned)
+	if err != nil {
+		return nil, err
+	}
+	preferred := append(PreferredLanguages(audioLanguage, ""), languages...)
+	orderPlaybackTracks(response.AudioTracks, preferred)
+
+	return response, nil
+}
+
+// playbackTracks lists the episode's own audio followed by its additional tracks. The primary
+// track reuses the URL already signed for the response; the others are signed only for owners.
+// Purchases cover the episode, so an owner may play every language it has.
+func (s *EpisodeService) playbackTracks(ctx context.Context, userID uuid.UUID, episode *models.Episode, tracks []*models.AudioTrack, response *models.EpisodeWithPurchase, isOwned bool) ([]*models.PlaybackTrack, error) {
+	playback := make([]*models.PlaybackTrack, 0, len(tracks)+1)
+	playback = append(playback, &models.PlaybackTrack{
+		Language:          episode.AudioLanguage,
+		IsPrimary:         true,
+		Duration:          episode.Duration,
+		AudioURL:          response.AudioURL,
+		AudioURLExpiresAt: response.AudioURLExpiresAt,
+	})
+
+	for _, track := range tracks {
+		entry := &models.PlaybackTrack{
+			Language: track.Language,
+			Duration: track.Duration,
+		}
+		if isOwned {
+			signedURL, expiresAt, err := s.storageService.CreateSignedAudioURL(ctx, trackAudioPath(track))
+			switch {
+			case errors.Is(err, ErrSignedURLUnsupported):
+				streamURL, expiresAt, err := s.storageService.SignedStreamURL(userID, episode.ID, track.Language)
+				if err != nil {
+					return nil, err
+				}
+				entry.AudioURL = streamURL
+				entry.AudioURLExpiresAt = &expiresAt
+			case err != nil:
+				return nil, fmt.Errorf("failed to sign audio track URL: %w", err)
+			default:
+				entry.AudioURL = signedURL
+				entry.AudioURLExpiresAt = &expiresAt
+			}
+		}
+		playback = append(playback, entry)
+	}
+
+	return playback, nil
+}
+
+// attachAudioLanguages lists the languages each episode can be played in, its own audio
+// language first
+func attachAudioLanguages(ctx context.Context, supabase *SupabaseService, episodes []*models.Episode) error {
+	ids := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		ids[i] = episode.ID
+	}
+
+	tracks, err := supabase.GetEpisodeAudioTracks(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, episode := range episodes {
+		languages := []string{episode.AudioLanguage}
+		for _, track := range tracks[episode.ID] {
+			languages = append(languages, track.Language)
+		}
+		episode.AudioLanguages = languages
+	}
+
+	return nil
+}
+
+// orderPlaybackTracks moves the track that best matches the preferred languages to the front,
+// leaving the episode's own audio first when none of them match
+func orderPlaybackTracks(tracks []*models.PlaybackTrack, preferred []string) {
+	available := make([]string, len(tracks))
+	for i, track := range tracks {
+		available[i] = track.Language
+	}
+
+	language, ok := matchLanguage(available, preferred)
+	if !ok {
+		return
+	}
+	for i, track := range tracks {
+		if track.Language == language {
+			copy(tracks[1:i+1], tracks[:i])
+			tracks[0] = track
+			return
+		}
+	}
+}
+
+// ReplaceChapters validates the chapter markers and replaces the episode's existing ones.
+// Markers are stored in time order; an empty list removes all chapters.
+func (s *EpisodeService) ReplaceChapters(ctx context.Context, episodeID uuid.UUID, inputs []models.ChapterInput) ([]*models.Chapter, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	chapters, err := buildChapters(inputs, episode.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.supabase.ReplaceEpisodeChapters(ctx, episode.ID, chapters); err != nil {
+		return nil, err
+	}
+
+	return chapters, nil
+}
+
+// buildChapters sorts the chapter inputs by start time, returning ErrInvalidChapters if one
+// starts after an episode of duration seconds ends or two start at the same time. A
+// duration of zero is not known yet and is not checked.
+func buildChapters(inputs []models.ChapterInput, duration int) ([]*models.Chapter, error) {
+	chapters := make([]*models.Chapter, 0, len(inputs))
+	for _, input := range inputs {
+		if duration > 0 && input.StartTime >= duration {
+			return nil, fmt.Errorf("%w: chapter %q starts after the episode ends", ErrInvalidChapters, input.Title)
+		}
+		chapters = append(chapters, &models.Chapter{
+			StartTime: input.StartTime,
+			Title:     strings.TrimSpace(input.Title),
+		})
+	}
+
+	sort.Slice(chapters, func(i, j int) bool {
+		return chapters[i].StartTime < chapters[j].StartTime
+	})
+	for i := 1; i < len(chapters); i++ {
+		if chapters[i].StartTime == chapters[i-1].StartTime {
+			return nil, fmt.Errorf("%w: more than one chapter starts at %d seconds", ErrInvalidChapters, chapters[i].StartTime)
+		}
+	}
+
+	return chapters, nil
+}
+
+// UpdateEpisode applies the fields set in req to the episode and records who changed what
+func (s *EpisodeService) UpdateEpisode(ctx context.Context, actorID, episodeID uuid.UUID, req *models.EpisodeUpdateRequest) (*models.Episode, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, ErrEpisodeNotFound
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	if req.Title != nil {
+		recordChange(entry, "title", episode.Title, *req.Title)
+		episode.Title = *req.Title
+	}
+	if req.Description != nil {
+		recordChange(entry, "description", episode.Description, *req.Description)
+		episode.Description = *req.Description
+	}
+	if req.EpisodeNumber != nil && *req.EpisodeNumber != episode.EpisodeNumber {
+		siblings, err := s.supabase.GetAllEpisodesBySeriesID(ctx, episode.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			if sibling.EpisodeNumber == *req.EpisodeNumber {
+				return nil, fmt.Errorf("%w: %d", ErrEpisodeNumberTaken, *req.EpisodeNumber)
+			}
+		}
+
+		recordChange(entry, "episode_number", episode.EpisodeNumber, *req.EpisodeNumber)
+		episode.EpisodeNumber = *req.EpisodeNumber
+	}
+	if req.CoinPrice != nil {
+		recordChange(entry, "coin_price", episode.CoinPrice, *req.CoinPrice)
+		episode.CoinPrice = *req.CoinPrice
+	}
+	if req.IsLocked != nil {
+		recordChange(entry, "is_locked", episode.IsLocked, *req.IsLocked)
+		episode.IsLocked = *req.IsLocked
+	}
+	if req.AudioLanguage != nil {
+		previous := episode.AudioLanguage
+		episode.AudioLanguage = *req.AudioLanguage
+		if err := normalizeAudioLanguage(episode); err != nil {
+			return nil, err
+		}
+
+		// The episode's own audio and its additional tracks each cover a different language
+		tracks, err := s.supabase.GetEpisodeAudioTracks(ctx, []uuid.UUID{episode.ID})
+		if err != nil {
+			return nil, err
+		}
+		for _, track := range tracks[episode.ID] {
+			if strings.EqualFold(track.Language, episode.AudioLanguage) {
+				return nil, fmt.Errorf("%w: the episode already has a %s audio track", ErrInvalidAudioLanguage, track.Language)
+			}
+		}
+
+		recordChange(entry, "audio_language", previous, episode.AudioLanguage)
+	}
+
+	if len(entry.Changes) == 0 {
+		return episode, nil
+	}
+
+	if err := s.supabase.UpdateEpisode(ctx, episode, entry); err != nil {
+		return nil, err
+	}
+
+	return episode, nil
+}
+
+// DeleteAudioTrack removes the episode's additional audio track in the language and records
+// who removed it. The episode's own audio cannot be removed this way.
+func (s *EpisodeService) DeleteAudioTrack(ctx context.Context, actorID, episodeID uuid.UUID, language string) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return ErrEpisodeNotFound
+	}
+
+	if !languageTagPattern.MatchString(language) {
+		return ErrAudioTrackNotFound
+	}
+	language = canonicalLanguageTag(language)
+
+	if strings.EqualFold(language, episode.AudioLanguage) {
+		return fmt.Errorf("%w: %s is the episode's own audio language", ErrInvalidAudioTrack, language)
+	}
+	audioPath, err := s.AudioPath(ctx, episode, language)
+	if err != nil {
+		return err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	entry.Changes["audio_track:"+language] = models.AuditChange{Old: audioPath, New: nil}
+
+	return s.supabase.DeleteAudioTrack(ctx, episode.ID, language, entry)
+}
+
+// SetPublication changes the episode's lifecycle status and records who changed it
+func (s *EpisodeService) SetPublication(ctx context.Context, actorID, episodeID uuid.UUID, req *models.PublicationRequest) (*models.Episode, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, ErrEpisodeNotFound
+	}
+
+	publishAt, err := resolvePublication(episode.Status, episode.PublishAt, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if episode.FreeAt != nil && publishAt != nil && !episode.FreeAt.After(*publishAt) {
+		return nil, fmt.Errorf("%w: publish_at must be before the episode's free_at", ErrInvalidPublication)
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	recordPublication(entry, episode.Status, req.Status, episode.PublishAt, publishAt)
+	if len(entry.Changes) == 0 {
+		return episode, nil
+	}
+
+	episode.Status = req.Status
+	episode.PublishAt = publishAt
+	if err := s.supabase.UpdateEpisode(ctx, episode, entry); err != nil {
+		return nil, err
+	}
+
+	return episode, nil
+}
+
+// SetEarlyAccess sells the episode with coins until freeAt, when it becomes free for
+// everyone, and records who changed it. A nil freeAt ends early access: the episode goes
+// back to following its is_locked flag. Listeners who bought it early keep their purchase.
+func (s *EpisodeService) SetEarlyAccess(ctx context.Context, actorID, episodeID uuid.UUID, freeAt *time.Time) (*models.Episode, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, ErrEpisodeNotFound
+	}
+
+	if freeAt != nil {
+		if !freeAt.After(time.Now()) {
+			return nil, fmt.Errorf("%w: free_at must be in the future", ErrInvalidEarlyAccess)
+		}
+		if episode.PublishAt != nil && !freeAt.After(*episode.PublishAt) {
+			return nil, fmt.Errorf("%w: free_at must be after the episode's publish_at", ErrInvalidEarlyAccess)
+		}
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	recordChange(entry, "free_at", auditTime(episode.FreeAt), auditTime(freeAt))
+	if len(entry.Changes) == 0 {
+		return episode, nil
+	}
+
+	episode.FreeAt = freeAt
+	if err := s.supabase.UpdateEpisode(ctx, episode, entry); err != nil {
+		return nil, err
+	}
+
+	return episode, nil
+}
+
+// DeleteEpisode soft-deletes the episode. It disappears from its series, but listeners who
+// bought it keep access.
+func (s *EpisodeService) DeleteEpisode(ctx context.Context, actorID, episodeID uuid.UUID) error {
+	return s.supabase.SoftDeleteEpisode(ctx, episodeID, newAuditLogEntry(actorID, "delete", "episode", episodeID))
+}
+
+// ReorderEpisodes renumbers the series' episodes from 1 in the given order. The order must
+// list every episode of the series exactly once.
+func (s *EpisodeService) ReorderEpisodes(ctx context.Context, actorID, seriesID uuid.UUID, episodeIDStrs []string) ([]*models.Episode, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	episodes, err := s.supabase.GetAllEpisodesBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousOrder := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		previousOrder[i] = episode.ID
+	}
+
+	reordered, episodeIDs, err := orderEpisodes(episodes, episodeIDStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "reorder", "series", seriesID)
+	entry.Changes["episode_order"] = models.AuditChange{Old: previousOrder, New: episodeIDs}
+
+	if err := s.supabase.ReorderEpisodes(ctx, seriesID, episodeIDs, entry); err != nil {
+		return nil, err
+	}
+
+	return reordered, nil
+}
+
+// orderEpisodes checks the requested order against the series' episodes and returns them
+// in that order, numbered from 1. Nothing is renumbered unless the whole order is valid.
+func orderEpisodes(episodes []*models.Episode, episodeIDStrs []string) ([]*models.Episode, []uuid.UUID, error) {
+	if len(episodeIDStrs) != len(episodes) {
+		return nil, nil, fmt.Errorf("%w: expected %d episodes, got %d", ErrInvalidEpisodeOrder, len(episodes), len(episodeIDStrs))
+	}
+
+	byID := make(map[uuid.UUID]*models.Episode, len(episodes))
+	for _, episode := range episodes {
+		byID[episode.ID] = episode
+	}
+
+	episodeIDs := make([]uuid.UUID, len(episodeIDStrs))
+	reordered := make([]*models.Episode, len(episodeIDStrs))
+	for i, idStr := range episodeIDStrs {
+		episodeID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid episode ID %q", ErrInvalidEpisodeOrder, idStr)
+		}
+		episode, ok := byID[episodeID]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: episode %s is not in the series or is listed twice", ErrInvalidEpisodeOrder, episodeID)
+		}
+		delete(byID, episodeID)
+
+		episodeIDs[i] = episodeID
+		reordered[i] = episode
+	}
+
+	for i, episode := range reordered {
+		episode.EpisodeNumber = i + 1
+	}
+
+	return reordered, episodeIDs, nil
+}
+
+// GetAccessSource reports how the user is entitled to the episode: "free" when it is not
+// locked or its early access has ended, "purchase" when they bought it with coins,
+// "subscription" when an active VIP subscription covers its premium series, or an empty
+// string when they have no access. Until an episode is released, and once it or its series
+// is deleted or unpublished, only purchases grant access. During early access only coin
+// purchases do.
+func (s *EpisodeService) GetAccessSource(ctx context.Context, userID uuid.UUID, episode *models.Episode) (string, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get series: %w", err)
+	}
+
+	return s.accessSource(ctx, userID, episode, series)
+}
+
+func (s *EpisodeService) accessSource(ctx context.Context, userID uuid.UUID, episode *models.Episode, series *models.Series) (string, error) {
+	now := time.Now()
+	released := isReleased(episode, series, now)
+	if released && isFree(episode, now) {
+		return "free", nil
+	}
+
+	isPurchased, err := s.supabase.HasUserPurchasedEpisode(ctx, userID, episode.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check purchase status: %w", err)
+	}
+	if isPurchased {
+		return "purchase", nil
+	}
+	if !released || !series.IsPremium || inEarlyAccess(episode, now) {
+		return "", nil
+	}
+
+	isSubscribed, err := s.subscriptionService.HasActiveSubscription(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check subscription status: %w", err)
+	}
+	if isSubscribed {
+		return "subscription", nil
+	}
+
+	return "", nil
+}
+
+// GetEntitledEpisode returns the episode only if the user currently has access to it.
+// Access is checked on every call so revoked purchases or lapsed subscriptions take effect immediately.
+func (s *EpisodeService) GetEntitledEpisode(ctx context.Context, episodeIDStr, userIDStr string) (*models.Episode, error) {
+	// Parse UUIDs
+	episodeID, err := uuid.Parse(episodeIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid episode ID: %w", err)
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessSource, err := s.GetAccessSource(ctx, userID, episode)
+	if err != nil {
+		return nil, err
+	}
+	if accessSource == "" {
+		return nil, ErrEpisodeAccessDenied
+	}
+
+	return episode, nil
+}
+
+// playbackAudioPath returns the loudness-normalized AAC rendition once the episode has one,
+// and the original upload until then
+func playbackAudioPath(episode *models.Episode) string {
+	if episode.AACAudioURL != "" {
+		return episode.AACAudioURL
+	}
+	return episode.AudioURL
+}
+
+// trackAudioPath returns the loudness-normalized rendition of an audio track once it has
+// one, and the uploaded file until then
+func trackAudioPath(track *models.AudioTrack) string {
+	if track.ProcessingStatus == "ready" && track.ProcessedAudioURL != "" {
+		return track.ProcessedAudioURL
+	}
+	return track.AudioURL
+}
+
+// CreateStreamToken returns a short-lived token that lets an entitled user stream the
+// episode and fetch its HLS key from media players that cannot send headers
+func (s *EpisodeService) CreateStreamToken(ctx context.Context, episodeIDStr, userIDStr string) (*models.StreamToken, error) {
+	episode, err := s.GetEntitledEpisode(ctx, episodeIDStr, userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	token, expiresAt, err := s.storageService.CreateStreamToken(userID, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StreamToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		StreamURL: s.storageService.StreamURL(episode.ID) + "?stream_token=" + token,
 	}, nil
 }
+
+// AudioPath returns the storage path of the episode's audio in the language: its own audio
+// when language is empty or the episode's audio language, and otherwise the matching track.
+// It returns ErrAudioTrackNotFound if the episode has no audio in the language.
+func (s *EpisodeService) AudioPath(ctx context.Context, episode *models.Episode, language string) (string, error) {
+	if language == "" || strings.EqualFold(language, episode.AudioLanguage) {
+		return playbackAudioPath(episode), nil
+	}
+
+	tracks, err := s.supabase.GetEpisodeAudioTracks(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return "", err
+	}
+	for _, track := range tracks[episode.ID] {
+		if strings.EqualFold(track.Language, language) {
+			return trackAudioPath(track), nil
+		}
+	}
+
+	return "", ErrAudioTrackNotFound
+}
+
+// StatEpisodeAudio returns the size and cache validators of an audio file resolved by AudioPath
+func (s *EpisodeService) StatEpisodeAudio(ctx context.Context, audioPath string) (*ObjectInfo, error) {
+	return s.storageService.StatAudio(ctx, audioPath)
+}
+
+// OpenEpisodeAudio reads length bytes of an audio file resolved by AudioPath from offset; a
+// negative length reads to the end
+func (s *EpisodeService) OpenEpisodeAudio(ctx context.Context, audioPath string, offset, length int64) (io.ReadCloser, error) {
+	return s.storageService.OpenAudio(ctx, audioPath, offset, length)
+}
+
+// RecordStreamUsage adds streamed bytes to the user's daily bandwidth total
+func (s *EpisodeService) RecordStreamUsage(ctx context.Context, userIDStr string, episodeID uuid.UUID, bytes int64) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return s.supabase.RecordStreamUsage(ctx, userID, episodeID, bytes)
+}
+
+// GetEpisodeKey returns the AES-128 key for the episode's HLS segments if the user is entitled to it
+func (s *EpisodeService) GetEpisodeKey(ctx context.Context, episodeIDStr, userIDStr string) ([]byte, error) {
+	episode, err := s.GetEntitledEpisode(ctx, episodeIDStr, userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := s.supabase.GetEpisodePackage(ctx, episode.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil || pkg.Version == 0 || len(pkg.EncryptionKey) == 0 {
+		return nil, fmt.Errorf("episode is not packaged")
+	}
+
+	return pkg.EncryptionKey, nil
+}
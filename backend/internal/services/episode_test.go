@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildChapters(t *testing.T) {
+	inputs := []models.ChapterInput{
+		{StartTime: 600, Title: " The Heist "},
+		{StartTime: 0, Title: "Cold Open"},
+		{StartTime: 1200, Title: "Aftermath"},
+	}
+
+	chapters, err := buildChapters(inputs, 1800)
+	if err != nil {
+		t.Fatalf("buildChapters() error = %v", err)
+	}
+	var starts []int
+	for _, chapter := range chapters {
+		starts = append(starts, chapter.StartTime)
+	}
+	if len(starts) != 3 || starts[0] != 0 || starts[1] != 600 || starts[2] != 1200 {
+		t.Errorf("buildChapters() start times = %v, want [0 600 1200]", starts)
+	}
+	if chapters[1].Title != "The Heist" {
+		t.Errorf("buildChapters() title = %q, want it trimmed", chapters[1].Title)
+	}
+
+	invalid := []struct {
+		name     string
+		inputs   []models.ChapterInput
+		duration int
+	}{
+		{"starts at the end", []models.ChapterInput{{StartTime: 1800, Title: "Credits"}}, 1800},
+		{"starts after the end", []models.ChapterInput{{StartTime: 2000, Title: "Credits"}}, 1800},
+		{"same start time", []models.ChapterInput{{StartTime: 60, Title: "A"}, {StartTime: 0, Title: "B"}, {StartTime: 60, Title: "C"}}, 0},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildChapters(tt.inputs, tt.duration); !errors.Is(err, ErrInvalidChapters) {
+				t.Errorf("buildChapters() error = %v, want %v", err, ErrInvalidChapters)
+			}
+		})
+	}
+
+	t.Run("unknown duration", func(t *testing.T) {
+		if _, err := buildChapters([]models.ChapterInput{{StartTime: 5000, Title: "Late"}}, 0); err != nil {
+			t.Errorf("buildChapters() error = %v, want nil", err)
+		}
+	})
+	t.Run("no chapters", func(t *testing.T) {
+		if chapters, err := buildChapters(nil, 1800); err != nil || len(chapters) != 0 {
+			t.Errorf("buildChapters(nil) = %v, %v, want no chapters", chapters, err)
+		}
+	})
+}
+
+func TestOrderEpisodes(t *testing.T) {
+	first := &models.Episode{ID: uuid.New(), EpisodeNumber: 1}
+	second := &models.Episode{ID: uuid.New(), EpisodeNumber: 2}
+	third := &models.Episode{ID: uuid.New(), EpisodeNumber: 3}
+	episodes := []*models.Episode{first, second, third}
+
+	t.Run("new order", func(t *testing.T) {
+		reordered, ids, err := orderEpisodes(episodes, []string{third.ID.String(), first.ID.String(), second.ID.String()})
+		if err != nil {
+			t.Fatalf("orderEpisodes() error = %v", err)
+		}
+		want := []*models.Episode{third, first, second}
+		for i, episode := range reordered {
+			if episode != want[i] || ids[i] != want[i].ID {
+				t.Fatalf("orderEpisodes()[%d] = %v, want %v", i, episode.ID, want[i].ID)
+			}
+			if episode.EpisodeNumber != i+1 {
+				t.Errorf("episode %v numbered %d, want %d", episode.ID, episode.EpisodeNumber, i+1)
+			}
+		}
+	})
+
+	invalid := map[string][]string{
+		"empty":        {},
+		"missing one":  {first.ID.String(), second.ID.String()},
+		"listed twice": {first.ID.String(), first.ID.String(), second.ID.String()},
+		"other series": {first.ID.String(), second.ID.String(), uuid.NewString()},
+		"not a uuid":   {first.ID.String(), second.ID.String(), "episode-3"},
+		"one extra":    {first.ID.String(), second.ID.String(), third.ID.String(), third.ID.String()},
+	}
+	for name, order := range invalid {
+		t.Run(name, func(t *testing.T) {
+			before := []int{first.EpisodeNumber, second.EpisodeNumber, third.EpisodeNumber}
+			if _, _, err := orderEpisodes(episodes, order); !errors.Is(err, ErrInvalidEpisodeOrder) {
+				t.Errorf("orderEpisodes(%v) error = %v, want %v", order, err, ErrInvalidEpisodeOrder)
+			}
+			after := []int{first.EpisodeNumber, second.EpisodeNumber, third.EpisodeNumber}
+			if before[0] != after[0] || before[1] != after[1] || before[2] != after[2] {
+				t.Errorf("orderEpisodes(%v) renumbered episodes to %v on error", order, after)
+			}
+		})
+	}
+}
+
+func TestOrderPlaybackTracks(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		preferred []string
+		want      []string
+	}{
+		{"no preference keeps the primary first", []string{"en", "hi", "yo"}, nil, []string{"en", "hi", "yo"}},
+		{"exact match moves to the front", []string{"en", "hi", "yo"}, []string{"yo"}, []string{"yo", "en", "hi"}},
+		{"region falls back to the base language", []string{"en", "hi", "yo"}, []string{"hi-IN"}, []string{"hi", "en", "yo"}},
+		{"first servable preference wins", []string{"en", "hi", "yo"}, []string{"fr", "yo", "hi"}, []string{"yo", "en", "hi"}},
+		{"preferring the primary changes nothing", []string{"en", "hi"}, []string{"en-GB"}, []string{"en", "hi"}},
+		{"no match keeps the primary first", []string{"en", "hi"}, []string{"de", "fr"}, []string{"en", "hi"}},
+		{"single track", []string{"en"}, []string{"hi"}, []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracks := make([]*models.PlaybackTrack, len(tt.languages))
+			for i, language := range tt.languages {
+				tracks[i] = &models.PlaybackTrack{Language: language, IsPrimary: i == 0}
+			}
+
+			orderPlaybackTracks(tracks, tt.preferred)
+
+			got := make([]string, len(tracks))
+			for i, track := range tracks {
+				got[i] = track.Language
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("orderPlaybackTracks(%v) = %v, want %v", tt.preferred, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidQueueOrder is returned when a reorder does not list every queued episode exactly once
+	ErrInvalidQueueOrder = errors.New("invalid queue order")
+	// ErrNotificationNotFound is returned when a user has no notification with the ID
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+
+	// notificationLookback is how far back each notifier run looks for released episodes.
+	// Runs overlap, so an episode is picked up even if a few runs fail.
+	notificationLookback = 24 * time.Hour
+)
+
+type LibraryService struct {
+	supabase *SupabaseService
+}
+
+func NewLibraryService(supabase *SupabaseService) *LibraryService {
+	return &LibraryService{
+		supabase: supabase,
+	}
+}
+
+// GetLibrary returns the user's followed series, favorite episodes and listen-later queue.
+// Series titles are given in the first of languages that has a translation.
+func (s *LibraryService) GetLibrary(ctx context.Context, userID uuid.UUID, languages []string) (*models.Library, error) {
+	followed, err := s.GetFollowedSeries(ctx, userID, languages)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites, err := s.supabase.GetFavoriteEpisodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := s.supabase.GetQueue(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Library{
+		FollowedSeries: followed,
+		Favorites:      favorites,
+		Queue:          queue,
+	}, nil
+}
+
+// FollowSeries makes the user follow a published series, so they are notified of its new episodes
+func (s *LibraryService) FollowSeries(ctx context.Context, userID, seriesID uuid.UUID) error {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil || !isPublished(series.Status, series.PublishAt, time.Now()) {
+		return ErrSeriesNotFound
+	}
+
+	return s.supabase.FollowSeries(ctx, userID, series.ID)
+}
+
+// UnfollowSeries stops the user following the series
+func (s *LibraryService) UnfollowSeries(ctx context.Context, userID, seriesID uuid.UUID) error {
+	return s.supabase.UnfollowSeries(ctx, userID, seriesID)
+}
+
+// GetFollowedSeries returns the series the user follows with their follower counts and ratings
+func (s *LibraryService) GetFollowedSeries(ctx context.Context, userID uuid.UUID, languages []string) ([]*models.Series, error) {
+	series, err := s.supabase.GetFollowedSeries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachFollowerCounts(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := attachRatings(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := localizeSeries(ctx, s.supabase, series, languages); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// FavoriteEpisode adds a released episode to the user's favorites
+func (s *LibraryService) FavoriteEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	if err := s.checkReleased(ctx, episodeID); err != nil {
+		return err
+	}
+	return s.supabase.FavoriteEpisode(ctx, userID, episodeID)
+}
+
+// UnfavoriteEpisode removes the episode from the user's favorites
+func (s *LibraryService) UnfavoriteEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	return s.supabase.UnfavoriteEpisode(ctx, userID, episodeID)
+}
+
+// GetFavorites returns the user's favorite episodes
+func (s *LibraryService) GetFavorites(ctx context.Context, userID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	return s.supabase.GetFavoriteEpisodes(ctx, userID)
+}
+
+// QueueEpisode adds a released episode to the end of the user's listen-later queue
+func (s *LibraryService) QueueEpisode(ctx context.Context, userID, episodeID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	if err := s.checkReleased(ctx, episodeID); err != nil {
+		return nil, err
+	}
+
+	if err := s.supabase.QueueEpisode(ctx, userID, episodeID); err != nil {
+		return nil, err
+	}
+
+	return s.supabase.GetQueue(ctx, userID)
+}
+
+// UnqueueEpisode removes the episode from the user's listen-later queue
+func (s *LibraryService) UnqueueEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	return s.supabase.UnqueueEpisode(ctx, userID, episodeID)
+}
+
+// GetQueue returns the user's listen-later queue in order
+func (s *LibraryService) GetQueue(ctx context.Context, userID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	return s.supabase.GetQueue(ctx, userID)
+}
+
+// ReorderQueue puts the user's queue in the order of episodeIDStrs, which must list every
+// queued episode exactly once
+func (s *LibraryService) ReorderQueue(ctx context.Context, userID uuid.UUID, episodeIDStrs []string) ([]*models.LibraryEpisode, error) {
+	queue, err := s.supabase.GetQueue(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(episodeIDStrs) != len(queue) {
+		return nil, fmt.Errorf("%w: expected %d episodes, got %d", ErrInvalidQueueOrder, len(queue), len(episodeIDStrs))
+	}
+
+	queued := make(map[uuid.UUID]bool, len(queue))
+	for _, item := range queue {
+		queued[item.EpisodeID] = true
+	}
+
+	episodeIDs := make([]uuid.UUID, len(episodeIDStrs))
+	for i, idStr := range episodeIDStrs {
+		episodeID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid episode ID %q", ErrInvalidQueueOrder, idStr)
+		}
+		if !queued[episodeID] {
+			return nil, fmt.Errorf("%w: episode %s is not in the queue or is listed twice", ErrInvalidQueueOrder, episodeID)
+		}
+		delete(queued, episodeID)
+		episodeIDs[i] = episodeID
+	}
+
+	if err := s.supabase.ReorderQueue(ctx, userID, episodeIDs); err != nil {
+		return nil, err
+	}
+
+	return s.supabase.GetQueue(ctx, userID)
+}
+
+// GetNotifications returns a page of the user's notifications and how many are unread
+func (s *LibraryService) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*models.Notification, int, error) {
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, err := s.supabase.GetNotifications(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := s.supabase.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, unread, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func (s *LibraryService) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	return s.supabase.MarkNotificationRead(ctx, userID, notificationID)
+}
+
+// MarkAllNotificationsRead marks all of the user's notifications as read
+func (s *LibraryService) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	return s.supabase.MarkAllNotificationsRead(ctx, userID)
+}
+
+// NotifyNewEpisodes notifies followers of the episodes released recently
+func (s *LibraryService) NotifyNewEpisodes(ctx context.Context) (int64, error) {
+	return s.supabase.CreateNewEpisodeNotifications(ctx, time.Now().Add(-notificationLookback))
+}
+
+// RunNotifier notifies followers of new episodes every interval until ctx is cancelled.
+// Episodes released by the PublishingService scheduler are picked up on the next run.
+func (s *LibraryService) RunNotifier(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := s.NotifyNewEpisodes(ctx)
+		if err != nil {
+			log.Printf("New episode notifier failed: %v", err)
+		} else if created > 0 {
+			log.Printf("Created %d new episode notifications", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReleased returns ErrEpisodeNotFound unless listeners can currently see the episode
+func (s *LibraryService) checkReleased(ctx context.Context, episodeID uuid.UUID) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if !isReleased(episode, series, time.Now()) {
+		return ErrEpisodeNotFound
+	}
+
+	return nil
+}
+
+// attachFollowerCounts loads the follower count of every series in one query
+func attachFollowerCounts(ctx context.Context, supabase *SupabaseService, series []*models.Series) error {
+	ids := make([]uuid.UUID, len(series))
+	for i, item := range series {
+		ids[i] = item.ID
+	}
+
+	counts, err := supabase.GetSeriesFollowerCounts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range series {
+		item.FollowerCount = counts[item.ID]
+	}
+
+	return nil
+}
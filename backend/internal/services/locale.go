@@ -0,0 +1,116 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// languageTagPattern matches BCP 47 tags of the shape we store, e.g. en, hi, pt-BR, yo-NG
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// maxPreferredLanguages bounds how many Accept-Language entries are considered
+const maxPreferredLanguages = 10
+
+// PreferredLanguages returns the languages a listener wants text in, most preferred first.
+// An explicit lang query parameter comes ahead of the Accept-Language header.
+func PreferredLanguages(lang, acceptLanguage string) []string {
+	var languages []string
+	if lang = strings.TrimSpace(lang); languageTagPattern.MatchString(lang) {
+		languages = append(languages, lang)
+	}
+	return append(languages, parseAcceptLanguage(acceptLanguage)...)
+}
+
+// parseAcceptLanguage returns the header's language tags ordered by quality. Wildcards,
+// malformed tags and tags with q=0 are dropped.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var entries []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if !languageTagPattern.MatchString(tag) {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		entries = append(entries, weighted{tag: tag, quality: quality})
+		if len(entries) == maxPreferredLanguages {
+			break
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	tags := make([]string, len(entries))
+	for i, entry := range entries {
+		tags[i] = entry.tag
+	}
+	return tags
+}
+
+// matchLanguage picks the available language that best serves the preferred ones. Each
+// preference is tried in turn, first exactly and then by its base language, so hi-IN is served
+// by hi and pt by pt-BR. It reports false when none of the preferences can be served.
+func matchLanguage(available, preferred []string) (string, bool) {
+	for _, want := range preferred {
+		for _, have := range available {
+			if strings.EqualFold(have, want) {
+				return have, true
+			}
+		}
+
+		wantBase := baseLanguage(want)
+		for _, have := range available {
+			if strings.EqualFold(baseLanguage(have), wantBase) {
+				return have, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// baseLanguage returns the primary subtag of a language tag, e.g. pt for pt-BR
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(base)
+}
+
+// canonicalLanguageTag normalizes the case of a language tag the way BCP 47 writes it:
+// a lowercase language, titlecase script and uppercase region, e.g. zh-Hant-TW
+func canonicalLanguageTag(tag string) string {
+	subtags := strings.Split(tag, "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "hi", []string{"hi"}},
+		{"ordered by quality", "en;q=0.5, hi-IN, yo;q=0.8", []string{"hi-IN", "yo", "en"}},
+		{"equal quality keeps order", "pt-BR, pt;q=0.9, en;q=0.9", []string{"pt-BR", "pt", "en"}},
+		{"wildcard dropped", "*, fr;q=0.5", []string{"fr"}},
+		{"zero quality dropped", "de;q=0, es", []string{"es"}},
+		{"malformed quality dropped", "de;q=high, es", []string{"es"}},
+		{"malformed tag dropped", "en_US, x, hi", []string{"hi"}},
+		{"extra whitespace", "  ha-NG ;q=0.7 ,ig  ", []string{"ig", "ha-NG"}},
+		{"capped", strings.Repeat("en,", maxPreferredLanguages+5), []string{"en", "en", "en", "en", "en", "en", "en", "en", "en", "en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
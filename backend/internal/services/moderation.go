@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrBlockedContent is returned when text contains a word the content filter blocks
+var ErrBlockedContent = errors.New("blocked content")
+
+// defaultBlockedWords is the built-in profanity list. BLOCKED_WORDS adds to it.
+var defaultBlockedWords = []string{
+	"asshole", "bastard", "bitch", "cunt", "dickhead", "fuck", "motherfuck",
+	"shit", "slut", "twat", "wanker", "whore",
+}
+
+// blockedSuffixes are the endings stripped from a word before it is looked up, so that
+// "fucking" and "bitches" match "fuck" and "bitch"
+var blockedSuffixes = []string{"ing", "in", "ers", "er", "ed", "es", "s", "y"}
+
+// leetReplacer undoes common character substitutions, such as "5h1t"
+var leetReplacer = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s",
+)
+
+// ContentFilter rejects user-written text, such as comments, that contains blocked words
+type ContentFilter struct {
+	words map[string]bool
+}
+
+func NewContentFilter(extraWords []string) *ContentFilter {
+	filter := &ContentFilter{
+		words: map[string]bool{},
+	}
+	for _, word := range append(defaultBlockedWords, extraWords...) {
+		if word = normalizeWord(strings.TrimSpace(word)); word != "" {
+			filter.words[word] = true
+		}
+	}
+	return filter
+}
+
+// Check returns ErrBlockedContent if the text contains a blocked word. Words are matched
+// case-insensitively, ignoring common character substitutions, repeated letters and
+// common endings such as "-ing" and "-s".
+func (f *ContentFilter) Check(text string) error {
+	text = leetReplacer.Replace(strings.ToLower(text))
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if f.blocked(normalizeWord(word)) {
+			return ErrBlockedContent
+		}
+	}
+	return nil
+}
+
+// blocked reports whether the normalized word, or the word without one of blockedSuffixes,
+// is a blocked word
+func (f *ContentFilter) blocked(word string) bool {
+	if f.words[word] {
+		return true
+	}
+	for _, suffix := range blockedSuffixes {
+		if stem := strings.TrimSuffix(word, suffix); stem != word && f.words[stem] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeWord lower-cases the word and collapses runs of the same letter, so that
+// "SHIIIT" and "shit" compare equal
+func normalizeWord(word string) string {
+	var b strings.Builder
+	var last rune
+	for _, r := range strings.ToLower(word) {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
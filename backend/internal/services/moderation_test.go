@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContentFilterCheck(t *testing.T) {
+	filter := NewContentFilter([]string{" Spoilerz ", ""})
+
+	tests := []struct {
+		name    string
+		text    string
+		blocked bool
+	}{
+		{"empty", "", false},
+		{"clean", "That twist at the end!", false},
+		{"blocked word", "what a load of shit", true},
+		{"upper case", "SHIT", true},
+		{"leet", "5h1t happens", true},
+		{"leet symbols", "@$$hole", true},
+		{"repeated letters", "fuuuuck", true},
+		{"repeated letters and case", "SHIIIIT", true},
+		{"ing suffix", "fucking great", true},
+		{"in suffix", "fuckin great", true},
+		{"es suffix", "bitches", true},
+		{"ers suffix", "motherfuckers", true},
+		{"y suffix", "shitty ending", true},
+		{"punctuation", "well...shit!", true},
+		{"extra word", "no spoilerz please", true},
+		{"blocked word inside another", "Scunthorpe", false},
+		{"similar word", "class assessment", false},
+		{"unlisted stem", "shitake", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := filter.Check(tt.text)
+			if blocked := errors.Is(err, ErrBlockedContent); blocked != tt.blocked {
+				t.Errorf("Check(%q) = %v, want blocked %v", tt.text, err, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"", ""},
+		{"shit", "shit"},
+		{"SHIIIT", "shit"},
+		{"Bookkeeper", "bokeper"},
+		{"aaa", "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := normalizeWord(tt.word); got != tt.want {
+				t.Errorf("normalizeWord(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
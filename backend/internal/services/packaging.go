@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// hlsSegmentSeconds is the target duration of each HLS segment
+const hlsSegmentSeconds = 6
+
+type PackagingService struct {
+	config         *config.Config
+	supabase       *SupabaseService
+	storageService *StorageService
+}
+
+func NewPackagingService(cfg *config.Config, supabase *SupabaseService, storageService *StorageService) *PackagingService {
+	return &PackagingService{
+		config:         cfg,
+		supabase:       supabase,
+		storageService: storageService,
+	}
+}
+
+func (s *PackagingService) GetPackage(ctx context.Context, episodeID uuid.UUID) (*models.EpisodePackage, error) {
+	return s.supabase.GetEpisodePackage(ctx, episodeID)
+}
+
+// QueuePackaging puts the episode on the transcode job queue for packaging. An episode that
+// is already queued or being packaged keeps its existing job.
+func (s *PackagingService) QueuePackaging(ctx context.Context, episodeID uuid.UUID) (*models.EpisodePackage, error) {
+	if _, err := s.supabase.GetEpisodeByID(ctx, episodeID); err != nil {
+		return nil, err
+	}
+
+	job, err := s.supabase.GetActiveTranscodeJob(ctx, episodeID, "package")
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		job = &models.TranscodeJob{
+			EpisodeID:   episodeID,
+			Kind:        "package",
+			Status:      "queued",
+			MaxAttempts: s.config.TranscodeMaxAttempts,
+		}
+		if err := s.supabase.CreateTranscodeJob(ctx, job); err != nil {
+			return nil, err
+		}
+
+		if err := s.supabase.SetEpisodePackageStatus(ctx, episodeID, "pending", nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.supabase.GetEpisodePackage(ctx, episodeID)
+}
+
+// PackageEpisode transcodes the episode's audio into AES-128 encrypted HLS renditions,
+// one per configured bitrate, and uploads them with a master playlist to the HLS bucket
+// under a new version prefix. The new version only goes live, with its key, once every
+// file is uploaded; until then, and if the run fails, players keep the previous version.
+// Once the new version is live the previous one is deleted, and a run that fails or is
+// superseded deletes what it uploaded. The key is kept in the database and only released
+// through the key endpoint.
+func (s *PackagingService) PackageEpisode(ctx context.Context, episodeID uuid.UUID) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.supabase.GetEpisodePackage(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+	version := 1
+	if current != nil {
+		version = current.Version + 1
+	}
+
+	key := make([]byte, 16)
+	iv := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to generate key: %v", err)
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return fmt.Errorf("failed to generate IV: %v", err)
+	}
+
+	if err := s.supabase.SetEpisodePackageStatus(ctx, episodeID, "processing", nil); err != nil {
+		return err
+	}
+
+	prefix := versionPrefix(episode.ID, version)
+	playlistPath, err := s.packageEpisode(ctx, episode, prefix, key, iv)
+	if err != nil {
+		s.deleteVersion(ctx, episode.ID, version)
+		return err
+	}
+
+	published, err := s.supabase.PublishEpisodePackage(ctx, &models.EpisodePackage{
+		EpisodeID:     episodeID,
+		Version:       version,
+		PlaylistPath:  playlistPath,
+		Bitrates:      strings.Join(s.config.HLSBitrates, ","),
+		EncryptionKey: key,
+		EncryptionIV:  iv,
+	})
+	if err != nil {
+		s.deleteVersion(ctx, episode.ID, version)
+		return err
+	}
+	if !published {
+		log.Printf("Package version %d of episode %s was superseded before it went live", version, episodeID)
+		s.deleteVersion(ctx, episode.ID, version)
+		return nil
+	}
+
+	if current != nil && current.Version > 0 {
+		s.deleteVersion(ctx, episode.ID, current.Version)
+	}
+
+	return nil
+}
+
+// deleteVersion removes the files of one package version from the HLS bucket. Failures are
+// only logged, as they leave unused files behind rather than breaking playback.
+func (s *PackagingService) deleteVersion(ctx context.Context, episodeID uuid.UUID, version int) {
+	if err := s.storageService.DeletePrefix(ctx, s.config.HLSBucketName, versionPrefix(episodeID, version)+"/"); err != nil {
+		log.Printf("Failed to delete package version %d of episode %s: %v", version, episodeID, err)
+	}
+}
+
+// versionPrefix is the HLS bucket prefix of one package version of an episode
+func versionPrefix(episodeID uuid.UUID, version int) string {
+	return fmt.Sprintf("%s/%d", episodeID, version)
+}
+
+func (s *PackagingService) packageEpisode(ctx context.Context, episode *models.Episode, prefix string, key, iv []byte) (string, error) {
+	workDir, err := os.MkdirTemp("", "hls-"+episode.ID.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to create work directory: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	sourcePath := filepath.Join(workDir, "source")
+	if err := s.storageService.DownloadAudio(ctx, playbackAudioPath(episode), sourcePath); err != nil {
+		return "", err
+	}
+
+	keyPath := filepath.Join(workDir, "enc.key")
+	if err := os.WriteFile(keyPath, key, 0600); err != nil {
+		return "", fmt.Errorf("failed to write key file: %v", err)
+	}
+
+	// Key info file: key URI written into playlists, local key path, IV. Playlists are shared
+	// by all listeners, so the URI carries no credential: players send an Authorization
+	// header or append a stream token from POST /episodes/:id/stream-token.
+	keyURI := fmt.Sprintf("%s/episodes/%s/hls/key", strings.TrimSuffix(s.config.PublicAPIURL, "/"), episode.ID)
+	keyInfoPath := filepath.Join(workDir, "enc.keyinfo")
+	keyInfo := fmt.Sprintf("%s\n%s\n%s\n", keyURI, keyPath, hex.EncodeToString(iv))
+	if err := os.WriteFile(keyInfoPath, []byte(keyInfo), 0600); err != nil {
+		return "", fmt.Errorf("failed to write key info file: %v", err)
+	}
+
+	outputDir := filepath.Join(workDir, "out")
+	var master strings.Builder
+	master.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+
+	for _, bitrate := range s.config.HLSBitrates {
+		bitrate = strings.TrimSpace(bitrate)
+		renditionDir := filepath.Join(outputDir, bitrate)
+		if err := os.MkdirAll(renditionDir, 0700); err != nil {
+			return "", fmt.Errorf("failed to create rendition directory: %v", err)
+		}
+
+		cmd := exec.CommandContext(ctx, s.config.FFmpegPath,
+			"-hide_banner", "-loglevel", "error", "-y",
+			"-i", sourcePath,
+			"-vn", "-c:a", "aac", "-b:a", bitrate,
+			"-f", "hls",
+			"-hls_time", strconv.Itoa(hlsSegmentSeconds),
+			"-hls_playlist_type", "vod",
+			"-hls_key_info_file", keyInfoPath,
+			"-hls_segment_filename", filepath.Join(renditionDir, "segment_%04d.ts"),
+			filepath.Join(renditionDir, "index.m3u8"),
+		)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("ffmpeg failed for %s: %v: %s", bitrate, err, strings.TrimSpace(string(output)))
+		}
+
+		fmt.Fprintf(&master, "#EXT-X-STREAM-INF:BANDWIDTH=%d,CODECS=\"mp4a.40.2\"\n%s/index.m3u8\n", bandwidthForBitrate(bitrate), bitrate)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "master.m3u8"), []byte(master.String()), 0600); err != nil {
+		return "", fmt.Errorf("failed to write master playlist: %v", err)
+	}
+
+	// The version prefix is new, so nothing here is live until the package is published
+	err = filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return s.uploadFile(ctx, outputDir, path, prefix)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + "/master.m3u8", nil
+}
+
+func (s *PackagingService) uploadFile(ctx context.Context, baseDir, path, prefix string) error {
+	relPath, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", relPath, err)
+	}
+	defer file.Close()
+
+	contentType := "video/mp2t"
+	if strings.HasSuffix(path, ".m3u8") {
+		contentType = "application/vnd.apple.mpegurl"
+	}
+
+	objectPath := prefix + "/" + filepath.ToSlash(relPath)
+	if err := s.storageService.UploadObject(ctx, s.config.HLSBucketName, objectPath, contentType, file); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectPath, err)
+	}
+
+	return nil
+}
+
+// bandwidthForBitrate converts an ffmpeg bitrate such as "96k" into the peak
+// bandwidth advertised in the master playlist, allowing for container overhead
+func bandwidthForBitrate(bitrate string) int {
+	multiplier := 1
+	value := strings.ToLower(bitrate)
+	switch {
+	case strings.HasSuffix(value, "k"):
+		multiplier = 1000
+		value = strings.TrimSuffix(value, "k")
+	case strings.HasSuffix(value, "m"):
+		multiplier = 1000000
+		value = strings.TrimSuffix(value, "m")
+	}
+
+	bps, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return bps * multiplier * 11 / 10
+}
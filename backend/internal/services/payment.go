@@ -2,8 +2,14 @@ package services
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
 
 	"audio-series-app/backend/internal/config"
 	"audio-series-app/backend/internal/models"
@@ -11,6 +17,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrInvalidWebhookSignature is returned when a gateway callback is not signed with our
+// webhook secret, or the secret is not configured
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
 type PaymentService struct {
 	config   *config.Config
 	supabase *SupabaseService
@@ -67,29 +77,82 @@ func (s *PaymentService) InitiatePayment(ctx context.Context, userIDStr, bundleI
 		return nil, fmt.Errorf("invalid bundle ID")
 	}
 
+	gateway, err := gatewayForCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create payment record
 	payment := &models.Payment{
 		UserID:   userID,
 		Amount:   selectedBundle.Price,
 		Currency: currency,
 		Coins:    selectedBundle.Coins,
+		Gateway:  gateway,
 		Status:   "pending",
 	}
 
-	err = s.supabase.CreatePayment(ctx, payment)
+	return s.initiateGatewayPayment(ctx, payment, fmt.Sprintf("Purchase of %d coins", selectedBundle.Coins), map[string]interface{}{
+		"coins": selectedBundle.Coins,
+	})
+}
+
+// InitiateSubscriptionPayment creates a pending payment for one period of a subscription plan
+func (s *PaymentService) InitiateSubscriptionPayment(ctx context.Context, userID uuid.UUID, plan *models.SubscriptionPlan, subscriptionID uuid.UUID) (*models.PaymentResponse, error) {
+	gateway, err := gatewayForCurrency(plan.Currency)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create payment record: %w", err)
+		return nil, err
 	}
 
-	// Initialize payment based on currency
-	var paymentResponse *models.PaymentResponse
+	payment := &models.Payment{
+		UserID:         userID,
+		Amount:         plan.Price,
+		Currency:       plan.Currency,
+		Gateway:        gateway,
+		Status:         "pending",
+		SubscriptionID: &subscriptionID,
+	}
+
+	return s.initiateGatewayPayment(ctx, payment, fmt.Sprintf("%s subscription", plan.Name), map[string]interface{}{
+		"subscription_id": subscriptionID.String(),
+	})
+}
+
+// gatewayForCurrency returns the payment gateway that handles the given currency
+func gatewayForCurrency(currency string) (string, error) {
 	switch currency {
 	case "INR":
-		paymentResponse, err = s.initiateRazorpayPayment(payment, selectedBundle)
+		return "razorpay", nil
 	case "NGN":
-		paymentResponse, err = s.initiatePaystackPayment(payment, selectedBundle)
+		return "paystack", nil
+	default:
+		return "", fmt.Errorf("unsupported currency: %s", currency)
+	}
+}
+
+func (s *PaymentService) initiateGatewayPayment(ctx context.Context, payment *models.Payment, description string, metadata map[string]interface{}) (*models.PaymentResponse, error) {
+	// Generate the gateway reference up front so callbacks can be matched to the payment
+	switch payment.Gateway {
+	case "razorpay":
+		payment.GatewayRef = "rzp_" + uuid.New().String()[:16]
+	case "paystack":
+		payment.GatewayRef = "ps_" + uuid.New().String()[:16]
+	}
+
+	err := s.supabase.CreatePayment(ctx, payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment record: %w", err)
+	}
+
+	// Initialize payment based on gateway
+	var paymentResponse *models.PaymentResponse
+	switch payment.Gateway {
+	case "razorpay":
+		paymentResponse, err = s.initiateRazorpayPayment(ctx, payment, description, metadata)
+	case "paystack":
+		paymentResponse, err = s.initiatePaystackPayment(ctx, payment, description, metadata)
 	default:
-		return nil, fmt.Errorf("unsupported currency: %s", currency)
+		return nil, fmt.Errorf("unsupported gateway: %s", payment.Gateway)
 	}
 
 	if err != nil {
@@ -99,21 +162,18 @@ func (s *PaymentService) InitiatePayment(ctx context.Context, userIDStr, bundleI
 	return paymentResponse, nil
 }
 
-func (s *PaymentService) initiateRazorpayPayment(payment *models.Payment, bundle *models.CoinBundle) (*models.PaymentResponse, error) {
+func (s *PaymentService) initiateRazorpayPayment(ctx context.Context, payment *models.Payment, description string, notes map[string]interface{}) (*models.PaymentResponse, error) {
 	// In a real implementation, you would use the Razorpay SDK
 	// For now, we'll create a mock response
-	gatewayRef := "rzp_" + uuid.New().String()[:16]
-
-	// Update payment with gateway reference
-	payment.Gateway = "razorpay"
-	payment.GatewayRef = gatewayRef
+	notes["payment_id"] = payment.ID.String()
 
 	paymentData := map[string]interface{}{
 		"key_id":      s.config.RazorpayKeyID,
-		"amount":      bundle.Price,
-		"currency":    bundle.Currency,
-		"order_id":    gatewayRef,
-		"description": fmt.Sprintf("Purchase of %d coins", bundle.Coins),
+		"amount":      payment.Amount,
+		"currency":    payment.Currency,
+		"order_id":    payment.GatewayRef,
+		"description": description,
+		"notes":       notes,
 		"prefill": map[string]string{
 			"email": "user@example.com",
 		},
@@ -121,60 +181,87 @@ func (s *PaymentService) initiateRazorpayPayment(payment *models.Payment, bundle
 
 	paymentDataJSON, _ := json.Marshal(paymentData)
 
-	err := s.supabase.UpdatePayment(context.Background(), payment.ID, "pending", string(paymentDataJSON))
+	err := s.supabase.UpdatePayment(ctx, payment.ID, "pending", string(paymentDataJSON))
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.PaymentResponse{
 		PaymentID:   payment.ID.String(),
-		GatewayRef:  gatewayRef,
-		Amount:      bundle.Price,
-		Currency:    bundle.Currency,
+		GatewayRef:  payment.GatewayRef,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
 		Gateway:     "razorpay",
-		RedirectURL: fmt.Sprintf("https://checkout.razorpay.com/v1/checkout.html?%s", gatewayRef),
+		RedirectURL: fmt.Sprintf("https://checkout.razorpay.com/v1/checkout.html?%s", payment.GatewayRef),
 	}, nil
 }
 
-func (s *PaymentService) initiatePaystackPayment(payment *models.Payment, bundle *models.CoinBundle) (*models.PaymentResponse, error) {
+func (s *PaymentService) initiatePaystackPayment(ctx context.Context, payment *models.Payment, description string, metadata map[string]interface{}) (*models.PaymentResponse, error) {
 	// In a real implementation, you would use the Paystack SDK
 	// For now, we'll create a mock response
-	gatewayRef := "ps_" + uuid.New().String()[:16]
-
-	// Update payment with gateway reference
-	payment.Gateway = "paystack"
-	payment.GatewayRef = gatewayRef
+	metadata["payment_id"] = payment.ID.String()
+	metadata["description"] = description
 
 	paymentData := map[string]interface{}{
 		"public_key":   s.config.PaystackPublicKey,
-		"amount":       bundle.Price,
-		"currency":     bundle.Currency,
-		"reference":    gatewayRef,
+		"amount":       payment.Amount,
+		"currency":     payment.Currency,
+		"reference":    payment.GatewayRef,
 		"email":        "user@example.com",
 		"callback_url": "https://yourapp.com/payment/callback",
-		"metadata": map[string]interface{}{
-			"payment_id": payment.ID.String(),
-			"coins":      bundle.Coins,
-		},
+		"metadata":     metadata,
 	}
 
 	paymentDataJSON, _ := json.Marshal(paymentData)
 
-	err := s.supabase.UpdatePayment(context.Background(), payment.ID, "pending", string(paymentDataJSON))
+	err := s.supabase.UpdatePayment(ctx, payment.ID, "pending", string(paymentDataJSON))
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.PaymentResponse{
 		PaymentID:   payment.ID.String(),
-		GatewayRef:  gatewayRef,
-		Amount:      bundle.Price,
-		Currency:    bundle.Currency,
+		GatewayRef:  payment.GatewayRef,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
 		Gateway:     "paystack",
-		RedirectURL: fmt.Sprintf("https://checkout.paystack.com/%s", gatewayRef),
+		RedirectURL: fmt.Sprintf("https://checkout.paystack.com/%s", payment.GatewayRef),
 	}, nil
 }
 
+// VerifyWebhookSignature checks that a callback body was signed by the gateway. Razorpay sends
+// the hex HMAC-SHA256 of the raw body, keyed with the webhook secret, in X-Razorpay-Signature;
+// Paystack sends the hex HMAC-SHA512 keyed with the secret key in X-Paystack-Signature.
+func (s *PaymentService) VerifyWebhookSignature(gateway string, body []byte, signature string) error {
+	var secret string
+	var newHash func() hash.Hash
+	switch gateway {
+	case "razorpay":
+		secret, newHash = s.config.RazorpayWebhookSecret, sha256.New
+	case "paystack":
+		secret, newHash = s.config.PaystackSecretKey, sha512.New
+	default:
+		return fmt.Errorf("unsupported gateway: %s", gateway)
+	}
+
+	if secret == "" || signature == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
 func (s *PaymentService) HandlePaymentCallback(ctx context.Context, gateway string, paymentData map[string]interface{}) error {
 	// Extract payment reference
 	var paymentRef string
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"testing"
+
+	"audio-series-app/backend/internal/config"
+)
+
+func sign(newHash func() hash.Hash, secret string, body []byte) string {
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"event":"subscription.cancelled"}`)
+	service := &PaymentService{config: &config.Config{
+		RazorpayWebhookSecret: "rzp-secret",
+		PaystackSecretKey:     "sk_test",
+	}}
+
+	tests := []struct {
+		name      string
+		gateway   string
+		body      []byte
+		signature string
+		wantErr   error
+	}{
+		{"razorpay valid", "razorpay", body, sign(sha256.New, "rzp-secret", body), nil},
+		{"paystack valid", "paystack", body, sign(sha512.New, "sk_test", body), nil},
+		{"razorpay wrong secret", "razorpay", body, sign(sha256.New, "other", body), ErrInvalidWebhookSignature},
+		{"paystack signed as razorpay", "paystack", body, sign(sha256.New, "sk_test", body), ErrInvalidWebhookSignature},
+		{"tampered body", "razorpay", []byte(`{"event":"subscription.halted"}`), sign(sha256.New, "rzp-secret", body), ErrInvalidWebhookSignature},
+		{"missing signature", "razorpay", body, "", ErrInvalidWebhookSignature},
+		{"not hex", "paystack", body, "not-a-signature", ErrInvalidWebhookSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.VerifyWebhookSignature(tt.gateway, tt.body, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyWebhookSignature() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("unconfigured secret", func(t *testing.T) {
+		unconfigured := &PaymentService{config: &config.Config{}}
+		err := unconfigured.VerifyWebhookSignature("razorpay", body, sign(sha256.New, "", body))
+		if !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("VerifyWebhookSignature() = %v, want %v", err, ErrInvalidWebhookSignature)
+		}
+	})
+
+	t.Run("unsupported gateway", func(t *testing.T) {
+		if err := service.VerifyWebhookSignature("stripe", body, "00"); err == nil || errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("VerifyWebhookSignature() = %v, want an unsupported gateway error", err)
+		}
+	})
+}
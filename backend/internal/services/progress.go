@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// maxClientClockSkew bounds how far ahead of the server a client timestamp may be.
+// A device with a fast clock would otherwise win every conflict until real time caught up.
+const maxClientClockSkew = 5 * time.Minute
+
+type ProgressService struct {
+	supabase *SupabaseService
+}
+
+func NewProgressService(supabase *SupabaseService) *ProgressService {
+	return &ProgressService{
+		supabase: supabase,
+	}
+}
+
+// SaveProgress records the user's position in an episode they can currently play;
+// ErrEpisodeAccessDenied is returned otherwise. Access is checked in a single query, as
+// players send progress every few seconds. When another device has already sent a
+// newer update, the stored progress is left alone and returned so the caller can catch up;
+// the boolean reports whether this update was applied.
+func (s *ProgressService) SaveProgress(ctx context.Context, userIDStr, episodeIDStr string, req *models.ProgressRequest) (*models.ListeningProgress, bool, error) {
+	// Parse UUIDs
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	episodeID, err := uuid.Parse(episodeIDStr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid episode ID: %w", err)
+	}
+
+	access, err := s.supabase.GetEpisodeAccess(ctx, userID, []uuid.UUID{episodeID})
+	if err != nil {
+		return nil, false, err
+	}
+	playable, exists := access[episodeID]
+	if !exists {
+		return nil, false, ErrEpisodeNotFound
+	}
+	if !playable {
+		return nil, false, ErrEpisodeAccessDenied
+	}
+
+	clientUpdatedAt := req.ClientTimestamp
+	if latest := time.Now().Add(maxClientClockSkew); clientUpdatedAt.After(latest) {
+		clientUpdatedAt = latest
+	}
+
+	position := req.Position
+	if req.Duration > 0 && position > req.Duration {
+		position = req.Duration
+	}
+
+	progress := &models.ListeningProgress{
+		UserID:          userID,
+		EpisodeID:       episodeID,
+		Position:        position,
+		Duration:        req.Duration,
+		Completed:       req.Completed,
+		ClientUpdatedAt: clientUpdatedAt,
+	}
+
+	applied, err := s.supabase.SaveListeningProgress(ctx, progress)
+	if err != nil {
+		return nil, false, err
+	}
+	if applied {
+		return progress, true, nil
+	}
+
+	current, err := s.supabase.GetListeningProgress(ctx, userID, episodeID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return current, false, nil
+}
+
+// GetContinueListening returns the user's unfinished episodes with their series, most recent first
+func (s *ProgressService) GetContinueListening(ctx context.Context, userIDStr string, limit int) ([]*models.ContinueListeningItem, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return s.supabase.GetContinueListening(ctx, userID, limit)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+)
+
+// ErrInvalidPublication is returned when a status change is missing or has an unusable publish time
+var ErrInvalidPublication = errors.New("invalid publication")
+
+// isPublished reports whether content with this status is visible to listeners at now.
+// Scheduled content counts as published as soon as its publish time passes, even before
+// the scheduler has flipped its status.
+func isPublished(status string, publishAt *time.Time, now time.Time) bool {
+	switch status {
+	case "published":
+		return true
+	case "scheduled":
+		return publishAt != nil && !publishAt.After(now)
+	default:
+		return false
+	}
+}
+
+// isReleased reports whether listeners can currently see the episode: it and its series
+// are published and neither has been deleted
+func isReleased(episode *models.Episode, series *models.Series, now time.Time) bool {
+	return episode.DeletedAt == nil && series.DeletedAt == nil &&
+		isPublished(episode.Status, episode.PublishAt, now) &&
+		isPublished(series.Status, series.PublishAt, now)
+}
+
+// inEarlyAccess reports whether the episode is in its early access window at now: it can
+// only be unlocked with coins until its free_at
+func inEarlyAccess(episode *models.Episode, now time.Time) bool {
+	return episode.FreeAt != nil && now.Before(*episode.FreeAt)
+}
+
+// isFree reports whether every listener may play the episode at now without paying. Once
+// an early access window ends the episode is free whatever its is_locked flag says.
+func isFree(episode *models.Episode, now time.Time) bool {
+	if episode.FreeAt != nil {
+		return !now.Before(*episode.FreeAt)
+	}
+	return !episode.IsLocked
+}
+
+// resolvePublication validates a status change and returns the publish time to store with it.
+// Scheduling needs a future time; publishing defaults to now, or keeps the original time when
+// the content was already published; drafts have no publish time.
+func resolvePublication(currentStatus string, currentPublishAt *time.Time, req *models.PublicationRequest, now time.Time) (*time.Time, error) {
+	switch req.Status {
+	case "draft":
+		return nil, nil
+	case "scheduled":
+		if req.PublishAt == nil || !req.PublishAt.After(now) {
+			return nil, fmt.Errorf("%w: scheduling needs a publish_at in the future", ErrInvalidPublication)
+		}
+		return req.PublishAt, nil
+	case "published":
+		if req.PublishAt != nil {
+			if req.PublishAt.After(now) {
+				return nil, fmt.Errorf("%w: use the scheduled status to publish in the future", ErrInvalidPublication)
+			}
+			return req.PublishAt, nil
+		}
+		if isPublished(currentStatus, currentPublishAt, now) && currentPublishAt != nil {
+			return currentPublishAt, nil
+		}
+		return &now, nil
+	case "archived":
+		return currentPublishAt, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidPublication, req.Status)
+	}
+}
+
+// recordPublication adds status and publish time changes to the entry
+func recordPublication(entry *models.AuditLogEntry, oldStatus, newStatus string, oldPublishAt, newPublishAt *time.Time) {
+	recordChange(entry, "status", oldStatus, newStatus)
+	recordChange(entry, "publish_at", auditTime(oldPublishAt), auditTime(newPublishAt))
+}
+
+// auditTime formats an optional time for the audit log; unset times are empty
+func auditTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+type PublishingService struct {
+	supabase *SupabaseService
+}
+
+func NewPublishingService(supabase *SupabaseService) *PublishingService {
+	return &PublishingService{
+		supabase: supabase,
+	}
+}
+
+// PublishDue marks scheduled series and episodes whose publish time has passed as published.
+// Listeners already see them from that moment; this keeps the stored status accurate.
+func (s *PublishingService) PublishDue(ctx context.Context) (int64, int64, error) {
+	return s.supabase.PublishScheduledContent(ctx, time.Now())
+}
+
+// RunScheduler publishes due content every interval until ctx is cancelled
+func (s *PublishingService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		series, episodes, err := s.PublishDue(ctx)
+		if err != nil {
+			log.Printf("Publishing scheduler failed: %v", err)
+		} else if series > 0 || episodes > 0 {
+			log.Printf("Published %d scheduled series and %d scheduled episodes", series, episodes)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+)
+
+func TestResolvePublication(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-48 * time.Hour)
+	future := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name             string
+		currentStatus    string
+		currentPublishAt *time.Time
+		req              models.PublicationRequest
+		want             *time.Time
+		wantErr          bool
+	}{
+		{"draft clears publish time", "published", &past, models.PublicationRequest{Status: "draft"}, nil, false},
+		{"schedule in the future", "draft", nil, models.PublicationRequest{Status: "scheduled", PublishAt: &future}, &future, false},
+		{"schedule without time", "draft", nil, models.PublicationRequest{Status: "scheduled"}, nil, true},
+		{"schedule in the past", "draft", nil, models.PublicationRequest{Status: "scheduled", PublishAt: &past}, nil, true},
+		{"schedule now", "draft", nil, models.PublicationRequest{Status: "scheduled", PublishAt: &now}, nil, true},
+		{"publish defaults to now", "draft", nil, models.PublicationRequest{Status: "published"}, &now, false},
+		{"publish backdated", "draft", nil, models.PublicationRequest{Status: "published", PublishAt: &past}, &past, false},
+		{"publish in the future", "draft", nil, models.PublicationRequest{Status: "published", PublishAt: &future}, nil, true},
+		{"republish keeps time", "published", &past, models.PublicationRequest{Status: "published"}, &past, false},
+		{"publish due schedule keeps time", "scheduled", &past, models.PublicationRequest{Status: "published"}, &past, false},
+		{"publish pending schedule now", "scheduled", &future, models.PublicationRequest{Status: "published"}, &now, false},
+		{"archive keeps time", "published", &past, models.PublicationRequest{Status: "archived"}, &past, false},
+		{"unknown status", "draft", nil, models.PublicationRequest{Status: "deleted"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePublication(tt.currentStatus, tt.currentPublishAt, &tt.req, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPublication) {
+					t.Errorf("resolvePublication() error = %v, want %v", err, ErrInvalidPublication)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePublication() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("resolvePublication() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEarlyAccessWindow(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(72 * time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		episode   models.Episode
+		wantEarly bool
+		wantFree  bool
+	}{
+		{"free episode", models.Episode{}, false, true},
+		{"locked episode", models.Episode{IsLocked: true}, false, false},
+		{"early access", models.Episode{IsLocked: true, FreeAt: &later}, true, false},
+		{"early access on an unlocked episode", models.Episode{FreeAt: &later}, true, false},
+		{"window over", models.Episode{IsLocked: true, FreeAt: &earlier}, false, true},
+		{"window ends now", models.Episode{IsLocked: true, FreeAt: &now}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inEarlyAccess(&tt.episode, now); got != tt.wantEarly {
+				t.Errorf("inEarlyAccess() = %v, want %v", got, tt.wantEarly)
+			}
+			if got := isFree(&tt.episode, now); got != tt.wantFree {
+				t.Errorf("isFree() = %v, want %v", got, tt.wantFree)
+			}
+		})
+	}
+}
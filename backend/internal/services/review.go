@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrReviewNotFound is returned when a review does not exist or is hidden from the caller
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrReviewNotAllowed is returned when a user who has neither bought, listened to nor
+	// subscribed to any episode of a series tries to review it
+	ErrReviewNotAllowed = errors.New("review not allowed")
+	// ErrInvalidReviewReport is returned when users report their own review
+	ErrInvalidReviewReport = errors.New("invalid review report")
+)
+
+const (
+	defaultReviewLimit = 20
+	maxReviewLimit     = 100
+
+	// reviewFlagThreshold is how many reports flag a review for moderators
+	reviewFlagThreshold = 3
+)
+
+type ReviewService struct {
+	supabase            *SupabaseService
+	subscriptionService *SubscriptionService
+	filter              *ContentFilter
+}
+
+func NewReviewService(cfg *config.Config, supabase *SupabaseService, subscriptionService *SubscriptionService) *ReviewService {
+	return &ReviewService{
+		supabase:            supabase,
+		subscriptionService: subscriptionService,
+		filter:              NewContentFilter(cfg.BlockedWords),
+	}
+}
+
+// GetSeriesReviews returns the published series' rating and a page of its reviews, newest
+// first. Hidden reviews are left out.
+func (s *ReviewService) GetSeriesReviews(ctx context.Context, seriesID uuid.UUID, limit, offset int) (*models.RatingSummary, []*models.Review, error) {
+	if _, err := s.publishedSeries(ctx, seriesID); err != nil {
+		return nil, nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultReviewLimit
+	}
+	if limit > maxReviewLimit {
+		limit = maxReviewLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	ratings, err := s.supabase.GetSeriesRatings(ctx, []uuid.UUID{seriesID})
+	if err != nil {
+		return nil, nil, err
+	}
+	summary := ratings[seriesID]
+	if summary == nil {
+		summary = &models.RatingSummary{}
+	}
+
+	reviews, err := s.supabase.GetSeriesReviews(ctx, seriesID, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, review := range reviews {
+		publicReview(review)
+	}
+
+	return summary, reviews, nil
+}
+
+// GetUserReview returns the user's own review of the series, with its moderation status
+func (s *ReviewService) GetUserReview(ctx context.Context, userID, seriesID uuid.UUID) (*models.Review, error) {
+	review, err := s.supabase.GetUserReview(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+
+	review.SeriesTitle = ""
+	review.ReportCount = 0
+	return review, nil
+}
+
+// SaveReview rates a published series for the user, with an optional written review,
+// replacing their earlier rating of it. Only listeners who have bought the series or one of
+// its episodes, or whose subscription unlocks one of its episodes, may review it. Written
+// reviews go through the content filter like comments.
+func (s *ReviewService) SaveReview(ctx context.Context, userID, seriesID uuid.UUID, req *models.ReviewRequest) (*models.Review, error) {
+	series, err := s.publishedSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	entitled, err := s.isEntitled(ctx, userID, series)
+	if err != nil {
+		return nil, err
+	}
+	if !entitled {
+		return nil, ErrReviewNotAllowed
+	}
+
+	review := &models.Review{
+		SeriesID: series.ID,
+		UserID:   userID,
+		Rating:   req.Rating,
+		Body:     strings.TrimSpace(req.Body),
+	}
+	if err := s.filter.Check(review.Body); err != nil {
+		return nil, err
+	}
+	if err := s.supabase.UpsertReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserReview(ctx, userID, series.ID)
+}
+
+// DeleteReview removes the user's review of the series
+func (s *ReviewService) DeleteReview(ctx context.Context, userID, seriesID uuid.UUID) error {
+	return s.supabase.DeleteReview(ctx, userID, seriesID)
+}
+
+// ReportReview records the user's report of someone else's review. Enough reports flag the
+// review for moderators.
+func (s *ReviewService) ReportReview(ctx context.Context, userID, reviewID uuid.UUID, req *models.ReportRequest) error {
+	review, err := s.supabase.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.Status == "hidden" {
+		return ErrReviewNotFound
+	}
+	if review.UserID == userID {
+		return fmt.Errorf("%w: you cannot report your own review", ErrInvalidReviewReport)
+	}
+
+	report := &models.ReviewReport{
+		ReviewID: review.ID,
+		UserID:   userID,
+		Reason:   req.Reason,
+		Details:  strings.TrimSpace(req.Details),
+	}
+
+	return s.supabase.ReportReview(ctx, report, reviewFlagThreshold)
+}
+
+// GetModerationQueue returns a page of reviews for moderators. An empty status returns the
+// flagged and reported reviews still waiting for a decision.
+func (s *ReviewService) GetModerationQueue(ctx context.Context, status string, limit, offset int) ([]*models.Review, error) {
+	if limit <= 0 {
+		limit = defaultReviewLimit
+	}
+	if limit > maxReviewLimit {
+		limit = maxReviewLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.supabase.GetModerationQueue(ctx, status, limit, offset)
+}
+
+// GetReviewReports returns the review's reports, newest first
+func (s *ReviewService) GetReviewReports(ctx context.Context, reviewID uuid.UUID) ([]*models.ReviewReport, error) {
+	if _, err := s.supabase.GetReviewByID(ctx, reviewID); err != nil {
+		return nil, err
+	}
+	return s.supabase.GetReviewReports(ctx, reviewID)
+}
+
+// ModerateReview shows, flags or hides a review and records who changed it. Hidden reviews
+// leave the public list and no longer count towards the series' rating.
+func (s *ReviewService) ModerateReview(ctx context.Context, actorID, reviewID uuid.UUID, status string) (*models.Review, error) {
+	review, err := s.supabase.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == status {
+		return review, nil
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "review", review.ID)
+	recordChange(entry, "status", review.Status, status)
+	review.Status = status
+
+	if err := s.supabase.SetReviewStatus(ctx, review, entry); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// ReplyToReview sets the team's public reply to a review, or removes it when reply is empty,
+// and records who changed it
+func (s *ReviewService) ReplyToReview(ctx context.Context, actorID, reviewID uuid.UUID, reply string) (*models.Review, error) {
+	review, err := s.supabase.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	reply = strings.TrimSpace(reply)
+	if reply == review.Reply {
+		return review, nil
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "review", review.ID)
+	recordChange(entry, "reply", review.Reply, reply)
+	review.Reply = reply
+
+	if err := s.supabase.SetReviewReply(ctx, review, actorID, entry); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// publishedSeries returns the series if listeners can currently see it
+func (s *ReviewService) publishedSeries(ctx context.Context, seriesID uuid.UUID) (*models.Series, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil || !isPublished(series.Status, series.PublishAt, time.Now()) {
+		return nil, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// isEntitled reports whether the user may review the series: they bought it or one of its
+// episodes, listened to one of its episodes, or their subscription unlocks one of its released
+// episodes. Free series can be reviewed once listened to.
+func (s *ReviewService) isEntitled(ctx context.Context, userID uuid.UUID, series *models.Series) (bool, error) {
+	engaged, err := s.supabase.HasListenedOrPurchasedFromSeries(ctx, userID, series.ID)
+	if err != nil || engaged {
+		return engaged, err
+	}
+	if !series.IsPremium {
+		return false, nil
+	}
+
+	episodes, err := s.supabase.GetEpisodesBySeriesID(ctx, series.ID)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	unlockable := false
+	for _, episode := range episodes {
+		if isReleased(episode, series, now) && !inEarlyAccess(episode, now) {
+			unlockable = true
+			break
+		}
+	}
+	if !unlockable {
+		return false, nil
+	}
+
+	return s.subscriptionService.HasActiveSubscription(ctx, userID)
+}
+
+// publicReview clears what only admins and the author may see
+func publicReview(review *models.Review) {
+	review.SeriesTitle = ""
+	review.Status = ""
+	review.ReportCount = 0
+}
+
+// authorName shows a reviewer or commenter by first name and last initial, e.g. Jane S.
+func authorName(firstName, lastName string) string {
+	firstName = strings.TrimSpace(firstName)
+	if firstName == "" {
+		return "Listener"
+	}
+
+	lastName = strings.TrimSpace(lastName)
+	if initial, _ := utf8.DecodeRuneInString(lastName); initial != utf8.RuneError {
+		return firstName + " " + string(initial) + "."
+	}
+	return firstName
+}
+
+// attachRatings loads the rating summary of every series in one query
+func attachRatings(ctx context.Context, supabase *SupabaseService, series []*models.Series) error {
+	ids := make([]uuid.UUID, len(series))
+	for i, item := range series {
+		ids[i] = item.ID
+	}
+
+	ratings, err := supabase.GetSeriesRatings(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range series {
+		if rating, ok := ratings[item.ID]; ok {
+			item.RatingAverage = rating.Average
+			item.RatingCount = rating.Count
+		}
+	}
+
+	return nil
+}
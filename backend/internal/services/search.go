@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"audio-series-app/backend/internal/models"
+)
+
+// ErrInvalidSearchQuery is returned when a search has no words to look for, or too many
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	// maxSearchTerms bounds how many words one search may combine
+	maxSearchTerms = 8
+)
+
+type SearchService struct {
+	supabase *SupabaseService
+}
+
+func NewSearchService(supabase *SupabaseService) *SearchService {
+	return &SearchService{
+		supabase: supabase,
+	}
+}
+
+// Search finds published series and released episodes whose text contains every word of q.
+// The last word may be incomplete, so results update as the user types. Each group holds up
+// to limit results.
+func (s *SearchService) Search(ctx context.Context, q string, limit int) (*models.SearchResults, error) {
+	tsQuery, err := prefixTSQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	series, err := s.supabase.SearchSeries(ctx, tsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.supabase.SearchEpisodes(ctx, tsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SearchResults{
+		Query:    strings.TrimSpace(q),
+		Series:   series,
+		Episodes: episodes,
+	}, nil
+}
+
+// prefixTSQuery turns free text into a tsquery that requires every word, each as a prefix.
+// Words are split on anything but letters, digits and combining marks, so Devanagari vowel
+// signs and Yoruba tone marks stay with their word and tsquery operators never get through.
+// Accents and case are normalized by the app_search configuration.
+func prefixTSQuery(q string) (string, error) {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	if len(words) == 0 {
+		return "", fmt.Errorf("%w: q must contain a word", ErrInvalidSearchQuery)
+	}
+	if len(words) > maxSearchTerms {
+		return "", fmt.Errorf("%w: q may contain at most %d words", ErrInvalidSearchQuery, maxSearchTerms)
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = "'" + word + "':*"
+	}
+
+	return strings.Join(terms, " & "), nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       string
+		want    string
+		wantErr bool
+	}{
+		{"single word", "mystery", "'mystery':*", false},
+		{"several words", "night  train", "'night':* & 'train':*", false},
+		{"punctuation splits words", "who-dunnit?", "'who':* & 'dunnit':*", false},
+		{"operators are dropped", "a & !b | (c:*)", "'a':* & 'b':* & 'c':*", false},
+		{"quotes are dropped", "it's", "'it':* & 's':*", false},
+		{"digits", "season 2", "'season':* & '2':*", false},
+		{"devanagari vowel signs stay", "कहानी", "'कहानी':*", false},
+		{"yoruba tone marks stay", "ìtàn", "'ìtàn':*", false},
+		{"accents stay for app_search to fold", "Café Noir", "'Café':* & 'Noir':*", false},
+		{"mixed scripts", "Ọ̀rẹ́ dost दोस्त", "'Ọ̀rẹ́':* & 'dost':* & 'दोस्त':*", false},
+		{"as many words as allowed", strings.TrimSpace(strings.Repeat("w ", maxSearchTerms)), strings.TrimSuffix(strings.Repeat("'w':* & ", maxSearchTerms), " & "), false},
+		{"empty", "", "", true},
+		{"only punctuation", " &|! ", "", true},
+		{"too many words", strings.Repeat("word ", maxSearchTerms+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prefixTSQuery(tt.q)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSearchQuery) {
+					t.Errorf("prefixTSQuery(%q) error = %v, want %v", tt.q, err, ErrInvalidSearchQuery)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("prefixTSQuery(%q) = %q, %v, want %q", tt.q, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchRejectsQueryWithoutWords(t *testing.T) {
+	// the query is checked before anything is looked up, so no database is needed
+	service := NewSearchService(nil)
+
+	for _, q := range []string{"", "   ", "?!", "'':*"} {
+		if _, err := service.Search(context.Background(), q, 10); !errors.Is(err, ErrInvalidSearchQuery) {
+			t.Errorf("Search(%q) error = %v, want %v", q, err, ErrInvalidSearchQuery)
+		}
+	}
+}
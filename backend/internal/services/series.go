@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"audio-series-app/backend/internal/models"
 
 	"github.com/google/uuid"
 )
 
+// ErrSeriesNotFound is returned when a series does not exist or has been deleted
+var ErrSeriesNotFound = errors.New("series not found")
+
 type SeriesService struct {
 	supabase *SupabaseService
 }
@@ -18,31 +24,281 @@ func NewSeriesService(supabase *SupabaseService) *SeriesService {
 	}
 }
 
+// CreateSeries saves a new series. It starts as a draft unless a status is given.
 func (s *SeriesService) CreateSeries(ctx context.Context, series *models.Series) error {
-	return s.supabase.CreateSeries(ctx, series)
-}
+	if series.Status != "" {
+		publishAt, err := resolvePublication("", nil, &models.PublicationRequest{Status: series.Status, PublishAt: series.PublishAt}, time.Now())
+		if err != nil {
+			return err
+		}
+		series.PublishAt = publishAt
+	}
 
-func (s *SeriesService) GetSeries(ctx context.Context) ([]*models.Series, error) {
-	return s.supabase.GetSeries(ctx)
+	return s.supabase.CreateSeries(ctx, series)
 }
 
 func (s *SeriesService) GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*models.Series, error) {
 	return s.supabase.GetSeriesByID(ctx, seriesID)
 }
 
-func (s *SeriesService) GetSeriesWithEpisodes(ctx context.Context, seriesID uuid.UUID) (*models.SeriesWithEpisodes, error) {
+// GetSeriesWithEpisodes returns a published series with its published episodes and
+// placeholders for the episodes scheduled to come next. Titles and descriptions are given in
+// the first of languages that has a translation, or the series' own language.
+func (s *SeriesService) GetSeriesWithEpisodes(ctx context.Context, seriesID uuid.UUID, languages []string) (*models.SeriesWithEpisodes, error) {
 	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	if series.DeletedAt != nil || !isPublished(series.Status, series.PublishAt, now) {
+		return nil, ErrSeriesNotFound
+	}
 
 	episodes, err := s.supabase.GetEpisodesBySeriesID(ctx, seriesID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Audio is only handed out per episode, through a signed URL, to entitled users
+	for _, episode := range episodes {
+		episode.AudioURL = ""
+		episode.AACAudioURL = ""
+		episode.OpusAudioURL = ""
+	}
+
+	upcoming, err := s.supabase.GetUpcomingEpisodes(ctx, seriesID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachSeriesCategories(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachFollowerCounts(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachRatings(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachCredits(ctx, s.supabase, series, episodes); err != nil {
+		return nil, err
+	}
+
+	if err := attachAudioLanguages(ctx, s.supabase, episodes); err != nil {
+		return nil, err
+	}
+
+	if err := s.localize(ctx, series, episodes, upcoming, languages); err != nil {
+		return nil, err
+	}
+
+	return &models.SeriesWithEpisodes{
+		Series:   series,
+		Episodes: episodes,
+		Upcoming: upcoming,
+	}, nil
+}
+
+// GetAllSeries returns every series that has not been deleted, drafts included, with their
+// follower counts and ratings for admins. An empty status matches every series.
+func (s *SeriesService) GetAllSeries(ctx context.Context, status string) ([]*models.Series, error) {
+	series, err := s.supabase.GetAllSeries(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachFollowerCounts(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	if err := attachRatings(ctx, s.supabase, series); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// PreviewSeries returns a series with all of its episodes, drafts and scheduled releases
+// included, so admins can check them before they go live
+func (s *SeriesService) PreviewSeries(ctx context.Context, seriesID uuid.UUID) (*models.SeriesWithEpisodes, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	episodes, err := s.supabase.GetAllEpisodesBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachSeriesCategories(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachFollowerCounts(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachRatings(ctx, s.supabase, []*models.Series{series}); err != nil {
+		return nil, err
+	}
+
+	if err := attachCredits(ctx, s.supabase, series, episodes); err != nil {
+		return nil, err
+	}
+
+	if err := attachAudioLanguages(ctx, s.supabase, episodes); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTranslations(ctx, series, episodes); err != nil {
+		return nil, err
+	}
+
 	return &models.SeriesWithEpisodes{
 		Series:   series,
 		Episodes: episodes,
 	}, nil
 }
+
+// localize translates the series, its episodes and its coming-soon placeholders for languages
+func (s *SeriesService) localize(ctx context.Context, series *models.Series, episodes []*models.Episode, upcoming []*models.UpcomingEpisode, languages []string) error {
+	if err := localizeSeries(ctx, s.supabase, []*models.Series{series}, languages); err != nil {
+		return err
+	}
+
+	if err := localizeEpisodes(ctx, s.supabase, episodes, series.Language, languages); err != nil {
+		return err
+	}
+
+	if len(languages) == 0 || len(upcoming) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(upcoming))
+	for i, item := range upcoming {
+		ids[i] = item.ID
+	}
+	translations, err := s.supabase.GetEpisodeTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range upcoming {
+		if translation := pickTranslation(series.Language, translations[item.ID], languages); translation != nil {
+			item.Title = translation.Title
+		}
+	}
+
+	return nil
+}
+
+// attachTranslations loads every translation of the series and its episodes for admins
+func (s *SeriesService) attachTranslations(ctx context.Context, series *models.Series, episodes []*models.Episode) error {
+	seriesTranslations, err := s.supabase.GetSeriesTranslations(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return err
+	}
+	series.Translations = seriesTranslations[series.ID]
+
+	ids := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		ids[i] = episode.ID
+	}
+	episodeTranslations, err := s.supabase.GetEpisodeTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, episode := range episodes {
+		episode.Translations = episodeTranslations[episode.ID]
+	}
+
+	return nil
+}
+
+// SetPublication changes the series' lifecycle status and records who changed it
+func (s *SeriesService) SetPublication(ctx context.Context, actorID, seriesID uuid.UUID, req *models.PublicationRequest) (*models.Series, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	publishAt, err := resolvePublication(series.Status, series.PublishAt, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "series", series.ID)
+	recordPublication(entry, series.Status, req.Status, series.PublishAt, publishAt)
+	if len(entry.Changes) == 0 {
+		return series, nil
+	}
+
+	series.Status = req.Status
+	series.PublishAt = publishAt
+	if err := s.supabase.UpdateSeries(ctx, series, entry); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// UpdateSeries applies the fields set in req to the series and records who changed what
+func (s *SeriesService) UpdateSeries(ctx context.Context, actorID, seriesID uuid.UUID, req *models.SeriesUpdateRequest) (*models.Series, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "series", series.ID)
+	if req.Title != nil {
+		recordChange(entry, "title", series.Title, *req.Title)
+		series.Title = *req.Title
+	}
+	if req.Description != nil {
+		recordChange(entry, "description", series.Description, *req.Description)
+		series.Description = *req.Description
+	}
+	if req.Author != nil {
+		recordChange(entry, "author", series.Author, *req.Author)
+		series.Author = *req.Author
+	}
+	if req.Category != nil {
+		recordChange(entry, "category", series.Category, *req.Category)
+		series.Category = *req.Category
+	}
+	if req.Language != nil {
+		recordChange(entry, "language", series.Language, *req.Language)
+		series.Language = *req.Language
+	}
+	if req.IsPremium != nil {
+		recordChange(entry, "is_premium", series.IsPremium, *req.IsPremium)
+		series.IsPremium = *req.IsPremium
+	}
+
+	if len(entry.Changes) == 0 {
+		return series, nil
+	}
+
+	if err := s.supabase.UpdateSeries(ctx, series, entry); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// DeleteSeries soft-deletes the series. It disappears from the catalog, but purchases of
+// its episodes keep working.
+func (s *SeriesService) DeleteSeries(ctx context.Context, actorID, seriesID uuid.UUID) error {
+	return s.supabase.SoftDeleteSeries(ctx, seriesID, newAuditLogEntry(actorID, "delete", "series", seriesID))
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"audio-series-app/backend/internal/models"
+)
+
+// ErrInvalidStatsRange is returned when a stats range is empty, too long or has an unknown interval
+var ErrInvalidStatsRange = errors.New("invalid stats range")
+
+// maxStatsPeriods bounds how many time-series buckets a single stats request may produce
+const maxStatsPeriods = 400
+
+// statsIntervals are the supported time-series granularities and their approximate length
+var statsIntervals = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 31 * 24 * time.Hour,
+}
+
+type StatsService struct {
+	supabase *SupabaseService
+}
+
+func NewStatsService(supabase *SupabaseService) *StatsService {
+	return &StatsService{
+		supabase: supabase,
+	}
+}
+
+// GetDashboardStats computes the admin dashboard for payments, signups and coin spending
+// in [from, to), with time series bucketed by interval (day, week or month)
+func (s *StatsService) GetDashboardStats(ctx context.Context, from, to time.Time, interval string) (*models.AdminStats, error) {
+	period, ok := statsIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: interval must be day, week or month", ErrInvalidStatsRange)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidStatsRange)
+	}
+	if to.Sub(from)/period > maxStatsPeriods {
+		return nil, fmt.Errorf("%w: range covers more than %d %ss", ErrInvalidStatsRange, maxStatsPeriods, interval)
+	}
+
+	stats, err := s.supabase.GetAdminStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.From = from
+	stats.To = to
+	stats.Interval = interval
+
+	if stats.Revenue, err = s.supabase.GetRevenueBreakdown(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	if stats.RevenueSeries, err = s.supabase.GetRevenueSeries(ctx, from, to, interval); err != nil {
+		return nil, err
+	}
+
+	if stats.ARPPU, err = s.supabase.GetRevenueByCurrency(ctx, from, to); err != nil {
+		return nil, err
+	}
+	for _, revenue := range stats.ARPPU {
+		if revenue.PayingUsers > 0 {
+			revenue.ARPPU = float64(revenue.Amount) / float64(revenue.PayingUsers)
+		}
+	}
+
+	purchased, spent, err := s.supabase.GetCoinFlow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stats.CoinSellThrough = &models.CoinSellThrough{Purchased: purchased, Spent: spent}
+	if purchased > 0 {
+		stats.CoinSellThrough.Rate = float64(spent) / float64(purchased)
+	}
+
+	if stats.Cohorts, err = s.supabase.GetSignupCohorts(ctx, from, to, interval); err != nil {
+		return nil, err
+	}
+	for _, cohort := range stats.Cohorts {
+		if cohort.Signups > 0 {
+			cohort.ConversionRate = float64(cohort.Converted) / float64(cohort.Signups)
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+
+	"github.com/google/uuid"
+)
+
+type StorageService struct {
+	config *config.Config
+	store  BlobStore
+}
+
+func NewStorageService(cfg *config.Config, store BlobStore) *StorageService {
+	return &StorageService{
+		config: cfg,
+		store:  store,
+	}
+}
+
+// AudioURLExpiry returns how long signed audio URLs stay valid
+func (s *StorageService) AudioURLExpiry() time.Duration {
+	expiry, err := time.ParseDuration(s.config.AudioURLExpiry)
+	if err != nil || expiry <= 0 {
+		return 15 * time.Minute
+	}
+	return expiry
+}
+
+// CreateSignedAudioURL returns a short-lived URL for an episode's audio file in the audio bucket
+func (s *StorageService) CreateSignedAudioURL(ctx context.Context, audioURL string) (string, time.Time, error) {
+	objectPath := s.audioObjectPath(audioURL)
+	if objectPath == "" {
+		return "", time.Time{}, fmt.Errorf("episode has no audio file")
+	}
+
+	return s.CreateSignedURL(ctx, s.config.AudioBucketName, objectPath, s.AudioURLExpiry())
+}
+
+// CreateSignedURL signs an object path for the given duration
+func (s *StorageService) CreateSignedURL(ctx context.Context, bucket, objectPath string, expiresIn time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(expiresIn)
+	signedURL, err := s.store.SignedURL(ctx, bucket, objectPath, expiresIn)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signedURL, expiresAt, nil
+}
+
+// StatAudio returns size and validators for an episode's audio file
+func (s *StorageService) StatAudio(ctx context.Context, audioURL string) (*ObjectInfo, error) {
+	objectPath := s.audioObjectPath(audioURL)
+	if objectPath == "" {
+		return nil, fmt.Errorf("episode has no audio file")
+	}
+
+	return s.store.Stat(ctx, s.config.AudioBucketName, objectPath)
+}
+
+// OpenAudio streams length bytes of an episode's audio file starting at offset.
+// A negative length reads to the end of the file.
+func (s *StorageService) OpenAudio(ctx context.Context, audioURL string, offset, length int64) (io.ReadCloser, error) {
+	objectPath := s.audioObjectPath(audioURL)
+	if objectPath == "" {
+		return nil, fmt.Errorf("episode has no audio file")
+	}
+
+	return s.store.GetRange(ctx, s.config.AudioBucketName, objectPath, offset, length)
+}
+
+// DownloadAudio copies an episode's audio file to a local path, for tools such as ffmpeg
+// that work on files. It reads through the storage backend, so it works on all of them.
+func (s *StorageService) DownloadAudio(ctx context.Context, audioURL, path string) error {
+	source, err := s.OpenAudio(ctx, audioURL, 0, -1)
+	if err != nil {
+		return fmt.Errorf("failed to open source audio: %w", err)
+	}
+	defer source.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create source file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, source); err != nil {
+		return fmt.Errorf("failed to download source audio: %v", err)
+	}
+
+	return nil
+}
+
+// UploadObject stores body at objectPath in the bucket, replacing any existing object
+func (s *StorageService) UploadObject(ctx context.Context, bucket, objectPath, contentType string, body io.Reader) error {
+	return s.store.Put(ctx, bucket, objectPath, contentType, body)
+}
+
+// DeleteObject removes an object from the bucket
+func (s *StorageService) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+	return s.store.Delete(ctx, bucket, objectPath)
+}
+
+// DeletePrefix removes every object in the bucket whose key starts with prefix
+func (s *StorageService) DeletePrefix(ctx context.Context, bucket, prefix string) error {
+	objects, err := s.store.List(ctx, bucket, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if err := s.store.Delete(ctx, bucket, object.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PublicObjectURL returns the URL of an object in a public bucket
+func (s *StorageService) PublicObjectURL(bucket, objectPath string) string {
+	return s.store.PublicURL(bucket, objectPath)
+}
+
+// StreamURL returns the authenticated proxy URL for an episode's audio
+func (s *StorageService) StreamURL(episodeID uuid.UUID) string {
+	return fmt.Sprintf("%s/episodes/%s/stream", strings.TrimSuffix(s.config.PublicAPIURL, "/"), episodeID)
+}
+
+// CreateStreamToken returns a stream token that lets the user play the episode for as long
+// as signed audio URLs stay valid
+func (s *StorageService) CreateStreamToken(userID, episodeID uuid.UUID) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.AudioURLExpiry())
+	token, err := signStreamToken(s.config.JWTSecret, userID, episodeID, expiresAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// SignedStreamURL returns the stream proxy URL for the episode's audio in language, or its
+// own audio when language is empty, carrying a stream token for the user
+func (s *StorageService) SignedStreamURL(userID, episodeID uuid.UUID, language string) (string, time.Time, error) {
+	token, expiresAt, err := s.CreateStreamToken(userID, episodeID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	query := url.Values{"stream_token": {token}}
+	if language != "" {
+		query.Set("language", language)
+	}
+
+	return s.StreamURL(episodeID) + "?" + query.Encode(), expiresAt, nil
+}
+
+// HLSPlaylistURL returns the public URL of a playlist in the HLS bucket
+func (s *StorageService) HLSPlaylistURL(playlistPath string) string {
+	return s.PublicObjectURL(s.config.HLSBucketName, playlistPath)
+}
+
+// audioObjectPath turns a stored audio_url into a path inside the audio bucket.
+// Older rows hold full public URLs; newer ones hold the bucket-relative path.
+func (s *StorageService) audioObjectPath(audioURL string) string {
+	for _, marker := range []string{"/object/public/", "/object/sign/", "/object/authenticated/"} {
+		if i := strings.Index(audioURL, marker); i != -1 {
+			rest := audioURL[i+len(marker):]
+			rest = strings.SplitN(rest, "?", 2)[0]
+			rest = strings.TrimPrefix(rest, s.config.AudioBucketName+"/")
+			if unescaped, err := url.PathUnescape(rest); err == nil {
+				return unescaped
+			}
+			return rest
+		}
+	}
+
+	if strings.Contains(audioURL, "://") {
+		// External URL we cannot sign
+		return ""
+	}
+
+	return strings.TrimPrefix(audioURL, "/")
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// streamTokenScope marks tokens that only authorize streaming one episode. Session tokens
+// carry no scope, and ValidateToken rejects scoped ones.
+const streamTokenScope = "stream"
+
+// ErrInvalidStreamToken is returned for a stream token that is expired, malformed or issued
+// for another episode
+var ErrInvalidStreamToken = errors.New("invalid stream token")
+
+// signStreamToken returns a token that lets the user stream one episode's audio and fetch
+// its HLS key until expiresAt. Media players put it in URLs, where it can end up in logs,
+// so it is short-lived and useless for anything else.
+func signStreamToken(secret string, userID, episodeID uuid.UUID, expiresAt time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":    userID.String(),
+		"episode_id": episodeID.String(),
+		"scope":      streamTokenScope,
+		"exp":        expiresAt.Unix(),
+		"iat":        time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign stream token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// parseStreamToken returns the user a stream token was issued to, if it is valid for the episode
+func parseStreamToken(secret, tokenString string, episodeID uuid.UUID) (uuid.UUID, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return uuid.Nil, ErrInvalidStreamToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["scope"] != streamTokenScope || claims["episode_id"] != episodeID.String() {
+		return uuid.Nil, ErrInvalidStreamToken
+	}
+	// Parse only checks exp when it is present, and stream tokens must always expire
+	if _, hasExpiry := claims["exp"]; !hasExpiry {
+		return uuid.Nil, ErrInvalidStreamToken
+	}
+
+	userIDStr, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, ErrInvalidStreamToken
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestParseStreamToken(t *testing.T) {
+	const secret = "test-secret"
+	userID := uuid.New()
+	episodeID := uuid.New()
+
+	valid, err := signStreamToken(secret, userID, episodeID, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("signStreamToken() error = %v", err)
+	}
+	expired, _ := signStreamToken(secret, userID, episodeID, time.Now().Add(-time.Minute))
+	otherSecret, _ := signStreamToken("other-secret", userID, episodeID, time.Now().Add(time.Minute))
+	session, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID.String(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	noExpiry, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":    userID.String(),
+		"episode_id": episodeID.String(),
+		"scope":      streamTokenScope,
+	}).SignedString([]byte(secret))
+
+	tests := []struct {
+		name      string
+		token     string
+		episodeID uuid.UUID
+		wantErr   bool
+	}{
+		{"valid", valid, episodeID, false},
+		{"other episode", valid, uuid.New(), true},
+		{"expired", expired, episodeID, true},
+		{"wrong secret", otherSecret, episodeID, true},
+		{"session token", session, episodeID, true},
+		{"no expiry", noExpiry, episodeID, true},
+		{"malformed", "not-a-token", episodeID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStreamToken(secret, tt.token, tt.episodeID)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStreamToken) {
+					t.Errorf("parseStreamToken() error = %v, want %v", err, ErrInvalidStreamToken)
+				}
+				return
+			}
+			if err != nil || got != userID {
+				t.Errorf("parseStreamToken() = %v, %v, want %v", got, err, userID)
+			}
+		})
+	}
+}
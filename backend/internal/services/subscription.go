@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionService struct {
+	config         *config.Config
+	supabase       *SupabaseService
+	paymentService *PaymentService
+}
+
+func NewSubscriptionService(cfg *config.Config, supabase *SupabaseService, paymentService *PaymentService) *SubscriptionService {
+	return &SubscriptionService{
+		config:         cfg,
+		supabase:       supabase,
+		paymentService: paymentService,
+	}
+}
+
+func (s *SubscriptionService) GetPlans(ctx context.Context) ([]*models.SubscriptionPlan, error) {
+	return s.supabase.GetSubscriptionPlans(ctx)
+}
+
+func (s *SubscriptionService) GetCurrentSubscription(ctx context.Context, userID uuid.UUID) (*models.Subscription, error) {
+	return s.supabase.GetCurrentSubscription(ctx, userID)
+}
+
+// HasActiveSubscription reports whether the user is entitled to subscription content right now.
+// Subscriptions in their grace period, or cancelled but not yet at period end, still count.
+func (s *SubscriptionService) HasActiveSubscription(ctx context.Context, userID uuid.UUID) (bool, error) {
+	subscription, err := s.supabase.GetCurrentSubscription(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if subscription == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	switch subscription.Status {
+	case "active", "cancelled":
+		return subscription.CurrentPeriodEnd != nil && now.Before(*subscription.CurrentPeriodEnd), nil
+	case "past_due":
+		return subscription.GraceUntil != nil && now.Before(*subscription.GraceUntil), nil
+	}
+
+	return false, nil
+}
+
+// Subscribe creates a pending subscription and initiates the first period's payment
+func (s *SubscriptionService) Subscribe(ctx context.Context, userIDStr, planIDStr string) (*models.SubscriptionResponse, error) {
+	// Parse UUIDs
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	planID, err := uuid.Parse(planIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plan ID: %w", err)
+	}
+
+	plan, err := s.supabase.GetSubscriptionPlanByID(ctx, planID)
+	if err != nil || !plan.IsActive {
+		return nil, fmt.Errorf("invalid plan ID")
+	}
+
+	hasActive, err := s.HasActiveSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check subscription status: %w", err)
+	}
+	if hasActive {
+		return nil, fmt.Errorf("already subscribed")
+	}
+
+	gateway, err := gatewayForCurrency(plan.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &models.Subscription{
+		UserID:  userID,
+		PlanID:  plan.ID,
+		Status:  "pending",
+		Gateway: gateway,
+	}
+
+	err = s.supabase.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	paymentResponse, err := s.paymentService.InitiateSubscriptionPayment(ctx, userID, plan, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SubscriptionResponse{
+		Subscription: subscription,
+		Payment:      paymentResponse,
+	}, nil
+}
+
+// Cancel stops the subscription from renewing; access continues until the end of the paid period
+func (s *SubscriptionService) Cancel(ctx context.Context, userIDStr string) (*models.Subscription, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	subscription, err := s.supabase.GetCurrentSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription == nil || subscription.Status == "cancelled" {
+		return nil, fmt.Errorf("no active subscription")
+	}
+
+	if err := s.cancel(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *SubscriptionService) cancel(ctx context.Context, subscription *models.Subscription) error {
+	subscription.CancelAtPeriodEnd = true
+	switch subscription.Status {
+	case "active":
+		subscription.Status = "cancelled"
+	default:
+		// Nothing paid is outstanding, so access ends immediately
+		subscription.Status = "expired"
+	}
+
+	if err := s.supabase.UpdateSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	return nil
+}
+
+// HandlePaymentCallback applies subscription state changes from a gateway callback.
+// It reports false when the callback does not concern a subscription, so the caller
+// can fall back to the coin payment flow.
+func (s *SubscriptionService) HandlePaymentCallback(ctx context.Context, gateway string, paymentData map[string]interface{}) (bool, error) {
+	// Lifecycle events sent by the gateway for the subscription itself
+	if event, ok := paymentData["event"].(string); ok && strings.HasPrefix(event, "subscription.") {
+		return true, s.handleSubscriptionEvent(ctx, event, paymentData)
+	}
+
+	// Extract the reference we generated when the payment was initiated
+	var gatewayRef, status string
+	switch gateway {
+	case "razorpay":
+		gatewayRef, _ = paymentData["razorpay_order_id"].(string)
+		status, _ = paymentData["status"].(string)
+	case "paystack":
+		gatewayRef, _ = paymentData["reference"].(string)
+		status, _ = paymentData["status"].(string)
+	default:
+		return false, nil
+	}
+
+	if gatewayRef == "" {
+		return false, nil
+	}
+
+	payment, err := s.supabase.GetPaymentByGatewayRef(ctx, gatewayRef)
+	if err != nil || payment.SubscriptionID == nil {
+		return false, nil
+	}
+
+	if payment.Status != "pending" {
+		// Gateways retry callbacks, so repeated deliveries are acknowledged without reprocessing
+		return true, nil
+	}
+
+	subscription, err := s.supabase.GetSubscriptionByID(ctx, *payment.SubscriptionID)
+	if err != nil {
+		return true, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	paymentDataJSON, _ := json.Marshal(paymentData)
+	succeeded := status == "captured" || status == "success" || status == "paid"
+	if !succeeded {
+		err = s.supabase.UpdatePayment(ctx, payment.ID, "failed", string(paymentDataJSON))
+		if err != nil {
+			return true, err
+		}
+		// A failed renewal leaves the subscription in its grace period until the worker expires it
+		return true, nil
+	}
+
+	err = s.supabase.UpdatePayment(ctx, payment.ID, "completed", string(paymentDataJSON))
+	if err != nil {
+		return true, err
+	}
+
+	return true, s.renew(ctx, subscription)
+}
+
+// handleSubscriptionEvent applies gateway-initiated changes such as a customer
+// cancelling the mandate from their bank or the Paystack dashboard
+func (s *SubscriptionService) handleSubscriptionEvent(ctx context.Context, event string, paymentData map[string]interface{}) error {
+	subscriptionID, err := subscriptionIDFromCallback(paymentData)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.supabase.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	switch event {
+	case "subscription.cancelled", "subscription.disable", "subscription.not_renew":
+		if subscription.Status == "cancelled" || subscription.Status == "expired" {
+			return nil
+		}
+		return s.cancel(ctx, subscription)
+	case "subscription.halted":
+		return s.startGracePeriod(ctx, subscription)
+	}
+
+	log.Printf("Ignoring unhandled subscription event %q for %s", event, subscription.ID)
+	return nil
+}
+
+// subscriptionIDFromCallback reads our subscription ID from Razorpay notes or Paystack metadata
+func subscriptionIDFromCallback(paymentData map[string]interface{}) (uuid.UUID, error) {
+	for _, key := range []string{"notes", "metadata"} {
+		if fields, ok := paymentData[key].(map[string]interface{}); ok {
+			if id, ok := fields["subscription_id"].(string); ok {
+				return uuid.Parse(id)
+			}
+		}
+	}
+	return uuid.Nil, fmt.Errorf("missing subscription reference")
+}
+
+// renew activates the subscription for the next period after a successful payment
+func (s *SubscriptionService) renew(ctx context.Context, subscription *models.Subscription) error {
+	plan, err := s.supabase.GetSubscriptionPlanByID(ctx, subscription.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription plan: %w", err)
+	}
+
+	// Renewals continue from the previous period end so grace days are not given away
+	start := time.Now()
+	if subscription.CurrentPeriodEnd != nil && subscription.Status == "past_due" {
+		start = *subscription.CurrentPeriodEnd
+	}
+
+	end := periodEnd(start, plan.Interval)
+	subscription.Status = "active"
+	subscription.CurrentPeriodStart = &start
+	subscription.CurrentPeriodEnd = &end
+	subscription.GraceUntil = nil
+
+	if err := s.supabase.UpdateSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to renew subscription: %w", err)
+	}
+
+	return nil
+}
+
+// startGracePeriod keeps access open for the configured grace days while a renewal is outstanding
+func (s *SubscriptionService) startGracePeriod(ctx context.Context, subscription *models.Subscription) error {
+	graceFrom := time.Now()
+	if subscription.CurrentPeriodEnd != nil {
+		graceFrom = *subscription.CurrentPeriodEnd
+	}
+
+	graceUntil := graceFrom.AddDate(0, 0, s.config.SubscriptionGraceDays)
+	subscription.Status = "past_due"
+	subscription.GraceUntil = &graceUntil
+
+	if err := s.supabase.UpdateSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to start grace period: %w", err)
+	}
+
+	return nil
+}
+
+// ProcessRenewals moves subscriptions past their period end into renewal or expiry
+func (s *SubscriptionService) ProcessRenewals(ctx context.Context) error {
+	due, err := s.supabase.GetSubscriptionsDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range due {
+		switch {
+		case subscription.Status == "active" && !subscription.CancelAtPeriodEnd:
+			plan, err := s.supabase.GetSubscriptionPlanByID(ctx, subscription.PlanID)
+			if err != nil {
+				log.Printf("Failed to get plan for subscription %s: %v", subscription.ID, err)
+				continue
+			}
+			if err := s.startGracePeriod(ctx, subscription); err != nil {
+				log.Printf("Failed to start grace period for subscription %s: %v", subscription.ID, err)
+				continue
+			}
+			if _, err := s.paymentService.InitiateSubscriptionPayment(ctx, subscription.UserID, plan, subscription.ID); err != nil {
+				log.Printf("Failed to initiate renewal for subscription %s: %v", subscription.ID, err)
+			}
+		default:
+			// Cancelled at period end, or grace period ran out without payment
+			subscription.Status = "expired"
+			subscription.GraceUntil = nil
+			if err := s.supabase.UpdateSubscription(ctx, subscription); err != nil {
+				log.Printf("Failed to expire subscription %s: %v", subscription.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunRenewals calls ProcessRenewals on every tick until the context is cancelled
+func (s *SubscriptionService) RunRenewals(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessRenewals(ctx); err != nil {
+			log.Printf("Subscription renewal run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func periodEnd(start time.Time, interval string) time.Time {
+	if interval == "year" {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
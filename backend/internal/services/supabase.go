@@ -3,11 +3,13 @@ package services
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"audio-series-app/backend/internal/config"
@@ -167,18 +169,24 @@ func (s *SupabaseService) UpdateUser(ctx context.Context, user *models.User) err
 // Series operations
 func (s *SupabaseService) CreateSeries(ctx context.Context, series *models.Series) error {
 	query := `
-		INSERT INTO series (id, title, description, cover_image, author, category, is_premium, total_episodes, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO series (id, title, description, cover_image, author, category, language, is_premium, total_episodes, created_by, status, publish_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
+	if series.Status == "" {
+		series.Status = "draft"
+	}
+	if series.Language == "" {
+		series.Language = "en"
+	}
 	series.ID = uuid.New()
 	series.CreatedAt = time.Now()
 	series.UpdatedAt = time.Now()
 
 	_, err := s.db.ExecContext(ctx, query,
 		series.ID, series.Title, series.Description, series.CoverImage,
-		series.Author, series.Category, series.IsPremium, series.TotalEpisodes,
-		series.CreatedBy, series.CreatedAt, series.UpdatedAt,
+		series.Author, series.Category, series.Language, series.IsPremium, series.TotalEpisodes,
+		series.CreatedBy, series.Status, series.PublishAt, series.CreatedAt, series.UpdatedAt,
 	)
 
 	if err != nil {
@@ -188,29 +196,221 @@ func (s *SupabaseService) CreateSeries(ctx context.Context, series *models.Serie
 	return nil
 }
 
-func (s *SupabaseService) GetSeries(ctx context.Context) ([]*models.Series, error) {
+// GetCatalogSeries returns a page of the series listeners can see (published, or scheduled
+// with a publish time that has passed, and not deleted) matching the query's filters. Language
+// filters are base languages, e.g. pt, and match any tag with that base, e.g. pt-BR. Series
+// are ordered by sortExpr, highest first, then by ID, and start after the cursor when one is
+// given. Each series comes with its sort key as text, for building the next cursor.
+func (s *SupabaseService) GetCatalogSeries(ctx context.Context, query *models.CatalogQuery, sortExpr, sortType string, cursorKey *string, cursorID uuid.UUID, limit int) ([]*models.Series, []string, error) {
+	sqlQuery := fmt.Sprintf(`
+		SELECT s.id, s.title, s.description, s.cover_image, COALESCE(s.cover_images, '{}'), s.author, s.category, s.language,
+		       s.is_premium, s.total_episodes, s.created_by, s.status, s.publish_at, s.created_at, s.updated_at,
+		       (%[1]s)::text
+		FROM series s
+		LEFT JOIN series_rankings r ON r.series_id = s.id
+		WHERE s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		  AND ($1 = '' OR EXISTS (
+		      SELECT 1 FROM series_categories sc
+		      JOIN categories c ON c.id = sc.category_id
+		      LEFT JOIN categories p ON p.id = c.parent_id
+		      WHERE sc.series_id = s.id AND (c.slug = $1 OR p.slug = $1)
+		  ))
+		  AND ($2 = '' OR LOWER(s.author) = LOWER($2))
+		  AND ($3::boolean IS NULL OR s.is_premium = $3)
+		  AND ($4 = '' OR split_part(LOWER(s.language), '-', 1) = $4)
+		  AND ($8 = '' OR EXISTS (
+		      SELECT 1 FROM episodes e
+		      WHERE e.series_id = s.id AND split_part(LOWER(e.audio_language), '-', 1) = $8
+		        AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  ))
+		  AND ($5::text IS NULL OR (%[1]s, s.id) < ($5::%[2]s, $6))
+		ORDER BY %[1]s DESC, s.id DESC
+		LIMIT $7
+	`, sortExpr, sortType)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery,
+		query.Category, query.Author, query.IsPremium, query.Language, cursorKey, cursorID, limit, query.AudioLanguage,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get series: %v", err)
+	}
+	defer rows.Close()
+
+	series := []*models.Series{}
+	var sortKeys []string
+	for rows.Next() {
+		s := &models.Series{}
+		var coverImages []byte
+		var sortKey string
+		err := rows.Scan(
+			&s.ID, &s.Title, &s.Description, &s.CoverImage, &coverImages, &s.Author, &s.Category, &s.Language,
+			&s.IsPremium, &s.TotalEpisodes, &s.CreatedBy, &s.Status, &s.PublishAt, &s.CreatedAt, &s.UpdatedAt,
+			&sortKey,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan series: %v", err)
+		}
+		if err := json.Unmarshal(coverImages, &s.CoverImages); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode cover images: %v", err)
+		}
+		series = append(series, s)
+		sortKeys = append(sortKeys, sortKey)
+	}
+
+	return series, sortKeys, nil
+}
+
+// RefreshSeriesRankings recomputes every series' play count from the episode metrics rollup,
+// its trending score from play events received since since, and its rating from its reviews
+// that are not hidden. Each play counts for half as much every halfLife.
+func (s *SupabaseService) RefreshSeriesRankings(ctx context.Context, now, since time.Time, halfLife time.Duration) error {
+	query := `
+		INSERT INTO series_rankings (series_id, play_count, trending_score, rating_average, rating_count, refreshed_at)
+		SELECT s.id, COALESCE(p.plays, 0), COALESCE(t.score, 0), COALESCE(rt.average, 0), COALESCE(rt.count, 0), $1
+		FROM series s
+		LEFT JOIN (
+			SELECT e.series_id, SUM(m.starts) AS plays
+			FROM episode_metrics m
+			JOIN episodes e ON e.id = m.episode_id
+			WHERE e.deleted_at IS NULL
+			GROUP BY e.series_id
+		) p ON p.series_id = s.id
+		LEFT JOIN (
+			SELECT e.series_id, SUM(POWER(0.5, EXTRACT(EPOCH FROM ($1 - le.received_at)) / $3)) AS score
+			FROM listening_events le
+			JOIN episodes e ON e.id = le.episode_id
+			WHERE le.type = 'play' AND le.received_at > $2 AND le.received_at <= $1 AND e.deleted_at IS NULL
+			GROUP BY e.series_id
+		) t ON t.series_id = s.id
+		LEFT JOIN (
+			SELECT series_id, ROUND(AVG(rating), 2) AS average, COUNT(*) AS count
+			FROM series_reviews
+			WHERE status <> 'hidden'
+			GROUP BY series_id
+		) rt ON rt.series_id = s.id
+		WHERE s.deleted_at IS NULL
+		ON CONFLICT (series_id) DO UPDATE SET
+			play_count = EXCLUDED.play_count,
+			trending_score = EXCLUDED.trending_score,
+			rating_average = EXCLUDED.rating_average,
+			rating_count = EXCLUDED.rating_count,
+			refreshed_at = EXCLUDED.refreshed_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query, now, since, halfLife.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to refresh series rankings: %v", err)
+	}
+
+	return nil
+}
+
+// SearchSeries returns the listeners' series matching the tsquery, best match first. Title
+// matches outrank author matches, which outrank description matches.
+func (s *SupabaseService) SearchSeries(ctx context.Context, tsQuery string, limit int) ([]*models.SeriesSearchResult, error) {
 	query := `
-		SELECT id, title, description, cover_image, author, category, is_premium, total_episodes, created_by, created_at, updated_at
-		FROM series ORDER BY created_at DESC
+		SELECT id, title, author, category, language, cover_image, COALESCE(cover_images, '{}'), is_premium, total_episodes,
+		       ts_rank_cd(search_vector, q) AS rank
+		FROM series, to_tsquery('app_search', $1) q
+		WHERE search_vector @@ q
+		  AND deleted_at IS NULL AND (status = 'published' OR (status = 'scheduled' AND publish_at <= NOW()))
+		ORDER BY rank DESC, title
+		LIMIT $2
 	`
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query, tsQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search series: %v", err)
+	}
+	defer rows.Close()
+
+	results := []*models.SeriesSearchResult{}
+	for rows.Next() {
+		result := &models.SeriesSearchResult{}
+		var coverImages []byte
+		err := rows.Scan(
+			&result.ID, &result.Title, &result.Author, &result.Category, &result.Language, &result.CoverImage, &coverImages,
+			&result.IsPremium, &result.TotalEpisodes, &result.Rank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan series search result: %v", err)
+		}
+		if err := json.Unmarshal(coverImages, &result.CoverImages); err != nil {
+			return nil, fmt.Errorf("failed to decode cover images: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// SearchEpisodes returns the released episodes matching the tsquery, best match first
+func (s *SupabaseService) SearchEpisodes(ctx context.Context, tsQuery string, limit int) ([]*models.EpisodeSearchResult, error) {
+	query := `
+		SELECT e.id, e.series_id, s.title, e.title, e.episode_number, e.duration,
+		       ts_rank_cd(e.search_vector, q) AS rank
+		FROM episodes e
+		JOIN series s ON s.id = e.series_id, to_tsquery('app_search', $1) q
+		WHERE e.search_vector @@ q
+		  AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		ORDER BY rank DESC, s.title, e.episode_number
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, tsQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search episodes: %v", err)
+	}
+	defer rows.Close()
+
+	results := []*models.EpisodeSearchResult{}
+	for rows.Next() {
+		result := &models.EpisodeSearchResult{}
+		err := rows.Scan(
+			&result.ID, &result.SeriesID, &result.SeriesTitle, &result.Title, &result.EpisodeNumber, &result.Duration, &result.Rank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode search result: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetAllSeries returns every series that has not been deleted, whatever its status, for
+// admins. An empty status matches every series.
+func (s *SupabaseService) GetAllSeries(ctx context.Context, status string) ([]*models.Series, error) {
+	query := `
+		SELECT id, title, description, cover_image, COALESCE(cover_images, '{}'), author, category, language, is_premium, total_episodes, created_by,
+		       status, publish_at, created_at, updated_at
+		FROM series
+		WHERE deleted_at IS NULL AND ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get series: %v", err)
 	}
 	defer rows.Close()
 
-	var series []*models.Series
+	series := []*models.Series{}
 	for rows.Next() {
 		s := &models.Series{}
+		var coverImages []byte
 		err := rows.Scan(
-			&s.ID, &s.Title, &s.Description, &s.CoverImage, &s.Author,
-			&s.Category, &s.IsPremium, &s.TotalEpisodes, &s.CreatedBy,
-			&s.CreatedAt, &s.UpdatedAt,
+			&s.ID, &s.Title, &s.Description, &s.CoverImage, &coverImages, &s.Author,
+			&s.Category, &s.Language, &s.IsPremium, &s.TotalEpisodes, &s.CreatedBy,
+			&s.Status, &s.PublishAt, &s.CreatedAt, &s.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan series: %v", err)
 		}
+		if err := json.Unmarshal(coverImages, &s.CoverImages); err != nil {
+			return nil, fmt.Errorf("failed to decode cover images: %v", err)
+		}
 		series = append(series, s)
 	}
 
@@ -219,95 +419,3718 @@ func (s *SupabaseService) GetSeries(ctx context.Context) ([]*models.Series, erro
 
 func (s *SupabaseService) GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*models.Series, error) {
 	query := `
-		SELECT id, title, description, cover_image, author, category, is_premium, total_episodes, created_by, created_at, updated_at
+		SELECT id, title, description, cover_image, COALESCE(cover_images, '{}'), author, category, language, is_premium, total_episodes, created_by,
+		       status, publish_at, deleted_at, created_at, updated_at
 		FROM series WHERE id = $1
 	`
 
 	series := &models.Series{}
+	var coverImages []byte
 	err := s.db.QueryRowContext(ctx, query, seriesID).Scan(
-		&series.ID, &series.Title, &series.Description, &series.CoverImage,
-		&series.Author, &series.Category, &series.IsPremium, &series.TotalEpisodes,
-		&series.CreatedBy, &series.CreatedAt, &series.UpdatedAt,
+		&series.ID, &series.Title, &series.Description, &series.CoverImage, &coverImages,
+		&series.Author, &series.Category, &series.Language, &series.IsPremium, &series.TotalEpisodes,
+		&series.CreatedBy, &series.Status, &series.PublishAt, &series.DeletedAt, &series.CreatedAt, &series.UpdatedAt,
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get series: %v", err)
 	}
 
+	if err := json.Unmarshal(coverImages, &series.CoverImages); err != nil {
+		return nil, fmt.Errorf("failed to decode cover images: %v", err)
+	}
+
 	return series, nil
 }
 
-// Episode operations
-func (s *SupabaseService) CreateEpisode(ctx context.Context, episode *models.Episode) error {
+// UpdateSeriesCover saves the series' cover URL and its resized variants
+func (s *SupabaseService) UpdateSeriesCover(ctx context.Context, series *models.Series) error {
 	query := `
-		INSERT INTO episodes (id, series_id, title, description, audio_url, duration, episode_number, coin_price, is_locked, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		UPDATE series SET cover_image = $2, cover_images = $3, updated_at = $4
+		WHERE id = $1
 	`
 
-	episode.ID = uuid.New()
-	episode.CreatedAt = time.Now()
-	episode.UpdatedAt = time.Now()
+	coverImages, err := json.Marshal(series.CoverImages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cover images: %v", err)
+	}
 
-	_, err := s.db.ExecContext(ctx, query,
-		episode.ID, episode.SeriesID, episode.Title, episode.Description,
-		episode.AudioURL, episode.Duration, episode.EpisodeNumber,
-		episode.CoinPrice, episode.IsLocked, episode.CreatedAt, episode.UpdatedAt,
+	series.UpdatedAt = time.Now()
+
+	_, err = s.db.ExecContext(ctx, query, series.ID, series.CoverImage, string(coverImages), series.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update series cover: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateSeries saves the series' editable details and records the change in the audit log.
+// It returns ErrSeriesNotFound if the series does not exist or has been deleted.
+func (s *SupabaseService) UpdateSeries(ctx context.Context, series *models.Series, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE series SET title = $2, description = $3, author = $4, category = $5, is_premium = $6,
+		                  status = $7, publish_at = $8, updated_at = $9, language = $10
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	series.UpdatedAt = time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		series.ID, series.Title, series.Description, series.Author,
+		series.Category, series.IsPremium, series.Status, series.PublishAt, series.UpdatedAt, series.Language,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to update series: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrSeriesNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series update: %v", err)
+	}
+
+	return nil
+}
 
+// SoftDeleteSeries hides the series from the catalog and records the deletion in the audit
+// log. Its rows are kept so existing purchases still resolve.
+func (s *SupabaseService) SoftDeleteSeries(ctx context.Context, seriesID uuid.UUID, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE series SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create episode: %v", err)
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to delete series: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrSeriesNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series deletion: %v", err)
 	}
 
 	return nil
 }
 
-func (s *SupabaseService) GetEpisodesBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*models.Episode, error) {
+// Category operations
+func (s *SupabaseService) CreateCategory(ctx context.Context, category *models.Category, entry *models.AuditLogEntry) error {
 	query := `
-		SELECT id, series_id, title, description, audio_url, duration, episode_number, coin_price, is_locked, created_at, updated_at
-		FROM episodes WHERE series_id = $1 ORDER BY episode_number
+		INSERT INTO categories (id, slug, name, kind, parent_id, display_names, sort_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, seriesID)
+	displayNames, err := json.Marshal(category.DisplayNames)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get episodes: %v", err)
+		return fmt.Errorf("failed to marshal display names: %v", err)
+	}
+
+	category.ID = uuid.New()
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+	entry.EntityID = category.ID
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
+		category.ID, category.Slug, category.Name, category.Kind, category.ParentID,
+		string(displayNames), category.SortOrder, category.CreatedAt, category.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit category: %v", err)
+	}
+
+	return nil
+}
+
+// GetCategories returns the taxonomy in display order, with how many published series each
+// category holds. A genre's count includes the series of its sub-genres. An empty kind
+// matches every category.
+func (s *SupabaseService) GetCategories(ctx context.Context, kind string) ([]*models.Category, error) {
+	query := `
+		SELECT c.id, c.slug, c.name, c.kind, c.parent_id, c.display_names, c.sort_order, c.created_at, c.updated_at,
+		       (
+		           SELECT COUNT(DISTINCT sc.series_id)
+		           FROM series_categories sc
+		           JOIN categories tagged ON tagged.id = sc.category_id
+		           JOIN series s ON s.id = sc.series_id
+		           WHERE (tagged.id = c.id OR tagged.parent_id = c.id)
+		             AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		       )
+		FROM categories c
+		WHERE $1 = '' OR c.kind = $1
+		ORDER BY c.kind, c.sort_order, c.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %v", err)
 	}
 	defer rows.Close()
 
-	var episodes []*models.Episode
+	categories := []*models.Category{}
+	for rows.Next() {
+		category := &models.Category{}
+		var displayNames []byte
+		var seriesCount int
+		err := rows.Scan(
+			&category.ID, &category.Slug, &category.Name, &category.Kind, &category.ParentID, &displayNames,
+			&category.SortOrder, &category.CreatedAt, &category.UpdatedAt, &seriesCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %v", err)
+		}
+		if err := json.Unmarshal(displayNames, &category.DisplayNames); err != nil {
+			return nil, fmt.Errorf("failed to decode display names: %v", err)
+		}
+		category.SeriesCount = &seriesCount
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryByID returns the category, or ErrCategoryNotFound if it does not exist
+func (s *SupabaseService) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	query := `
+		SELECT id, slug, name, kind, parent_id, display_names, sort_order, created_at, updated_at
+		FROM categories WHERE id = $1
+	`
+
+	category := &models.Category{}
+	var displayNames []byte
+	err := s.db.QueryRowContext(ctx, query, categoryID).Scan(
+		&category.ID, &category.Slug, &category.Name, &category.Kind, &category.ParentID, &displayNames,
+		&category.SortOrder, &category.CreatedAt, &category.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %v", err)
+	}
+
+	if err := json.Unmarshal(displayNames, &category.DisplayNames); err != nil {
+		return nil, fmt.Errorf("failed to decode display names: %v", err)
+	}
+
+	return category, nil
+}
+
+// GetCategoryBySlug returns the category with the slug, or nil if there is none
+func (s *SupabaseService) GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	query := `SELECT id FROM categories WHERE slug = $1`
+
+	var categoryID uuid.UUID
+	err := s.db.QueryRowContext(ctx, query, slug).Scan(&categoryID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %v", err)
+	}
+
+	return s.GetCategoryByID(ctx, categoryID)
+}
+
+// UpdateCategory saves the category and records the change in the audit log
+func (s *SupabaseService) UpdateCategory(ctx context.Context, category *models.Category, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE categories SET slug = $2, name = $3, kind = $4, parent_id = $5, display_names = $6, sort_order = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	displayNames, err := json.Marshal(category.DisplayNames)
+	if err != nil {
+		return fmt.Errorf("failed to marshal display names: %v", err)
+	}
+
+	category.UpdatedAt = time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		category.ID, category.Slug, category.Name, category.Kind, category.ParentID,
+		string(displayNames), category.SortOrder, category.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrCategoryNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit category update: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteCategory removes the category and untags every series it was on. Genres with
+// sub-genres are refused with ErrCategoryHasChildren.
+func (s *SupabaseService) DeleteCategory(ctx context.Context, categoryID uuid.UUID, entry *models.AuditLogEntry) error {
+	childrenQuery := `SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)`
+	deleteQuery := `DELETE FROM categories WHERE id = $1`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var hasChildren bool
+	if err := tx.QueryRowContext(ctx, childrenQuery, categoryID).Scan(&hasChildren); err != nil {
+		return fmt.Errorf("failed to check sub-categories: %v", err)
+	}
+	if hasChildren {
+		return ErrCategoryHasChildren
+	}
+
+	result, err := tx.ExecContext(ctx, deleteQuery, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrCategoryNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit category deletion: %v", err)
+	}
+
+	return nil
+}
+
+// GetSeriesCategories returns the categories of each of the series, in the order they were
+// assigned, keyed by series ID
+func (s *SupabaseService) GetSeriesCategories(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID][]*models.Category, error) {
+	query := `
+		SELECT sc.series_id, c.id, c.slug, c.name, c.kind, c.parent_id, c.display_names, c.sort_order, c.created_at, c.updated_at
+		FROM series_categories sc
+		JOIN categories c ON c.id = sc.category_id
+		WHERE sc.series_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY sc.series_id, sc.position
+	`
+
+	byID := map[uuid.UUID][]*models.Category{}
+	if len(seriesIDs) == 0 {
+		return byID, nil
+	}
+
+	ids, err := json.Marshal(seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal series IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series categories: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		category := &models.Category{}
+		var seriesID uuid.UUID
+		var displayNames []byte
+		err := rows.Scan(
+			&seriesID, &category.ID, &category.Slug, &category.Name, &category.Kind, &category.ParentID, &displayNames,
+			&category.SortOrder, &category.CreatedAt, &category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan series category: %v", err)
+		}
+		if err := json.Unmarshal(displayNames, &category.DisplayNames); err != nil {
+			return nil, fmt.Errorf("failed to decode display names: %v", err)
+		}
+		byID[seriesID] = append(byID[seriesID], category)
+	}
+
+	return byID, nil
+}
+
+// SetSeriesCategories replaces the series' categories, in order, sets its legacy category
+// label to primaryName and records the change in the audit log
+func (s *SupabaseService) SetSeriesCategories(ctx context.Context, seriesID uuid.UUID, categoryIDs []uuid.UUID, primaryName string, entry *models.AuditLogEntry) error {
+	clearQuery := `DELETE FROM series_categories WHERE series_id = $1`
+	insertQuery := `
+		INSERT INTO series_categories (series_id, category_id, position)
+		SELECT $1, c.category_id, c.position
+		FROM jsonb_to_recordset($2::jsonb) AS c(category_id UUID, position INTEGER)
+	`
+	labelQuery := `UPDATE series SET category = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	type assignment struct {
+		CategoryID uuid.UUID `json:"category_id"`
+		Position   int       `json:"position"`
+	}
+	assignments := make([]assignment, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		assignments[i] = assignment{CategoryID: categoryID, Position: i}
+	}
+
+	rows, err := json.Marshal(assignments)
+	if err != nil {
+		return fmt.Errorf("failed to marshal series categories: %v", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, labelQuery, seriesID, primaryName)
+	if err != nil {
+		return fmt.Errorf("failed to update series category: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrSeriesNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, clearQuery, seriesID); err != nil {
+		return fmt.Errorf("failed to clear series categories: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, seriesID, string(rows)); err != nil {
+		return fmt.Errorf("failed to set series categories: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series categories: %v", err)
+	}
+
+	return nil
+}
+
+// Creator operations
+func (s *SupabaseService) CreateCreator(ctx context.Context, creator *models.Creator, entry *models.AuditLogEntry) error {
+	query := `
+		INSERT INTO creators (id, slug, name, bio, avatar_url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	creator.ID = uuid.New()
+	creator.CreatedAt = time.Now()
+	creator.UpdatedAt = time.Now()
+	entry.EntityID = creator.ID
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
+		creator.ID, creator.Slug, creator.Name, creator.Bio, creator.AvatarURL, creator.CreatedAt, creator.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create creator: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit creator: %v", err)
+	}
+
+	return nil
+}
+
+// GetCreatorByID returns the creator with their follower count, or ErrCreatorNotFound if
+// they do not exist
+func (s *SupabaseService) GetCreatorByID(ctx context.Context, creatorID uuid.UUID) (*models.Creator, error) {
+	query := `
+		SELECT c.id, c.slug, c.name, COALESCE(c.bio, ''), COALESCE(c.avatar_url, ''), c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM creator_follows f WHERE f.creator_id = c.id)
+		FROM creators c WHERE c.id = $1
+	`
+
+	creator := &models.Creator{}
+	err := s.db.QueryRowContext(ctx, query, creatorID).Scan(
+		&creator.ID, &creator.Slug, &creator.Name, &creator.Bio, &creator.AvatarURL,
+		&creator.CreatedAt, &creator.UpdatedAt, &creator.FollowerCount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrCreatorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creator: %v", err)
+	}
+
+	return creator, nil
+}
+
+// GetCreatorBySlug returns the creator with the slug, or nil if there is none
+func (s *SupabaseService) GetCreatorBySlug(ctx context.Context, slug string) (*models.Creator, error) {
+	query := `SELECT id FROM creators WHERE slug = $1`
+
+	var creatorID uuid.UUID
+	err := s.db.QueryRowContext(ctx, query, slug).Scan(&creatorID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creator: %v", err)
+	}
+
+	return s.GetCreatorByID(ctx, creatorID)
+}
+
+// UpdateCreator saves the creator and records the change in the audit log
+func (s *SupabaseService) UpdateCreator(ctx context.Context, creator *models.Creator, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE creators SET slug = $2, name = $3, bio = $4, avatar_url = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	creator.UpdatedAt = time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		creator.ID, creator.Slug, creator.Name, creator.Bio, creator.AvatarURL, creator.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update creator: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrCreatorNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit creator update: %v", err)
+	}
+
+	return nil
+}
+
+// GetSeriesCredits returns the credits of each of the series, in display order, keyed by
+// series ID
+func (s *SupabaseService) GetSeriesCredits(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID][]*models.Credit, error) {
+	query := `
+		SELECT sc.series_id, c.id, c.slug, c.name, COALESCE(c.avatar_url, ''), sc.role
+		FROM series_credits sc
+		JOIN creators c ON c.id = sc.creator_id
+		WHERE sc.series_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY sc.series_id, sc.position
+	`
+
+	return s.getCredits(ctx, query, seriesIDs)
+}
+
+// GetEpisodeCredits returns the credits of each of the episodes, in display order, keyed by
+// episode ID
+func (s *SupabaseService) GetEpisodeCredits(ctx context.Context, episodeIDs []uuid.UUID) (map[uuid.UUID][]*models.Credit, error) {
+	query := `
+		SELECT ec.episode_id, c.id, c.slug, c.name, COALESCE(c.avatar_url, ''), ec.role
+		FROM episode_credits ec
+		JOIN creators c ON c.id = ec.creator_id
+		WHERE ec.episode_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY ec.episode_id, ec.position
+	`
+
+	return s.getCredits(ctx, query, episodeIDs)
+}
+
+// getCredits runs a credits query whose first column is the ID the credits belong to
+func (s *SupabaseService) getCredits(ctx context.Context, query string, ownerIDs []uuid.UUID) (map[uuid.UUID][]*models.Credit, error) {
+	byID := map[uuid.UUID][]*models.Credit{}
+	if len(ownerIDs) == 0 {
+		return byID, nil
+	}
+
+	ids, err := json.Marshal(ownerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credits: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit := &models.Credit{}
+		var ownerID uuid.UUID
+		if err := rows.Scan(&ownerID, &credit.CreatorID, &credit.Slug, &credit.Name, &credit.AvatarURL, &credit.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan credit: %v", err)
+		}
+		byID[ownerID] = append(byID[ownerID], credit)
+	}
+
+	return byID, nil
+}
+
+// SetSeriesCredits replaces the series' credits, in order, and records the change in the
+// audit log. A non-empty authorNames also replaces the series' legacy author label.
+func (s *SupabaseService) SetSeriesCredits(ctx context.Context, seriesID uuid.UUID, credits []models.CreditInput, authorNames string, entry *models.AuditLogEntry) error {
+	clearQuery := `DELETE FROM series_credits WHERE series_id = $1`
+	insertQuery := `
+		INSERT INTO series_credits (series_id, creator_id, role, position)
+		SELECT $1, c.creator_id, c.role, c.position
+		FROM jsonb_to_recordset($2::jsonb) AS c(creator_id UUID, role VARCHAR(20), position INTEGER)
+	`
+	labelQuery := `
+		UPDATE series SET author = CASE WHEN $2 = '' THEN author ELSE $2 END, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	rows, err := creditRows(credits)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, labelQuery, seriesID, authorNames)
+	if err != nil {
+		return fmt.Errorf("failed to update series author: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrSeriesNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, clearQuery, seriesID); err != nil {
+		return fmt.Errorf("failed to clear series credits: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, seriesID, rows); err != nil {
+		return fmt.Errorf("failed to set series credits: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series credits: %v", err)
+	}
+
+	return nil
+}
+
+// SetEpisodeCredits replaces the episode's credits, in order, and records the change in the
+// audit log
+func (s *SupabaseService) SetEpisodeCredits(ctx context.Context, episodeID uuid.UUID, credits []models.CreditInput, entry *models.AuditLogEntry) error {
+	clearQuery := `DELETE FROM episode_credits WHERE episode_id = $1`
+	insertQuery := `
+		INSERT INTO episode_credits (episode_id, creator_id, role, position)
+		SELECT $1, c.creator_id, c.role, c.position
+		FROM jsonb_to_recordset($2::jsonb) AS c(creator_id UUID, role VARCHAR(20), position INTEGER)
+	`
+
+	rows, err := creditRows(credits)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, clearQuery, episodeID); err != nil {
+		return fmt.Errorf("failed to clear episode credits: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, episodeID, rows); err != nil {
+		return fmt.Errorf("failed to set episode credits: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit episode credits: %v", err)
+	}
+
+	return nil
+}
+
+// creditRows encodes credits as a JSON recordset with their display positions
+func creditRows(credits []models.CreditInput) (string, error) {
+	type creditRow struct {
+		CreatorID uuid.UUID `json:"creator_id"`
+		Role      string    `json:"role"`
+		Position  int       `json:"position"`
+	}
+	rows := make([]creditRow, len(credits))
+	for i, credit := range credits {
+		rows[i] = creditRow{CreatorID: credit.CreatorID, Role: credit.Role, Position: i}
+	}
+
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal credits: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// GetCreatorSeries returns the published series the creator is credited on, newest first,
+// with their roles on each
+func (s *SupabaseService) GetCreatorSeries(ctx context.Context, creatorID uuid.UUID) ([]*models.CreditedSeries, error) {
+	query := `
+		SELECT s.id, s.title, COALESCE(s.cover_image, ''), s.total_episodes, string_agg(sc.role, ',' ORDER BY sc.position)
+		FROM series_credits sc
+		JOIN series s ON s.id = sc.series_id
+		WHERE sc.creator_id = $1
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		GROUP BY s.id
+		ORDER BY COALESCE(s.publish_at, s.created_at) DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creator series: %v", err)
+	}
+	defer rows.Close()
+
+	works := []*models.CreditedSeries{}
+	for rows.Next() {
+		work := &models.CreditedSeries{}
+		var roles string
+		if err := rows.Scan(&work.ID, &work.Title, &work.CoverImage, &work.TotalEpisodes, &roles); err != nil {
+			return nil, fmt.Errorf("failed to scan creator series: %v", err)
+		}
+		work.Roles = strings.Split(roles, ",")
+		works = append(works, work)
+	}
+
+	return works, nil
+}
+
+// GetCreatorEpisodes returns the released episodes the creator is credited on individually,
+// newest first, with their roles on each
+func (s *SupabaseService) GetCreatorEpisodes(ctx context.Context, creatorID uuid.UUID, limit int) ([]*models.CreditedEpisode, error) {
+	query := `
+		SELECT e.id, s.id, s.title, e.title, e.episode_number, string_agg(ec.role, ',' ORDER BY ec.position)
+		FROM episode_credits ec
+		JOIN episodes e ON e.id = ec.episode_id
+		JOIN series s ON s.id = e.series_id
+		WHERE ec.creator_id = $1
+		  AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		GROUP BY e.id, s.id
+		ORDER BY COALESCE(e.publish_at, e.created_at) DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, creatorID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creator episodes: %v", err)
+	}
+	defer rows.Close()
+
+	works := []*models.CreditedEpisode{}
+	for rows.Next() {
+		work := &models.CreditedEpisode{}
+		var roles string
+		if err := rows.Scan(&work.ID, &work.SeriesID, &work.SeriesTitle, &work.Title, &work.EpisodeNumber, &roles); err != nil {
+			return nil, fmt.Errorf("failed to scan creator episode: %v", err)
+		}
+		work.Roles = strings.Split(roles, ",")
+		works = append(works, work)
+	}
+
+	return works, nil
+}
+
+// FollowCreator makes the user follow the creator. Following twice is not an error.
+func (s *SupabaseService) FollowCreator(ctx context.Context, userID, creatorID uuid.UUID) error {
+	query := `
+		INSERT INTO creator_follows (user_id, creator_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, creator_id) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, creatorID); err != nil {
+		return fmt.Errorf("failed to follow creator: %v", err)
+	}
+
+	return nil
+}
+
+// UnfollowCreator stops the user following the creator
+func (s *SupabaseService) UnfollowCreator(ctx context.Context, userID, creatorID uuid.UUID) error {
+	query := `DELETE FROM creator_follows WHERE user_id = $1 AND creator_id = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, creatorID); err != nil {
+		return fmt.Errorf("failed to unfollow creator: %v", err)
+	}
+
+	return nil
+}
+
+// GetFollowedCreators returns the creators the user follows, most recently followed first
+func (s *SupabaseService) GetFollowedCreators(ctx context.Context, userID uuid.UUID) ([]*models.Creator, error) {
+	query := `
+		SELECT c.id, c.slug, c.name, COALESCE(c.bio, ''), COALESCE(c.avatar_url, ''), c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM creator_follows cf WHERE cf.creator_id = c.id)
+		FROM creator_follows f
+		JOIN creators c ON c.id = f.creator_id
+		WHERE f.user_id = $1
+		ORDER BY f.created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed creators: %v", err)
+	}
+	defer rows.Close()
+
+	creators := []*models.Creator{}
+	for rows.Next() {
+		creator := &models.Creator{}
+		err := rows.Scan(
+			&creator.ID, &creator.Slug, &creator.Name, &creator.Bio, &creator.AvatarURL,
+			&creator.CreatedAt, &creator.UpdatedAt, &creator.FollowerCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan followed creator: %v", err)
+		}
+		creators = append(creators, creator)
+	}
+
+	return creators, nil
+}
+
+// GetCreatorFeed returns the released episodes credited to creators the user follows, either
+// on the episode itself or on its series, newest release first. An episode with several
+// followed creators appears once.
+func (s *SupabaseService) GetCreatorFeed(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.FeedItem, error) {
+	query := `
+		WITH credited AS (
+		    SELECT e.id AS episode_id, sc.creator_id, sc.position
+		    FROM series_credits sc
+		    JOIN episodes e ON e.series_id = sc.series_id
+		    UNION ALL
+		    SELECT ec.episode_id, ec.creator_id, ec.position
+		    FROM episode_credits ec
+		)
+		SELECT episode_id, title, episode_number, series_id, series_title, cover_image, creator_id, creator_name, released_at
+		FROM (
+		    SELECT DISTINCT ON (e.id)
+		           e.id AS episode_id, e.title, e.episode_number, s.id AS series_id, s.title AS series_title,
+		           COALESCE(s.cover_image, '') AS cover_image, c.id AS creator_id, c.name AS creator_name,
+		           COALESCE(e.publish_at, e.created_at) AS released_at
+		    FROM creator_follows f
+		    JOIN credited cr ON cr.creator_id = f.creator_id
+		    JOIN creators c ON c.id = cr.creator_id
+		    JOIN episodes e ON e.id = cr.episode_id
+		    JOIN series s ON s.id = e.series_id
+		    WHERE f.user_id = $1
+		      AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		      AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		    ORDER BY e.id, cr.position
+		) feed
+		ORDER BY released_at DESC, episode_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creator feed: %v", err)
+	}
+	defer rows.Close()
+
+	items := []*models.FeedItem{}
+	for rows.Next() {
+		item := &models.FeedItem{}
+		err := rows.Scan(
+			&item.EpisodeID, &item.Title, &item.EpisodeNumber, &item.SeriesID, &item.SeriesTitle,
+			&item.CoverImage, &item.CreatorID, &item.CreatorName, &item.ReleasedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed item: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Episode operations
+func (s *SupabaseService) CreateEpisode(ctx context.Context, episode *models.Episode) error {
+	query := `
+		INSERT INTO episodes (id, series_id, title, description, audio_url, duration, audio_codec, audio_bitrate, processing_status, episode_number, coin_price, is_locked, status, publish_at, free_at, audio_language, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+		        COALESCE(NULLIF($16, ''), (SELECT language FROM series WHERE id = $2), 'en'), $17, $18)
+		RETURNING audio_language
+	`
+
+	if episode.ProcessingStatus == "" {
+		episode.ProcessingStatus = "unprocessed"
+	}
+	if episode.Status == "" {
+		episode.Status = "draft"
+	}
+	episode.ID = uuid.New()
+	episode.CreatedAt = time.Now()
+	episode.UpdatedAt = time.Now()
+
+	// Without an audio language the episode is taken to be in its series' language
+	err := s.db.QueryRowContext(ctx, query,
+		episode.ID, episode.SeriesID, episode.Title, episode.Description,
+		episode.AudioURL, episode.Duration, episode.AudioCodec, episode.AudioBitrate, episode.ProcessingStatus,
+		episode.EpisodeNumber, episode.CoinPrice, episode.IsLocked, episode.Status, episode.PublishAt, episode.FreeAt,
+		episode.AudioLanguage, episode.CreatedAt, episode.UpdatedAt,
+	).Scan(&episode.AudioLanguage)
+
+	if err != nil {
+		return fmt.Errorf("failed to create episode: %v", err)
+	}
+
+	return nil
+}
+
+// GetEpisodesBySeriesID returns the episodes of the series listeners can see: published, or
+// scheduled with a publish time that has passed, and not deleted
+func (s *SupabaseService) GetEpisodesBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*models.Episode, error) {
+	query := `
+		SELECT id, series_id, title, description, audio_url, duration, COALESCE(audio_codec, ''), COALESCE(audio_bitrate, 0),
+		       COALESCE(processing_status, 'unprocessed'), COALESCE(aac_audio_url, ''), COALESCE(opus_audio_url, ''), episode_number, coin_price, is_locked,
+		       status, publish_at, free_at, audio_language, created_at, updated_at
+		FROM episodes
+		WHERE series_id = $1 AND deleted_at IS NULL AND (status = 'published' OR (status = 'scheduled' AND publish_at <= NOW()))
+		ORDER BY episode_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episodes: %v", err)
+	}
+	defer rows.Close()
+
+	var episodes []*models.Episode
+	for rows.Next() {
+		episode := &models.Episode{}
+		err := rows.Scan(
+			&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description,
+			&episode.AudioURL, &episode.Duration, &episode.AudioCodec, &episode.AudioBitrate,
+			&episode.ProcessingStatus, &episode.AACAudioURL, &episode.OpusAudioURL,
+			&episode.EpisodeNumber, &episode.CoinPrice, &episode.IsLocked,
+			&episode.Status, &episode.PublishAt, &episode.FreeAt, &episode.AudioLanguage, &episode.CreatedAt, &episode.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %v", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, nil
+}
+
+// GetAllEpisodesBySeriesID returns every episode of the series that has not been deleted,
+// whatever its status
+func (s *SupabaseService) GetAllEpisodesBySeriesID(ctx context.Context, seriesID uuid.UUID) ([]*models.Episode, error) {
+	query := `
+		SELECT id, series_id, title, description, audio_url, duration, COALESCE(audio_codec, ''), COALESCE(audio_bitrate, 0),
+		       COALESCE(processing_status, 'unprocessed'), COALESCE(aac_audio_url, ''), COALESCE(opus_audio_url, ''), episode_number, coin_price, is_locked,
+		       status, publish_at, free_at, audio_language, created_at, updated_at
+		FROM episodes WHERE series_id = $1 AND deleted_at IS NULL ORDER BY episode_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episodes: %v", err)
+	}
+	defer rows.Close()
+
+	episodes := []*models.Episode{}
+	for rows.Next() {
+		episode := &models.Episode{}
+		err := rows.Scan(
+			&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description,
+			&episode.AudioURL, &episode.Duration, &episode.AudioCodec, &episode.AudioBitrate,
+			&episode.ProcessingStatus, &episode.AACAudioURL, &episode.OpusAudioURL,
+			&episode.EpisodeNumber, &episode.CoinPrice, &episode.IsLocked,
+			&episode.Status, &episode.PublishAt, &episode.FreeAt, &episode.AudioLanguage, &episode.CreatedAt, &episode.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %v", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, nil
+}
+
+func (s *SupabaseService) GetEpisodeByID(ctx context.Context, episodeID uuid.UUID) (*models.Episode, error) {
+	query := `
+		SELECT id, series_id, title, description, audio_url, duration, COALESCE(audio_codec, ''), COALESCE(audio_bitrate, 0),
+		       COALESCE(processing_status, 'unprocessed'), COALESCE(aac_audio_url, ''), COALESCE(opus_audio_url, ''), episode_number, coin_price, is_locked,
+		       status, publish_at, free_at, audio_language, deleted_at, created_at, updated_at
+		FROM episodes WHERE id = $1
+	`
+
+	episode := &models.Episode{}
+	err := s.db.QueryRowContext(ctx, query, episodeID).Scan(
+		&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description,
+		&episode.AudioURL, &episode.Duration, &episode.AudioCodec, &episode.AudioBitrate,
+		&episode.ProcessingStatus, &episode.AACAudioURL, &episode.OpusAudioURL,
+		&episode.EpisodeNumber, &episode.CoinPrice, &episode.IsLocked,
+		&episode.Status, &episode.PublishAt, &episode.FreeAt, &episode.AudioLanguage, &episode.DeletedAt,
+		&episode.CreatedAt, &episode.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode: %v", err)
+	}
+
+	return episode, nil
+}
+
+// UpdateEpisodeRenditions records the transcode status and rendition paths of an episode
+func (s *SupabaseService) UpdateEpisodeRenditions(ctx context.Context, episode *models.Episode) error {
+	query := `
+		UPDATE episodes SET processing_status = $2, aac_audio_url = NULLIF($3, ''), opus_audio_url = NULLIF($4, ''), updated_at = $5
+		WHERE id = $1
+	`
+
+	episode.UpdatedAt = time.Now()
+
+	_, err := s.db.ExecContext(ctx, query,
+		episode.ID, episode.ProcessingStatus, episode.AACAudioURL, episode.OpusAudioURL, episode.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update episode renditions: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateEpisode saves the episode's editable details and records the change in the audit log.
+// It returns ErrEpisodeNotFound if the episode does not exist or has been deleted.
+func (s *SupabaseService) UpdateEpisode(ctx context.Context, episode *models.Episode, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE episodes SET title = $2, description = $3, episode_number = $4, coin_price = $5, is_locked = $6,
+		                    status = $7, publish_at = $8, free_at = $10, audio_language = $11, updated_at = $9,
+		                    -- a manual status change takes the episode out of the drip schedule
+		                    drip_queued = drip_queued AND status = $7 AND publish_at IS NOT DISTINCT FROM $8,
+		                    drip_scheduled = drip_scheduled AND status = $7 AND publish_at IS NOT DISTINCT FROM $8
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	episode.UpdatedAt = time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		episode.ID, episode.Title, episode.Description, episode.EpisodeNumber,
+		episode.CoinPrice, episode.IsLocked, episode.Status, episode.PublishAt, episode.UpdatedAt, episode.FreeAt,
+		episode.AudioLanguage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update episode: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrEpisodeNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit episode update: %v", err)
+	}
+
+	return nil
+}
+
+// SoftDeleteEpisode removes the episode from its series and records the deletion in the
+// audit log. Its row is kept so existing purchases still resolve, and its episode number
+// is freed for reuse.
+func (s *SupabaseService) SoftDeleteEpisode(ctx context.Context, episodeID uuid.UUID, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE episodes SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, episodeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete episode: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrEpisodeNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit episode deletion: %v", err)
+	}
+
+	return nil
+}
+
+// ReorderEpisodes renumbers the series' episodes 1..n in the order given and records the
+// change in the audit log. The unique index on (series_id, episode_number) is checked row
+// by row, so every episode is first moved to its negated number, which no live episode uses.
+func (s *SupabaseService) ReorderEpisodes(ctx context.Context, seriesID uuid.UUID, episodeIDs []uuid.UUID, entry *models.AuditLogEntry) error {
+	parkQuery := `
+		UPDATE episodes SET episode_number = -episode_number
+		WHERE series_id = $1 AND deleted_at IS NULL
+	`
+	renumberQuery := `
+		UPDATE episodes e SET episode_number = o.episode_number, updated_at = NOW()
+		FROM jsonb_to_recordset($2::jsonb) AS o(id UUID, episode_number INTEGER)
+		WHERE e.id = o.id AND e.series_id = $1 AND e.deleted_at IS NULL
+	`
+
+	type position struct {
+		ID            uuid.UUID `json:"id"`
+		EpisodeNumber int       `json:"episode_number"`
+	}
+	positions := make([]position, len(episodeIDs))
+	for i, episodeID := range episodeIDs {
+		positions[i] = position{ID: episodeID, EpisodeNumber: i + 1}
+	}
+
+	rows, err := json.Marshal(positions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal episode order: %v", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, parkQuery, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to reorder episodes: %v", err)
+	}
+	if parked, _ := result.RowsAffected(); parked != int64(len(episodeIDs)) {
+		return ErrInvalidEpisodeOrder
+	}
+
+	result, err = tx.ExecContext(ctx, renumberQuery, seriesID, string(rows))
+	if err != nil {
+		return fmt.Errorf("failed to reorder episodes: %v", err)
+	}
+	if renumbered, _ := result.RowsAffected(); renumbered != int64(len(episodeIDs)) {
+		return ErrInvalidEpisodeOrder
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit episode order: %v", err)
+	}
+
+	return nil
+}
+
+// PublishScheduledContent marks scheduled series and episodes whose publish time has passed
+// as published, returning how many of each were released
+func (s *SupabaseService) PublishScheduledContent(ctx context.Context, now time.Time) (int64, int64, error) {
+	seriesQuery := `
+		UPDATE series SET status = 'published', updated_at = NOW()
+		WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+	`
+	episodesQuery := `
+		UPDATE episodes SET status = 'published', updated_at = NOW()
+		WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+	`
+
+	result, err := s.db.ExecContext(ctx, seriesQuery, now)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to publish scheduled series: %v", err)
+	}
+	series, _ := result.RowsAffected()
+
+	result, err = s.db.ExecContext(ctx, episodesQuery, now)
+	if err != nil {
+		return series, 0, fmt.Errorf("failed to publish scheduled episodes: %v", err)
+	}
+	episodes, _ := result.RowsAffected()
+
+	return series, episodes, nil
+}
+
+// Library operations
+
+// FollowSeries makes the user follow the series. Following twice is not an error and keeps
+// the original follow time, which decides what counts as a new episode.
+func (s *SupabaseService) FollowSeries(ctx context.Context, userID, seriesID uuid.UUID) error {
+	query := `
+		INSERT INTO series_follows (user_id, series_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, series_id) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, seriesID); err != nil {
+		return fmt.Errorf("failed to follow series: %v", err)
+	}
+
+	return nil
+}
+
+// UnfollowSeries stops the user following the series
+func (s *SupabaseService) UnfollowSeries(ctx context.Context, userID, seriesID uuid.UUID) error {
+	query := `DELETE FROM series_follows WHERE user_id = $1 AND series_id = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, seriesID); err != nil {
+		return fmt.Errorf("failed to unfollow series: %v", err)
+	}
+
+	return nil
+}
+
+// GetFollowedSeries returns the published series the user follows, most recently followed first
+func (s *SupabaseService) GetFollowedSeries(ctx context.Context, userID uuid.UUID) ([]*models.Series, error) {
+	query := `
+		SELECT s.id, s.title, s.description, s.cover_image, COALESCE(s.cover_images, '{}'), s.author, s.category, s.language, s.is_premium,
+		       s.total_episodes, s.created_by, s.status, s.publish_at, s.deleted_at, s.created_at, s.updated_at
+		FROM series_follows f
+		JOIN series s ON s.id = f.series_id
+		WHERE f.user_id = $1
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		ORDER BY f.created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed series: %v", err)
+	}
+	defer rows.Close()
+
+	followed := []*models.Series{}
+	for rows.Next() {
+		series := &models.Series{}
+		var coverImages []byte
+		err := rows.Scan(
+			&series.ID, &series.Title, &series.Description, &series.CoverImage, &coverImages,
+			&series.Author, &series.Category, &series.Language, &series.IsPremium, &series.TotalEpisodes,
+			&series.CreatedBy, &series.Status, &series.PublishAt, &series.DeletedAt, &series.CreatedAt, &series.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan followed series: %v", err)
+		}
+		if err := json.Unmarshal(coverImages, &series.CoverImages); err != nil {
+			return nil, fmt.Errorf("failed to decode cover images: %v", err)
+		}
+		followed = append(followed, series)
+	}
+
+	return followed, nil
+}
+
+// GetSeriesFollowerCounts returns how many users follow each of the series, keyed by series
+// ID. Series without followers are left out.
+func (s *SupabaseService) GetSeriesFollowerCounts(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT series_id, COUNT(*)
+		FROM series_follows
+		WHERE series_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		GROUP BY series_id
+	`
+
+	counts := map[uuid.UUID]int{}
+	if len(seriesIDs) == 0 {
+		return counts, nil
+	}
+
+	ids, err := json.Marshal(seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal series IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower counts: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seriesID uuid.UUID
+		var count int
+		if err := rows.Scan(&seriesID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan follower count: %v", err)
+		}
+		counts[seriesID] = count
+	}
+
+	return counts, nil
+}
+
+// FavoriteEpisode adds the episode to the user's favorites. Favoriting twice is not an error.
+func (s *SupabaseService) FavoriteEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	query := `
+		INSERT INTO episode_favorites (user_id, episode_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, episode_id) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, episodeID); err != nil {
+		return fmt.Errorf("failed to favorite episode: %v", err)
+	}
+
+	return nil
+}
+
+// UnfavoriteEpisode removes the episode from the user's favorites
+func (s *SupabaseService) UnfavoriteEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	query := `DELETE FROM episode_favorites WHERE user_id = $1 AND episode_id = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, episodeID); err != nil {
+		return fmt.Errorf("failed to unfavorite episode: %v", err)
+	}
+
+	return nil
+}
+
+// GetFavoriteEpisodes returns the user's released favorite episodes, most recently added first
+func (s *SupabaseService) GetFavoriteEpisodes(ctx context.Context, userID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	query := `
+		SELECT e.id, e.title, e.episode_number, e.duration, s.id, s.title, COALESCE(s.cover_image, ''), 0, f.created_at
+		FROM episode_favorites f
+		JOIN episodes e ON e.id = f.episode_id
+		JOIN series s ON s.id = e.series_id
+		WHERE f.user_id = $1
+		  AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		ORDER BY f.created_at DESC
+	`
+
+	return s.getLibraryEpisodes(ctx, query, userID)
+}
+
+// QueueEpisode adds the episode to the end of the user's listen-later queue. An episode
+// already in the queue keeps its place.
+func (s *SupabaseService) QueueEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	query := `
+		INSERT INTO listen_later (user_id, episode_id, position, created_at)
+		SELECT $1, $2, COALESCE(MAX(position), 0) + 1, NOW()
+		FROM listen_later WHERE user_id = $1
+		ON CONFLICT (user_id, episode_id) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, episodeID); err != nil {
+		return fmt.Errorf("failed to queue episode: %v", err)
+	}
+
+	return nil
+}
+
+// UnqueueEpisode removes the episode from the user's listen-later queue
+func (s *SupabaseService) UnqueueEpisode(ctx context.Context, userID, episodeID uuid.UUID) error {
+	query := `DELETE FROM listen_later WHERE user_id = $1 AND episode_id = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, episodeID); err != nil {
+		return fmt.Errorf("failed to unqueue episode: %v", err)
+	}
+
+	return nil
+}
+
+// GetQueue returns the released episodes of the user's listen-later queue in order, numbered from 1
+func (s *SupabaseService) GetQueue(ctx context.Context, userID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	query := `
+		SELECT e.id, e.title, e.episode_number, e.duration, s.id, s.title, COALESCE(s.cover_image, ''),
+		       ROW_NUMBER() OVER (ORDER BY q.position, q.created_at), q.created_at
+		FROM listen_later q
+		JOIN episodes e ON e.id = q.episode_id
+		JOIN series s ON s.id = e.series_id
+		WHERE q.user_id = $1
+		  AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		ORDER BY q.position, q.created_at
+	`
+
+	return s.getLibraryEpisodes(ctx, query, userID)
+}
+
+// ReorderQueue puts the listed episodes of the user's queue at positions 1 to n in the order
+// given. Queued episodes that are not listed, because they are no longer released, move
+// behind them in their existing order.
+func (s *SupabaseService) ReorderQueue(ctx context.Context, userID uuid.UUID, episodeIDs []uuid.UUID) error {
+	parkQuery := `UPDATE listen_later SET position = position + $2 WHERE user_id = $1`
+	reorderQuery := `
+		UPDATE listen_later q SET position = o.position
+		FROM jsonb_to_recordset($2::jsonb) AS o(episode_id UUID, position INTEGER)
+		WHERE q.user_id = $1 AND q.episode_id = o.episode_id
+	`
+
+	type position struct {
+		EpisodeID uuid.UUID `json:"episode_id"`
+		Position  int       `json:"position"`
+	}
+	positions := make([]position, len(episodeIDs))
+	for i, episodeID := range episodeIDs {
+		positions[i] = position{EpisodeID: episodeID, Position: i + 1}
+	}
+
+	rows, err := json.Marshal(positions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queue order: %v", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, parkQuery, userID, len(episodeIDs)); err != nil {
+		return fmt.Errorf("failed to reorder queue: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, reorderQuery, userID, string(rows))
+	if err != nil {
+		return fmt.Errorf("failed to reorder queue: %v", err)
+	}
+	if reordered, _ := result.RowsAffected(); reordered != int64(len(episodeIDs)) {
+		return ErrInvalidQueueOrder
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit queue order: %v", err)
+	}
+
+	return nil
+}
+
+// getLibraryEpisodes runs a favorites or queue query for the user and scans its rows
+func (s *SupabaseService) getLibraryEpisodes(ctx context.Context, query string, userID uuid.UUID) ([]*models.LibraryEpisode, error) {
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library episodes: %v", err)
+	}
+	defer rows.Close()
+
+	episodes := []*models.LibraryEpisode{}
+	for rows.Next() {
+		episode := &models.LibraryEpisode{}
+		err := rows.Scan(
+			&episode.EpisodeID, &episode.Title, &episode.EpisodeNumber, &episode.Duration, &episode.SeriesID,
+			&episode.SeriesTitle, &episode.CoverImage, &episode.Position, &episode.AddedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan library episode: %v", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, nil
+}
+
+// Notification operations
+
+// CreateNewEpisodeNotifications notifies the followers of each series about its episodes
+// released since the given time, skipping episodes released before the user followed the
+// series. Running it again over the same period adds nothing, so every release path, whether
+// publishing, scheduling or drip releases, is covered by running it periodically.
+func (s *SupabaseService) CreateNewEpisodeNotifications(ctx context.Context, since time.Time) (int64, error) {
+	query := `
+		INSERT INTO notifications (user_id, kind, series_id, episode_id, created_at)
+		SELECT f.user_id, 'new_episode', s.id, e.id, NOW()
+		FROM episodes e
+		JOIN series s ON s.id = e.series_id
+		JOIN series_follows f ON f.series_id = s.id
+		WHERE e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		  AND COALESCE(e.publish_at, e.created_at) > $1
+		  AND COALESCE(e.publish_at, e.created_at) > f.created_at
+		ON CONFLICT (user_id, kind, episode_id) DO NOTHING
+	`
+
+	result, err := s.db.ExecContext(ctx, query, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create notifications: %v", err)
+	}
+
+	created, _ := result.RowsAffected()
+	return created, nil
+}
+
+// GetNotifications returns a page of the user's notifications, newest first, leaving out
+// episodes that have since been deleted
+func (s *SupabaseService) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	query := `
+		SELECT n.id, n.kind, s.id, s.title, COALESCE(s.cover_image, ''), e.id, e.title, e.episode_number, n.read_at, n.created_at
+		FROM notifications n
+		JOIN episodes e ON e.id = n.episode_id
+		JOIN series s ON s.id = n.series_id
+		WHERE n.user_id = $1 AND e.deleted_at IS NULL AND s.deleted_at IS NULL
+		  AND (NOT $2 OR n.read_at IS NULL)
+		ORDER BY n.created_at DESC, n.id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %v", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	for rows.Next() {
+		notification := &models.Notification{}
+		err := rows.Scan(
+			&notification.ID, &notification.Kind, &notification.SeriesID, &notification.SeriesTitle, &notification.CoverImage,
+			&notification.EpisodeID, &notification.EpisodeTitle, &notification.EpisodeNumber, &notification.ReadAt, &notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %v", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns how many of the user's notifications are unread
+func (s *SupabaseService) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM notifications n
+		JOIN episodes e ON e.id = n.episode_id
+		WHERE n.user_id = $1 AND n.read_at IS NULL AND e.deleted_at IS NULL
+	`
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %v", err)
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read. It returns
+// ErrNotificationNotFound if the user has no such notification.
+func (s *SupabaseService) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := s.db.ExecContext(ctx, query, notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %v", err)
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of the user as read
+func (s *SupabaseService) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark notifications read: %v", err)
+	}
+
+	return nil
+}
+
+// Review operations
+
+// reviewColumns are the columns scanReview reads, from series_reviews r joined with series s and users u
+const reviewColumns = `
+	r.id, r.series_id, s.title, r.user_id, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), r.rating, r.body,
+	r.status, r.report_count, COALESCE(r.reply, ''), r.replied_at, r.created_at, r.updated_at
+`
+
+// HasListenedOrPurchasedFromSeries reports whether the user has a completed purchase of the
+// series or one of its episodes, or has listened to one of its episodes. Listening counts only
+// as the server saw it: audio streamed to the user, or progress saved through the access check.
+func (s *SupabaseService) HasListenedOrPurchasedFromSeries(ctx context.Context, userID, seriesID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+		    SELECT 1 FROM purchases p
+		    LEFT JOIN episodes e ON e.id = p.episode_id
+		    WHERE p.user_id = $1 AND p.status = 'completed' AND (p.series_id = $2 OR e.series_id = $2)
+		) OR EXISTS (
+		    SELECT 1 FROM stream_usage u
+		    JOIN episodes e ON e.id = u.episode_id
+		    WHERE u.user_id = $1 AND e.series_id = $2
+		) OR EXISTS (
+		    SELECT 1 FROM listening_progress lp
+		    JOIN episodes e ON e.id = lp.episode_id
+		    WHERE lp.user_id = $1 AND e.series_id = $2
+		)
+	`
+
+	var engaged bool
+	if err := s.db.QueryRowContext(ctx, query, userID, seriesID).Scan(&engaged); err != nil {
+		return false, fmt.Errorf("failed to check listening and purchase history: %v", err)
+	}
+
+	return engaged, nil
+}
+
+// GetReviewByID returns the review, or ErrReviewNotFound if there is none
+func (s *SupabaseService) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*models.Review, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM series_reviews r
+		JOIN series s ON s.id = r.series_id
+		JOIN users u ON u.id = r.user_id
+		WHERE r.id = $1
+	`
+
+	review, err := scanReview(s.db.QueryRowContext(ctx, query, reviewID))
+	if err == sql.ErrNoRows {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %v", err)
+	}
+
+	return review, nil
+}
+
+// GetUserReview returns the user's review of the series, or nil if they have not reviewed it
+func (s *SupabaseService) GetUserReview(ctx context.Context, userID, seriesID uuid.UUID) (*models.Review, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM series_reviews r
+		JOIN series s ON s.id = r.series_id
+		JOIN users u ON u.id = r.user_id
+		WHERE r.user_id = $1 AND r.series_id = $2
+	`
+
+	review, err := scanReview(s.db.QueryRowContext(ctx, query, userID, seriesID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %v", err)
+	}
+
+	return review, nil
+}
+
+// GetSeriesReviews returns a page of the series' reviews that are not hidden, newest first
+func (s *SupabaseService) GetSeriesReviews(ctx context.Context, seriesID uuid.UUID, limit, offset int) ([]*models.Review, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM series_reviews r
+		JOIN series s ON s.id = r.series_id
+		JOIN users u ON u.id = r.user_id
+		WHERE r.series_id = $1 AND r.status <> 'hidden'
+		ORDER BY r.created_at DESC, r.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %v", err)
+	}
+	defer rows.Close()
+
+	return scanReviews(rows)
+}
+
+// GetModerationQueue returns a page of reviews for moderators, most reported first. An empty
+// status returns the reviews waiting for a decision: flagged ones and visible ones that have
+// been reported.
+func (s *SupabaseService) GetModerationQueue(ctx context.Context, status string, limit, offset int) ([]*models.Review, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM series_reviews r
+		JOIN series s ON s.id = r.series_id
+		JOIN users u ON u.id = r.user_id
+		WHERE ($1 = '' AND (r.status = 'flagged' OR (r.status = 'visible' AND r.report_count > 0)))
+		   OR r.status = $1
+		ORDER BY r.report_count DESC, r.updated_at DESC, r.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation queue: %v", err)
+	}
+	defer rows.Close()
+
+	return scanReviews(rows)
+}
+
+// GetSeriesRatings returns the rating summary of each of the series, keyed by series ID.
+// Series nobody has rated are left out.
+func (s *SupabaseService) GetSeriesRatings(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID]*models.RatingSummary, error) {
+	query := `
+		SELECT series_id, rating_average, rating_count
+		FROM series_rankings
+		WHERE series_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid) AND rating_count > 0
+	`
+
+	ratings := map[uuid.UUID]*models.RatingSummary{}
+	if len(seriesIDs) == 0 {
+		return ratings, nil
+	}
+
+	ids, err := json.Marshal(seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal series IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series ratings: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seriesID uuid.UUID
+		rating := &models.RatingSummary{}
+		if err := rows.Scan(&seriesID, &rating.Average, &rating.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan series rating: %v", err)
+		}
+		ratings[seriesID] = rating
+	}
+
+	return ratings, nil
+}
+
+// UpsertReview saves the user's review of the series, replacing the rating and text of any
+// review they already left, and updates the series' rating. Moderation state and replies
+// are kept.
+func (s *SupabaseService) UpsertReview(ctx context.Context, review *models.Review) error {
+	query := `
+		INSERT INTO series_reviews (id, series_id, user_id, rating, body, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (series_id, user_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			body = EXCLUDED.body
+		RETURNING id, status, report_count, COALESCE(reply, ''), replied_at, created_at, updated_at
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query,
+		uuid.New(), review.SeriesID, review.UserID, review.Rating, review.Body,
+	).Scan(&review.ID, &review.Status, &review.ReportCount, &review.Reply, &review.RepliedAt, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save review: %v", err)
+	}
+
+	if err := s.refreshSeriesRating(ctx, tx, review.SeriesID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteReview removes the user's review of the series and updates the series' rating. It
+// returns ErrReviewNotFound if there is none.
+func (s *SupabaseService) DeleteReview(ctx context.Context, userID, seriesID uuid.UUID) error {
+	query := `DELETE FROM series_reviews WHERE user_id = $1 AND series_id = $2`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, userID, seriesID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrReviewNotFound
+	}
+
+	if err := s.refreshSeriesRating(ctx, tx, seriesID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review deletion: %v", err)
+	}
+
+	return nil
+}
+
+// ReportReview records the user's report of the review. A user's first report counts towards
+// the review's report count; once that reaches flagThreshold a visible review is flagged for
+// moderators. Reporting twice is not an error and keeps the first report.
+func (s *SupabaseService) ReportReview(ctx context.Context, report *models.ReviewReport, flagThreshold int) error {
+	reportQuery := `
+		INSERT INTO review_reports (review_id, user_id, reason, details, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+		ON CONFLICT (review_id, user_id) DO NOTHING
+	`
+	countQuery := `
+		UPDATE series_reviews SET
+			report_count = report_count + 1,
+			status = CASE WHEN status = 'visible' AND report_count + 1 >= $2 THEN 'flagged' ELSE status END
+		WHERE id = $1
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, reportQuery, report.ReviewID, report.UserID, report.Reason, report.Details)
+	if err != nil {
+		return fmt.Errorf("failed to report review: %v", err)
+	}
+	if inserted, _ := result.RowsAffected(); inserted == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, countQuery, report.ReviewID, flagThreshold); err != nil {
+		return fmt.Errorf("failed to count review report: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review report: %v", err)
+	}
+
+	return nil
+}
+
+// GetReviewReports returns the reports of the review, newest first
+func (s *SupabaseService) GetReviewReports(ctx context.Context, reviewID uuid.UUID) ([]*models.ReviewReport, error) {
+	query := `
+		SELECT review_id, user_id, reason, COALESCE(details, ''), created_at
+		FROM review_reports
+		WHERE review_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review reports: %v", err)
+	}
+	defer rows.Close()
+
+	reports := []*models.ReviewReport{}
+	for rows.Next() {
+		report := &models.ReviewReport{}
+		err := rows.Scan(&report.ReviewID, &report.UserID, &report.Reason, &report.Details, &report.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review report: %v", err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// SetReviewStatus shows, flags or hides the review, updates the series' rating and records
+// the change in the audit log
+func (s *SupabaseService) SetReviewStatus(ctx context.Context, review *models.Review, entry *models.AuditLogEntry) error {
+	query := `UPDATE series_reviews SET status = $2 WHERE id = $1 RETURNING updated_at`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, review.ID, review.Status).Scan(&review.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to moderate review: %v", err)
+	}
+
+	if err := s.refreshSeriesRating(ctx, tx, review.SeriesID); err != nil {
+		return err
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review moderation: %v", err)
+	}
+
+	return nil
+}
+
+// SetReviewReply saves the team's reply to the review, or removes it when empty, and records
+// the change in the audit log
+func (s *SupabaseService) SetReviewReply(ctx context.Context, review *models.Review, repliedBy uuid.UUID, entry *models.AuditLogEntry) error {
+	query := `
+		UPDATE series_reviews SET
+			reply = NULLIF($2, ''),
+			replied_by = CASE WHEN $2 = '' THEN NULL ELSE $3::uuid END,
+			replied_at = CASE WHEN $2 = '' THEN NULL ELSE NOW() END
+		WHERE id = $1
+		RETURNING replied_at, updated_at
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, review.ID, review.Reply, repliedBy).Scan(&review.RepliedAt, &review.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reply to review: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review reply: %v", err)
+	}
+
+	return nil
+}
+
+// refreshSeriesRating recomputes the series' rating from its reviews that are not hidden, so
+// a review shows in the rating sort without waiting for RefreshSeriesRankings
+func (s *SupabaseService) refreshSeriesRating(ctx context.Context, tx *sql.Tx, seriesID uuid.UUID) error {
+	query := `
+		INSERT INTO series_rankings (series_id, rating_average, rating_count)
+		SELECT $1, COALESCE(ROUND(AVG(rating), 2), 0), COUNT(*)
+		FROM series_reviews
+		WHERE series_id = $1 AND status <> 'hidden'
+		ON CONFLICT (series_id) DO UPDATE SET
+			rating_average = EXCLUDED.rating_average,
+			rating_count = EXCLUDED.rating_count
+	`
+
+	if _, err := tx.ExecContext(ctx, query, seriesID); err != nil {
+		return fmt.Errorf("failed to refresh series rating: %v", err)
+	}
+
+	return nil
+}
+
+// scanReview reads a row of reviewColumns
+func scanReview(row interface{ Scan(...interface{}) error }) (*models.Review, error) {
+	review := &models.Review{}
+	var firstName, lastName string
+	err := row.Scan(
+		&review.ID, &review.SeriesID, &review.SeriesTitle, &review.UserID, &firstName, &lastName, &review.Rating,
+		&review.Body, &review.Status, &review.ReportCount, &review.Reply, &review.RepliedAt, &review.CreatedAt, &review.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	review.AuthorName = authorName(firstName, lastName)
+
+	return review, nil
+}
+
+// scanReviews reads every row of a reviewColumns query
+func scanReviews(rows *sql.Rows) ([]*models.Review, error) {
+	reviews := []*models.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %v", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// Comment operations
+
+// commentColumns are the columns scanComment reads, from episode_comments c joined with
+// episodes e and users u. $1 is the viewer, for liked_by_me.
+const commentColumns = `
+	c.id, c.episode_id, e.title, c.user_id, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), c.parent_id,
+	c.position, c.body, c.status, c.report_count, c.like_count, c.deleted_at IS NOT NULL,
+	EXISTS (SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id = $1),
+	(SELECT COUNT(*) FROM episode_comments rc WHERE rc.parent_id = c.id AND rc.status <> 'hidden'),
+	c.created_at, c.updated_at
+`
+
+// GetCommentByID returns the comment as seen by viewerID, or ErrCommentNotFound if there is none
+func (s *SupabaseService) GetCommentByID(ctx context.Context, commentID, viewerID uuid.UUID) (*models.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM episode_comments c
+		JOIN episodes e ON e.id = c.episode_id
+		JOIN users u ON u.id = c.user_id
+		WHERE c.id = $2
+	`
+
+	comment, err := scanComment(s.db.QueryRowContext(ctx, query, viewerID, commentID))
+	if err == sql.ErrNoRows {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %v", err)
+	}
+
+	return comment, nil
+}
+
+// GetEpisodeComments returns a page of the episode's top-level comments that are not hidden,
+// as seen by viewerID and ordered by orderBy
+func (s *SupabaseService) GetEpisodeComments(ctx context.Context, episodeID, viewerID uuid.UUID, orderBy string, limit, offset int) ([]*models.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM episode_comments c
+		JOIN episodes e ON e.id = c.episode_id
+		JOIN users u ON u.id = c.user_id
+		WHERE c.episode_id = $2 AND c.parent_id IS NULL AND c.status <> 'hidden'
+		ORDER BY ` + orderBy + `
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, viewerID, episodeID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %v", err)
+	}
+	defer rows.Close()
+
+	return scanComments(rows)
+}
+
+// GetCommentReplies returns a page of the replies to each of the comments that are not
+// hidden, as seen by viewerID, oldest first. limit and offset apply to each comment's
+// replies separately, so one query loads the first few replies of a whole page of comments.
+func (s *SupabaseService) GetCommentReplies(ctx context.Context, parentIDs []uuid.UUID, viewerID uuid.UUID, limit, offset int) ([]*models.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM (
+		    SELECT ec.*, ROW_NUMBER() OVER (PARTITION BY ec.parent_id ORDER BY ec.created_at, ec.id) AS reply_number
+		    FROM episode_comments ec
+		    WHERE ec.parent_id IN (SELECT jsonb_array_elements_text($2::jsonb)::uuid) AND ec.status <> 'hidden'
+		) c
+		JOIN episodes e ON e.id = c.episode_id
+		JOIN users u ON u.id = c.user_id
+		WHERE c.reply_number > $4 AND c.reply_number <= $3 + $4
+		ORDER BY c.parent_id, c.reply_number
+	`
+
+	if len(parentIDs) == 0 {
+		return []*models.Comment{}, nil
+	}
+
+	ids, err := json.Marshal(parentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal comment IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, viewerID, string(ids), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment replies: %v", err)
+	}
+	defer rows.Close()
+
+	return scanComments(rows)
+}
+
+// CreateComment saves a new comment or reply unless its author has already posted as many
+// as one of the limits allows, in which case ErrCommentRateLimited is returned. Posts are
+// counted in comment_posts, which keeps them after the comments are deleted, and posts older
+// than the longest window are pruned. A per-user advisory lock is held while counting and
+// inserting, so concurrent posts cannot both slip under a limit.
+func (s *SupabaseService) CreateComment(ctx context.Context, comment *models.Comment, limits []commentRateLimit) error {
+	lockQuery := `SELECT pg_advisory_xact_lock(hashtext('episode_comments'), hashtext($1::text))`
+	countQuery := `SELECT COUNT(*) FROM comment_posts WHERE user_id = $1 AND created_at >= $2`
+	insertQuery := `
+		INSERT INTO episode_comments (id, episode_id, user_id, parent_id, position, body, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING status, like_count, report_count, created_at, updated_at
+	`
+	postQuery := `INSERT INTO comment_posts (user_id, created_at) VALUES ($1, $2)`
+	pruneQuery := `DELETE FROM comment_posts WHERE user_id = $1 AND created_at < $2`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockQuery, comment.UserID); err != nil {
+		return fmt.Errorf("failed to lock user comments: %v", err)
+	}
+
+	now := time.Now()
+	for _, limit := range limits {
+		var count int
+		if err := tx.QueryRowContext(ctx, countQuery, comment.UserID, now.Add(-limit.Window)).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count recent comments: %v", err)
+		}
+		if count >= limit.Max {
+			return fmt.Errorf("%w: at most %d comments per %s", ErrCommentRateLimited, limit.Max, limit.Name)
+		}
+	}
+
+	comment.ID = uuid.New()
+	err = tx.QueryRowContext(ctx, insertQuery,
+		comment.ID, comment.EpisodeID, comment.UserID, comment.ParentID, comment.Position, comment.Body,
+	).Scan(&comment.Status, &comment.LikeCount, &comment.ReportCount, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, postQuery, comment.UserID, comment.CreatedAt); err != nil {
+		return fmt.Errorf("failed to record comment post: %v", err)
+	}
+	var longest time.Duration
+	for _, limit := range limits {
+		if limit.Window > longest {
+			longest = limit.Window
+		}
+	}
+	if _, err := tx.ExecContext(ctx, pruneQuery, comment.UserID, now.Add(-longest)); err != nil {
+		return fmt.Errorf("failed to prune comment posts: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit comment: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteComment removes the user's comment. A comment with replies is kept with tombstone as
+// its body so the replies survive, and a tombstone whose last reply goes is removed with it.
+// It returns ErrCommentNotFound if the user has no comment with the ID.
+func (s *SupabaseService) DeleteComment(ctx context.Context, userID, commentID uuid.UUID, tombstone string) error {
+	// Locking the comment keeps new replies out until the delete commits
+	lockQuery := `
+		SELECT c.parent_id, EXISTS (SELECT 1 FROM episode_comments r WHERE r.parent_id = c.id)
+		FROM episode_comments c
+		WHERE c.id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
+		FOR UPDATE
+	`
+	tombstoneQuery := `UPDATE episode_comments SET body = $2, deleted_at = NOW() WHERE id = $1`
+	deleteQuery := `DELETE FROM episode_comments WHERE id = $1`
+	parentQuery := `
+		DELETE FROM episode_comments c
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM episode_comments r WHERE r.parent_id = c.id)
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var parentID *uuid.UUID
+	var hasReplies bool
+	err = tx.QueryRowContext(ctx, lockQuery, commentID, userID).Scan(&parentID, &hasReplies)
+	if err == sql.ErrNoRows {
+		return ErrCommentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %v", err)
+	}
+
+	if hasReplies {
+		if _, err := tx.ExecContext(ctx, tombstoneQuery, commentID, tombstone); err != nil {
+			return fmt.Errorf("failed to delete comment: %v", err)
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx, deleteQuery, commentID); err != nil {
+			return fmt.Errorf("failed to delete comment: %v", err)
+		}
+		if parentID != nil {
+			if _, err := tx.ExecContext(ctx, parentQuery, *parentID); err != nil {
+				return fmt.Errorf("failed to delete parent comment: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit comment deletion: %v", err)
+	}
+
+	return nil
+}
+
+// LikeComment records the user's like of the comment. Liking twice is not an error.
+func (s *SupabaseService) LikeComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	likeQuery := `
+		INSERT INTO comment_likes (comment_id, user_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (comment_id, user_id) DO NOTHING
+	`
+	countQuery := `UPDATE episode_comments SET like_count = like_count + 1 WHERE id = $1`
+
+	return s.updateCommentLike(ctx, likeQuery, countQuery, userID, commentID)
+}
+
+// UnlikeComment removes the user's like of the comment, if any
+func (s *SupabaseService) UnlikeComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	likeQuery := `DELETE FROM comment_likes WHERE comment_id = $1 AND user_id = $2`
+	countQuery := `UPDATE episode_comments SET like_count = GREATEST(like_count - 1, 0) WHERE id = $1`
+
+	return s.updateCommentLike(ctx, likeQuery, countQuery, userID, commentID)
+}
+
+// updateCommentLike runs likeQuery and, only if it changed a like, countQuery, so the
+// comment's like count stays in step with its likes
+func (s *SupabaseService) updateCommentLike(ctx context.Context, likeQuery, countQuery string, userID, commentID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, likeQuery, commentID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update comment like: %v", err)
+	}
+	if changed, _ := result.RowsAffected(); changed == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, countQuery, commentID); err != nil {
+		return fmt.Errorf("failed to count comment likes: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit comment like: %v", err)
+	}
+
+	return nil
+}
+
+// ReportComment records the user's report of the comment. A user's first report counts
+// towards the comment's report count; once that reaches flagThreshold a visible comment is
+// flagged for moderators. Reporting twice is not an error and keeps the first report.
+func (s *SupabaseService) ReportComment(ctx context.Context, report *models.CommentReport, flagThreshold int) error {
+	reportQuery := `
+		INSERT INTO comment_reports (comment_id, user_id, reason, details, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+		ON CONFLICT (comment_id, user_id) DO NOTHING
+	`
+	countQuery := `
+		UPDATE episode_comments SET
+			report_count = report_count + 1,
+			status = CASE WHEN status = 'visible' AND report_count + 1 >= $2 THEN 'flagged' ELSE status END
+		WHERE id = $1
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, reportQuery, report.CommentID, report.UserID, report.Reason, report.Details)
+	if err != nil {
+		return fmt.Errorf("failed to report comment: %v", err)
+	}
+	if inserted, _ := result.RowsAffected(); inserted == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, countQuery, report.CommentID, flagThreshold); err != nil {
+		return fmt.Errorf("failed to count comment report: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit comment report: %v", err)
+	}
+
+	return nil
+}
+
+// GetCommentReports returns the reports of the comment, newest first
+func (s *SupabaseService) GetCommentReports(ctx context.Context, commentID uuid.UUID) ([]*models.CommentReport, error) {
+	query := `
+		SELECT comment_id, user_id, reason, COALESCE(details, ''), created_at
+		FROM comment_reports
+		WHERE comment_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment reports: %v", err)
+	}
+	defer rows.Close()
+
+	reports := []*models.CommentReport{}
+	for rows.Next() {
+		report := &models.CommentReport{}
+		err := rows.Scan(&report.CommentID, &report.UserID, &report.Reason, &report.Details, &report.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment report: %v", err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// GetCommentModerationQueue returns a page of comments and replies for moderators, most
+// reported first. An empty status returns the comments waiting for a decision: flagged ones
+// and visible ones that have been reported.
+func (s *SupabaseService) GetCommentModerationQueue(ctx context.Context, status string, limit, offset int) ([]*models.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM episode_comments c
+		JOIN episodes e ON e.id = c.episode_id
+		JOIN users u ON u.id = c.user_id
+		WHERE ($2 = '' AND (c.status = 'flagged' OR (c.status = 'visible' AND c.report_count > 0)))
+		   OR c.status = $2
+		ORDER BY c.report_count DESC, c.updated_at DESC, c.id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, uuid.Nil, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment moderation queue: %v", err)
+	}
+	defer rows.Close()
+
+	return scanComments(rows)
+}
+
+// SetCommentStatus shows, flags or hides the comment and records the change in the audit log
+func (s *SupabaseService) SetCommentStatus(ctx context.Context, comment *models.Comment, entry *models.AuditLogEntry) error {
+	query := `UPDATE episode_comments SET status = $2 WHERE id = $1 RETURNING updated_at`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, comment.ID, comment.Status).Scan(&comment.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrCommentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to moderate comment: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit comment moderation: %v", err)
+	}
+
+	return nil
+}
+
+// scanComment reads a row of commentColumns
+func scanComment(row interface{ Scan(...interface{}) error }) (*models.Comment, error) {
+	comment := &models.Comment{}
+	var firstName, lastName string
+	err := row.Scan(
+		&comment.ID, &comment.EpisodeID, &comment.EpisodeTitle, &comment.UserID, &firstName, &lastName, &comment.ParentID,
+		&comment.Position, &comment.Body, &comment.Status, &comment.ReportCount, &comment.LikeCount, &comment.Deleted,
+		&comment.LikedByMe, &comment.ReplyCount, &comment.CreatedAt, &comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	comment.AuthorName = authorName(firstName, lastName)
+
+	return comment, nil
+}
+
+// scanComments reads every row of a commentColumns query
+func scanComments(rows *sql.Rows) ([]*models.Comment, error) {
+	comments := []*models.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %v", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// Audio track operations
+
+// GetEpisodeAudioTracks returns the additional audio tracks of each of the episodes, by
+// language, keyed by episode ID
+func (s *SupabaseService) GetEpisodeAudioTracks(ctx context.Context, episodeIDs []uuid.UUID) (map[uuid.UUID][]*models.AudioTrack, error) {
+	query := `
+		SELECT id, episode_id, language, audio_url, duration, COALESCE(audio_codec, ''), COALESCE(audio_bitrate, 0),
+		       COALESCE(processing_status, 'unprocessed'), COALESCE(processed_audio_url, ''), created_at, updated_at
+		FROM episode_audio_tracks
+		WHERE episode_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY episode_id, language
+	`
+
+	byID := map[uuid.UUID][]*models.AudioTrack{}
+	if len(episodeIDs) == 0 {
+		return byID, nil
+	}
+
+	ids, err := json.Marshal(episodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal episode IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio tracks: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		track := &models.AudioTrack{}
+		err := rows.Scan(
+			&track.ID, &track.EpisodeID, &track.Language, &track.AudioURL, &track.Duration,
+			&track.AudioCodec, &track.AudioBitrate, &track.ProcessingStatus, &track.ProcessedAudioURL,
+			&track.CreatedAt, &track.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audio track: %v", err)
+		}
+		byID[track.EpisodeID] = append(byID[track.EpisodeID], track)
+	}
+
+	return byID, nil
+}
+
+// UpsertAudioTrack adds the track, replacing any the episode already has in its language, and
+// records the change in the audit log. A replaced track loses its processed rendition.
+func (s *SupabaseService) UpsertAudioTrack(ctx context.Context, track *models.AudioTrack, entry *models.AuditLogEntry) error {
+	query := `
+		INSERT INTO episode_audio_tracks (id, episode_id, language, audio_url, duration, audio_codec, audio_bitrate, processing_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'unprocessed', $8, $8)
+		ON CONFLICT (episode_id, language) DO UPDATE SET
+			audio_url = EXCLUDED.audio_url,
+			duration = EXCLUDED.duration,
+			audio_codec = EXCLUDED.audio_codec,
+			audio_bitrate = EXCLUDED.audio_bitrate,
+			processing_status = 'unprocessed',
+			processed_audio_url = NULL,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, processing_status, created_at, updated_at
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query,
+		uuid.New(), track.EpisodeID, track.Language, track.AudioURL, track.Duration,
+		track.AudioCodec, track.AudioBitrate, time.Now(),
+	).Scan(&track.ID, &track.ProcessingStatus, &track.CreatedAt, &track.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save audio track: %v", err)
+	}
+	track.ProcessedAudioURL = ""
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit audio track: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SupabaseService) GetAudioTrackByID(ctx context.Context, trackID uuid.UUID) (*models.AudioTrack, error) {
+	query := `
+		SELECT id, episode_id, language, audio_url, duration, COALESCE(audio_codec, ''), COALESCE(audio_bitrate, 0),
+		       COALESCE(processing_status, 'unprocessed'), COALESCE(processed_audio_url, ''), created_at, updated_at
+		FROM episode_audio_tracks WHERE id = $1
+	`
+
+	track := &models.AudioTrack{}
+	err := s.db.QueryRowContext(ctx, query, trackID).Scan(
+		&track.ID, &track.EpisodeID, &track.Language, &track.AudioURL, &track.Duration,
+		&track.AudioCodec, &track.AudioBitrate, &track.ProcessingStatus, &track.ProcessedAudioURL,
+		&track.CreatedAt, &track.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrAudioTrackNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio track: %v", err)
+	}
+
+	return track, nil
+}
+
+// SetAudioTrackStatus updates the track's processing status, keeping any processed rendition
+func (s *SupabaseService) SetAudioTrackStatus(ctx context.Context, trackID uuid.UUID, status string) error {
+	query := `UPDATE episode_audio_tracks SET processing_status = $2, updated_at = NOW() WHERE id = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, trackID, status); err != nil {
+		return fmt.Errorf("failed to update audio track status: %v", err)
+	}
+
+	return nil
+}
+
+// SaveAudioTrackRendition stores the normalized rendition of the track's audio and marks it
+// ready. It reports false when the track was replaced with new audio in the meantime, so a
+// rendition of the old audio is never served for the new one.
+func (s *SupabaseService) SaveAudioTrackRendition(ctx context.Context, trackID uuid.UUID, sourceURL, processedURL string) (bool, error) {
+	query := `
+		UPDATE episode_audio_tracks SET processing_status = 'ready', processed_audio_url = $3, updated_at = NOW()
+		WHERE id = $1 AND audio_url = $2
+	`
+
+	result, err := s.db.ExecContext(ctx, query, trackID, sourceURL, processedURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to save audio track rendition: %v", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to save audio track rendition: %v", err)
+	}
+
+	return updated > 0, nil
+}
+
+// DeleteAudioTrack removes the episode's track in the language and records the change in the
+// audit log. It returns ErrAudioTrackNotFound if there is none.
+func (s *SupabaseService) DeleteAudioTrack(ctx context.Context, episodeID uuid.UUID, language string, entry *models.AuditLogEntry) error {
+	query := `DELETE FROM episode_audio_tracks WHERE episode_id = $1 AND language = $2`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, episodeID, language)
+	if err != nil {
+		return fmt.Errorf("failed to delete audio track: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrAudioTrackNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit audio track deletion: %v", err)
+	}
+
+	return nil
+}
+
+// Translation operations
+
+// GetSeriesTranslations returns the translations of each of the series, keyed by series ID
+func (s *SupabaseService) GetSeriesTranslations(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID][]*models.Translation, error) {
+	query := `
+		SELECT series_id, language, title, COALESCE(description, ''), created_at, updated_at
+		FROM series_translations
+		WHERE series_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY series_id, language
+	`
+
+	return s.getTranslations(ctx, query, seriesIDs)
+}
+
+// GetEpisodeTranslations returns the translations of each of the episodes, keyed by episode ID
+func (s *SupabaseService) GetEpisodeTranslations(ctx context.Context, episodeIDs []uuid.UUID) (map[uuid.UUID][]*models.Translation, error) {
+	query := `
+		SELECT episode_id, language, title, COALESCE(description, ''), created_at, updated_at
+		FROM episode_translations
+		WHERE episode_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		ORDER BY episode_id, language
+	`
+
+	return s.getTranslations(ctx, query, episodeIDs)
+}
+
+// getTranslations runs a translations query whose first column is the ID the translations belong to
+func (s *SupabaseService) getTranslations(ctx context.Context, query string, ownerIDs []uuid.UUID) (map[uuid.UUID][]*models.Translation, error) {
+	byID := map[uuid.UUID][]*models.Translation{}
+	if len(ownerIDs) == 0 {
+		return byID, nil
+	}
+
+	ids, err := json.Marshal(ownerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		translation := &models.Translation{}
+		var ownerID uuid.UUID
+		err := rows.Scan(
+			&ownerID, &translation.Language, &translation.Title, &translation.Description,
+			&translation.CreatedAt, &translation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %v", err)
+		}
+		byID[ownerID] = append(byID[ownerID], translation)
+	}
+
+	return byID, nil
+}
+
+// UpsertSeriesTranslation creates or replaces the series' translation for its language and
+// records the change in the audit log
+func (s *SupabaseService) UpsertSeriesTranslation(ctx context.Context, seriesID uuid.UUID, translation *models.Translation, entry *models.AuditLogEntry) error {
+	query := `
+		INSERT INTO series_translations (series_id, language, title, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (series_id, language) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at, updated_at
+	`
+
+	return s.upsertTranslation(ctx, query, seriesID, translation, entry)
+}
+
+// UpsertEpisodeTranslation creates or replaces the episode's translation for its language and
+// records the change in the audit log
+func (s *SupabaseService) UpsertEpisodeTranslation(ctx context.Context, episodeID uuid.UUID, translation *models.Translation, entry *models.AuditLogEntry) error {
+	query := `
+		INSERT INTO episode_translations (episode_id, language, title, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (episode_id, language) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at, updated_at
+	`
+
+	return s.upsertTranslation(ctx, query, episodeID, translation, entry)
+}
+
+func (s *SupabaseService) upsertTranslation(ctx context.Context, query string, ownerID uuid.UUID, translation *models.Translation, entry *models.AuditLogEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query,
+		ownerID, translation.Language, translation.Title, translation.Description, time.Now(),
+	).Scan(&translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save translation: %v", err)
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit translation: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteSeriesTranslation removes the series' translation for the language and records the
+// change in the audit log. It returns ErrTranslationNotFound if there is none.
+func (s *SupabaseService) DeleteSeriesTranslation(ctx context.Context, seriesID uuid.UUID, language string, entry *models.AuditLogEntry) error {
+	query := `DELETE FROM series_translations WHERE series_id = $1 AND language = $2`
+
+	return s.deleteTranslation(ctx, query, seriesID, language, entry)
+}
+
+// DeleteEpisodeTranslation removes the episode's translation for the language and records the
+// change in the audit log. It returns ErrTranslationNotFound if there is none.
+func (s *SupabaseService) DeleteEpisodeTranslation(ctx context.Context, episodeID uuid.UUID, language string, entry *models.AuditLogEntry) error {
+	query := `DELETE FROM episode_translations WHERE episode_id = $1 AND language = $2`
+
+	return s.deleteTranslation(ctx, query, episodeID, language, entry)
+}
+
+func (s *SupabaseService) deleteTranslation(ctx context.Context, query string, ownerID uuid.UUID, language string, entry *models.AuditLogEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, ownerID, language)
+	if err != nil {
+		return fmt.Errorf("failed to delete translation: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return ErrTranslationNotFound
+	}
+
+	if err := s.insertAuditLogEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit translation deletion: %v", err)
+	}
+
+	return nil
+}
+
+// Drip schedule operations
+func (s *SupabaseService) UpsertDripSchedule(ctx context.Context, schedule *models.DripSchedule) error {
+	query := `
+		INSERT INTO drip_schedules (series_id, weekdays, release_time, timezone, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (series_id)
+		DO UPDATE SET weekdays = EXCLUDED.weekdays, release_time = EXCLUDED.release_time,
+		              timezone = EXCLUDED.timezone, created_by = EXCLUDED.created_by, updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	weekdays, err := json.Marshal(schedule.Weekdays)
+	if err != nil {
+		return fmt.Errorf("failed to marshal weekdays: %v", err)
+	}
+
+	schedule.CreatedAt = time.Now()
+	schedule.UpdatedAt = time.Now()
+
+	err = s.db.QueryRowContext(ctx, query,
+		schedule.SeriesID, string(weekdays), schedule.ReleaseTime, schedule.Timezone,
+		schedule.CreatedBy, schedule.CreatedAt, schedule.UpdatedAt,
+	).Scan(&schedule.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save drip schedule: %v", err)
+	}
+
+	return nil
+}
+
+// GetDripSchedule returns the series' drip schedule, or nil if it has none
+func (s *SupabaseService) GetDripSchedule(ctx context.Context, seriesID uuid.UUID) (*models.DripSchedule, error) {
+	query := `
+		SELECT series_id, weekdays, to_char(release_time, 'HH24:MI'), timezone, created_by, created_at, updated_at
+		FROM drip_schedules WHERE series_id = $1
+	`
+
+	schedule := &models.DripSchedule{}
+	var weekdays []byte
+	err := s.db.QueryRowContext(ctx, query, seriesID).Scan(
+		&schedule.SeriesID, &weekdays, &schedule.ReleaseTime, &schedule.Timezone,
+		&schedule.CreatedBy, &schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drip schedule: %v", err)
+	}
+
+	if err := json.Unmarshal(weekdays, &schedule.Weekdays); err != nil {
+		return nil, fmt.Errorf("failed to decode weekdays: %v", err)
+	}
+
+	return schedule, nil
+}
+
+// GetDripSchedules returns the drip schedules of every series that has not been deleted
+func (s *SupabaseService) GetDripSchedules(ctx context.Context) ([]*models.DripSchedule, error) {
+	query := `
+		SELECT d.series_id, d.weekdays, to_char(d.release_time, 'HH24:MI'), d.timezone, d.created_by, d.created_at, d.updated_at
+		FROM drip_schedules d
+		JOIN series s ON s.id = d.series_id
+		WHERE s.deleted_at IS NULL
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drip schedules: %v", err)
+	}
+	defer rows.Close()
+
+	var schedules []*models.DripSchedule
+	for rows.Next() {
+		schedule := &models.DripSchedule{}
+		var weekdays []byte
+		err := rows.Scan(
+			&schedule.SeriesID, &weekdays, &schedule.ReleaseTime, &schedule.Timezone,
+			&schedule.CreatedBy, &schedule.CreatedAt, &schedule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan drip schedule: %v", err)
+		}
+		if err := json.Unmarshal(weekdays, &schedule.Weekdays); err != nil {
+			return nil, fmt.Errorf("failed to decode weekdays: %v", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// DeleteDripSchedule removes the series' drip schedule and returns the releases it had
+// planned but not yet made to draft. It returns false if the series had no schedule.
+func (s *SupabaseService) DeleteDripSchedule(ctx context.Context, seriesID uuid.UUID) (bool, error) {
+	deleteQuery := `DELETE FROM drip_schedules WHERE series_id = $1`
+	revertQuery := `
+		UPDATE episodes SET status = 'draft', publish_at = NULL, drip_scheduled = false, updated_at = NOW()
+		WHERE series_id = $1 AND drip_scheduled AND status = 'scheduled' AND publish_at > NOW() AND deleted_at IS NULL
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, deleteQuery, seriesID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete drip schedule: %v", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, revertQuery, seriesID); err != nil {
+		return false, fmt.Errorf("failed to unschedule drip releases: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit drip schedule deletion: %v", err)
+	}
+
+	return true, nil
+}
+
+// GetDripQueue returns the series' episodes waiting for a drip release, in episode order:
+// drafts queued for it, and future releases the drip schedule planned earlier
+func (s *SupabaseService) GetDripQueue(ctx context.Context, seriesID uuid.UUID, now time.Time) ([]*models.Episode, error) {
+	query := `
+		SELECT id, series_id, title, episode_number, status, publish_at
+		FROM episodes
+		WHERE series_id = $1 AND deleted_at IS NULL
+		  AND ((status = 'draft' AND drip_queued) OR (status = 'scheduled' AND drip_scheduled AND publish_at > $2))
+		ORDER BY episode_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drip queue: %v", err)
+	}
+	defer rows.Close()
+
+	var episodes []*models.Episode
+	for rows.Next() {
+		episode := &models.Episode{}
+		err := rows.Scan(
+			&episode.ID, &episode.SeriesID, &episode.Title, &episode.EpisodeNumber, &episode.Status, &episode.PublishAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan episode: %v", err)
+		}
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, nil
+}
+
+// SetEpisodeDripQueued adds a draft episode to its series' drip queue, or takes an episode
+// out of it; a release the schedule planned but has not made goes back to draft. It returns
+// false when the episode does not exist or, when queueing, is neither a draft nor already
+// scheduled by the drip schedule.
+func (s *SupabaseService) SetEpisodeDripQueued(ctx context.Context, episodeID uuid.UUID, queued bool) (bool, error) {
+	query := `
+		UPDATE episodes SET
+			status = CASE WHEN NOT $2 AND drip_scheduled AND status = 'scheduled' AND publish_at > NOW() THEN 'draft' ELSE status END,
+			publish_at = CASE WHEN NOT $2 AND drip_scheduled AND status = 'scheduled' AND publish_at > NOW() THEN NULL ELSE publish_at END,
+			drip_queued = $2,
+			drip_scheduled = drip_scheduled AND $2,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		  AND (NOT $2 OR status = 'draft' OR (status = 'scheduled' AND drip_scheduled))
+	`
+
+	result, err := s.db.ExecContext(ctx, query, episodeID, queued)
+	if err != nil {
+		return false, fmt.Errorf("failed to update drip queue: %v", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update drip queue: %v", err)
+	}
+
+	return updated > 0, nil
+}
+
+// ScheduleDripReleases schedules each episode for its release time in one statement
+func (s *SupabaseService) ScheduleDripReleases(ctx context.Context, releases []*models.UpcomingEpisode) error {
+	query := `
+		UPDATE episodes e SET status = 'scheduled', publish_at = r.release_at, drip_scheduled = true, updated_at = NOW()
+		FROM jsonb_to_recordset($1::jsonb) AS r(id UUID, release_at TIMESTAMP WITH TIME ZONE)
+		WHERE e.id = r.id AND e.deleted_at IS NULL
+	`
+
+	rows, err := json.Marshal(releases)
+	if err != nil {
+		return fmt.Errorf("failed to marshal drip releases: %v", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, query, string(rows))
+	if err != nil {
+		return fmt.Errorf("failed to schedule drip releases: %v", err)
+	}
+
+	return nil
+}
+
+// GetUpcomingEpisodes returns the series' scheduled episodes that have not been released yet
+func (s *SupabaseService) GetUpcomingEpisodes(ctx context.Context, seriesID uuid.UUID, now time.Time) ([]*models.UpcomingEpisode, error) {
+	query := `
+		SELECT id, title, episode_number, publish_at
+		FROM episodes
+		WHERE series_id = $1 AND status = 'scheduled' AND publish_at > $2 AND deleted_at IS NULL
+		ORDER BY publish_at, episode_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming episodes: %v", err)
+	}
+	defer rows.Close()
+
+	var upcoming []*models.UpcomingEpisode
+	for rows.Next() {
+		episode := &models.UpcomingEpisode{}
+		if err := rows.Scan(&episode.ID, &episode.Title, &episode.EpisodeNumber, &episode.ReleaseAt); err != nil {
+			return nil, fmt.Errorf("failed to scan upcoming episode: %v", err)
+		}
+		upcoming = append(upcoming, episode)
+	}
+
+	return upcoming, nil
+}
+
+// Chapter operations
+func (s *SupabaseService) GetEpisodeChapters(ctx context.Context, episodeID uuid.UUID) ([]*models.Chapter, error) {
+	query := `
+		SELECT id, episode_id, start_time, title, created_at
+		FROM episode_chapters WHERE episode_id = $1 ORDER BY start_time
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chapters: %v", err)
+	}
+	defer rows.Close()
+
+	chapters := []*models.Chapter{}
+	for rows.Next() {
+		chapter := &models.Chapter{}
+		err := rows.Scan(&chapter.ID, &chapter.EpisodeID, &chapter.StartTime, &chapter.Title, &chapter.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chapter: %v", err)
+		}
+		chapters = append(chapters, chapter)
+	}
+
+	return chapters, nil
+}
+
+// ReplaceEpisodeChapters swaps the episode's chapters for the given list in a single statement
+func (s *SupabaseService) ReplaceEpisodeChapters(ctx context.Context, episodeID uuid.UUID, chapters []*models.Chapter) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM episode_chapters WHERE episode_id = $1
+		)
+		INSERT INTO episode_chapters (id, episode_id, start_time, title, created_at)
+		SELECT c.id, $1, c.start_time, c.title, c.created_at
+		FROM jsonb_to_recordset($2::jsonb) AS c(id UUID, start_time INTEGER, title TEXT, created_at TIMESTAMP WITH TIME ZONE)
+	`
+
+	for _, chapter := range chapters {
+		chapter.ID = uuid.New()
+		chapter.EpisodeID = episodeID
+		chapter.CreatedAt = time.Now()
+	}
+
+	rows, err := json.Marshal(chapters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chapters: %v", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, query, episodeID, string(rows))
+	if err != nil {
+		return fmt.Errorf("failed to save chapters: %v", err)
+	}
+
+	return nil
+}
+
+// Waveform operations
+func (s *SupabaseService) UpsertEpisodeWaveform(ctx context.Context, waveform *models.EpisodeWaveform) error {
+	query := `
+		INSERT INTO episode_waveforms (episode_id, peaks, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (episode_id)
+		DO UPDATE SET peaks = EXCLUDED.peaks, updated_at = EXCLUDED.updated_at
+	`
+
+	peaks, err := json.Marshal(waveform.Peaks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal waveform: %v", err)
+	}
+
+	if waveform.CreatedAt.IsZero() {
+		waveform.CreatedAt = time.Now()
+	}
+	waveform.UpdatedAt = time.Now()
+
+	_, err = s.db.ExecContext(ctx, query, waveform.EpisodeID, string(peaks), waveform.CreatedAt, waveform.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save waveform: %v", err)
+	}
+
+	return nil
+}
+
+// GetEpisodeWaveform returns the episode's waveform, or nil if it has not been generated
+func (s *SupabaseService) GetEpisodeWaveform(ctx context.Context, episodeID uuid.UUID) (*models.EpisodeWaveform, error) {
+	query := `
+		SELECT episode_id, peaks, created_at, updated_at
+		FROM episode_waveforms WHERE episode_id = $1
+	`
+
+	waveform := &models.EpisodeWaveform{}
+	var peaks []byte
+	err := s.db.QueryRowContext(ctx, query, episodeID).Scan(
+		&waveform.EpisodeID, &peaks, &waveform.CreatedAt, &waveform.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waveform: %v", err)
+	}
+
+	if err := json.Unmarshal(peaks, &waveform.Peaks); err != nil {
+		return nil, fmt.Errorf("failed to decode waveform: %v", err)
+	}
+
+	return waveform, nil
+}
+
+// Transcode job operations
+func (s *SupabaseService) CreateTranscodeJob(ctx context.Context, job *models.TranscodeJob) error {
+	query := `
+		INSERT INTO transcode_jobs (id, episode_id, kind, track_id, status, attempts, max_attempts, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	job.ID = uuid.New()
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+	if job.RunAt.IsZero() {
+		job.RunAt = job.CreatedAt
+	}
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.ID, job.EpisodeID, job.Kind, job.TrackID, job.Status, job.Attempts, job.MaxAttempts, job.RunAt, job.CreatedAt, job.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create transcode job: %v", err)
+	}
+
+	return nil
+}
+
+// ClaimTranscodeJob marks the next due job as running and returns it, or nil when none is due.
+// Running jobs whose worker stopped before staleBefore are picked up again while they have
+// attempts left. SKIP LOCKED lets several workers poll the queue without handing out the
+// same job twice.
+func (s *SupabaseService) ClaimTranscodeJob(ctx context.Context, staleBefore time.Time) (*models.TranscodeJob, error) {
+	query := `
+		UPDATE transcode_jobs SET status = 'running', attempts = attempts + 1, started_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM transcode_jobs
+			WHERE (status = 'queued' AND run_at <= NOW())
+			   OR (status = 'running' AND started_at < $1 AND attempts < max_attempts)
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+	`
+
+	job := &models.TranscodeJob{}
+	err := s.db.QueryRowContext(ctx, query, staleBefore).Scan(
+		&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+		&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim transcode job: %v", err)
+	}
+
+	return job, nil
+}
+
+// FailStaleTranscodeJobs marks running jobs that were started before staleBefore and have
+// no attempts left as failed with the message, and returns them
+func (s *SupabaseService) FailStaleTranscodeJobs(ctx context.Context, staleBefore time.Time, message string) ([]*models.TranscodeJob, error) {
+	query := `
+		UPDATE transcode_jobs SET status = 'failed', last_error = $2, completed_at = NOW(), updated_at = NOW()
+		WHERE status = 'running' AND started_at < $1 AND attempts >= max_attempts
+		RETURNING id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, staleBefore, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fail stale transcode jobs: %v", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.TranscodeJob
+	for rows.Next() {
+		job := &models.TranscodeJob{}
+		err := rows.Scan(
+			&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+			&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transcode job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// UpdateTranscodeJob saves the job's status, retry schedule and error
+func (s *SupabaseService) UpdateTranscodeJob(ctx context.Context, job *models.TranscodeJob) error {
+	query := `
+		UPDATE transcode_jobs SET status = $2, attempts = $3, last_error = $4, run_at = $5, completed_at = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	job.UpdatedAt = time.Now()
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.ID, job.Status, job.Attempts, job.LastError, job.RunAt, job.CompletedAt, job.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update transcode job: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SupabaseService) GetTranscodeJobByID(ctx context.Context, jobID uuid.UUID) (*models.TranscodeJob, error) {
+	query := `
+		SELECT id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+		FROM transcode_jobs WHERE id = $1
+	`
+
+	job := &models.TranscodeJob{}
+	err := s.db.QueryRowContext(ctx, query, jobID).Scan(
+		&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+		&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcode job: %v", err)
+	}
+
+	return job, nil
+}
+
+// GetActiveTranscodeJob returns the episode's queued or running job of the kind, or nil if it has none
+func (s *SupabaseService) GetActiveTranscodeJob(ctx context.Context, episodeID uuid.UUID, kind string) (*models.TranscodeJob, error) {
+	query := `
+		SELECT id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+		FROM transcode_jobs WHERE episode_id = $1 AND kind = $2 AND status IN ('queued', 'running')
+		LIMIT 1
+	`
+
+	job := &models.TranscodeJob{}
+	err := s.db.QueryRowContext(ctx, query, episodeID, kind).Scan(
+		&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+		&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcode job: %v", err)
+	}
+
+	return job, nil
+}
+
+// GetQueuedTrackJob returns the audio track's job that is waiting to run, or nil if it has none
+func (s *SupabaseService) GetQueuedTrackJob(ctx context.Context, trackID uuid.UUID) (*models.TranscodeJob, error) {
+	query := `
+		SELECT id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+		FROM transcode_jobs WHERE track_id = $1 AND kind = 'track' AND status = 'queued'
+		LIMIT 1
+	`
+
+	job := &models.TranscodeJob{}
+	err := s.db.QueryRowContext(ctx, query, trackID).Scan(
+		&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+		&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcode job: %v", err)
+	}
+
+	return job, nil
+}
+
+// GetTranscodeJobs lists jobs newest first, optionally filtered by status
+func (s *SupabaseService) GetTranscodeJobs(ctx context.Context, status string, limit, offset int) ([]*models.TranscodeJob, error) {
+	query := `
+		SELECT id, episode_id, kind, track_id, status, attempts, max_attempts, last_error, run_at, started_at, completed_at, created_at, updated_at
+		FROM transcode_jobs WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcode jobs: %v", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.TranscodeJob
+	for rows.Next() {
+		job := &models.TranscodeJob{}
+		err := rows.Scan(
+			&job.ID, &job.EpisodeID, &job.Kind, &job.TrackID, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+			&job.RunAt, &job.StartedAt, &job.CompletedAt, &job.CreatedAt, &job.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transcode job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Episode packaging operations
+
+// SetEpisodePackageStatus records the status and error of the latest packaging run. The live
+// version, playlist and key are left alone, so players keep using them while a new run works.
+func (s *SupabaseService) SetEpisodePackageStatus(ctx context.Context, episodeID uuid.UUID, status string, runError *string) error {
+	query := `
+		INSERT INTO episode_packages (episode_id, status, error, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (episode_id)
+		DO UPDATE SET status = EXCLUDED.status, error = EXCLUDED.error, updated_at = NOW()
+	`
+
+	_, err := s.db.ExecContext(ctx, query, episodeID, status, runError)
+	if err != nil {
+		return fmt.Errorf("failed to save episode package status: %v", err)
+	}
+
+	return nil
+}
+
+// PublishEpisodePackage makes a fully uploaded version live, switching the playlist and key
+// and marking the package ready in one statement. It reports false when a newer version is
+// already live.
+func (s *SupabaseService) PublishEpisodePackage(ctx context.Context, pkg *models.EpisodePackage) (bool, error) {
+	query := `
+		INSERT INTO episode_packages (episode_id, status, version, playlist_path, bitrates, encryption_key, encryption_iv, error, created_at, updated_at)
+		VALUES ($1, 'ready', $2, $3, $4, $5, $6, NULL, NOW(), NOW())
+		ON CONFLICT (episode_id)
+		DO UPDATE SET status = 'ready', version = EXCLUDED.version, playlist_path = EXCLUDED.playlist_path,
+		              bitrates = EXCLUDED.bitrates, encryption_key = EXCLUDED.encryption_key,
+		              encryption_iv = EXCLUDED.encryption_iv, error = NULL, updated_at = NOW()
+		WHERE episode_packages.version < EXCLUDED.version
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		pkg.EpisodeID, pkg.Version, pkg.PlaylistPath, pkg.Bitrates, pkg.EncryptionKey, pkg.EncryptionIV,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to publish episode package: %v", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to publish episode package: %v", err)
+	}
+
+	return rows > 0, nil
+}
+
+// GetEpisodePackage returns the episode's HLS package, or nil if it has never been packaged
+func (s *SupabaseService) GetEpisodePackage(ctx context.Context, episodeID uuid.UUID) (*models.EpisodePackage, error) {
+	query := `
+		SELECT episode_id, status, COALESCE(version, 0), COALESCE(playlist_path, ''), COALESCE(bitrates, ''), encryption_key, encryption_iv, error, created_at, updated_at
+		FROM episode_packages WHERE episode_id = $1
+	`
+
+	pkg := &models.EpisodePackage{}
+	err := s.db.QueryRowContext(ctx, query, episodeID).Scan(
+		&pkg.EpisodeID, &pkg.Status, &pkg.Version, &pkg.PlaylistPath, &pkg.Bitrates,
+		&pkg.EncryptionKey, &pkg.EncryptionIV, &pkg.Error, &pkg.CreatedAt, &pkg.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode package: %v", err)
+	}
+
+	return pkg, nil
+}
+
+// Listening progress operations
+
+// SaveListeningProgress stores the update unless the user already has a newer one for the
+// episode; the latest client timestamp wins. It reports whether the update was applied.
+func (s *SupabaseService) SaveListeningProgress(ctx context.Context, progress *models.ListeningProgress) (bool, error) {
+	query := `
+		INSERT INTO listening_progress (user_id, episode_id, position, duration, completed, client_updated_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id, episode_id)
+		DO UPDATE SET position = EXCLUDED.position, duration = EXCLUDED.duration, completed = EXCLUDED.completed,
+		              client_updated_at = EXCLUDED.client_updated_at, updated_at = NOW()
+		WHERE listening_progress.client_updated_at <= EXCLUDED.client_updated_at
+		RETURNING updated_at
+	`
+
+	err := s.db.QueryRowContext(ctx, query,
+		progress.UserID, progress.EpisodeID, progress.Position, progress.Duration,
+		progress.Completed, progress.ClientUpdatedAt,
+	).Scan(&progress.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to save listening progress: %v", err)
+	}
+
+	return true, nil
+}
+
+func (s *SupabaseService) GetListeningProgress(ctx context.Context, userID, episodeID uuid.UUID) (*models.ListeningProgress, error) {
+	query := `
+		SELECT user_id, episode_id, position, duration, completed, client_updated_at, updated_at
+		FROM listening_progress WHERE user_id = $1 AND episode_id = $2
+	`
+
+	progress := &models.ListeningProgress{}
+	err := s.db.QueryRowContext(ctx, query, userID, episodeID).Scan(
+		&progress.UserID, &progress.EpisodeID, &progress.Position, &progress.Duration,
+		&progress.Completed, &progress.ClientUpdatedAt, &progress.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get listening progress: %v", err)
+	}
+
+	return progress, nil
+}
+
+// GetContinueListening returns the user's started but unfinished released episodes, most recently played first
+func (s *SupabaseService) GetContinueListening(ctx context.Context, userID uuid.UUID, limit int) ([]*models.ContinueListeningItem, error) {
+	query := `
+		SELECT e.id, e.series_id, e.title, e.description, e.duration, COALESCE(e.processing_status, 'unprocessed'),
+		       e.episode_number, e.coin_price, e.is_locked, e.free_at, e.audio_language, e.created_at, e.updated_at,
+		       s.id, s.title, s.description, s.cover_image, COALESCE(s.cover_images, '{}'), s.author, s.category,
+		       s.is_premium, s.total_episodes, s.created_by, s.created_at, s.updated_at,
+		       p.user_id, p.episode_id, p.position, p.duration, p.completed, p.client_updated_at, p.updated_at
+		FROM listening_progress p
+		JOIN episodes e ON e.id = p.episode_id
+		JOIN series s ON s.id = e.series_id
+		WHERE p.user_id = $1 AND p.completed = false AND p.position > 0
+		  AND e.deleted_at IS NULL AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		  AND s.deleted_at IS NULL AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		ORDER BY p.updated_at DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get continue listening: %v", err)
+	}
+	defer rows.Close()
+
+	items := []*models.ContinueListeningItem{}
+	for rows.Next() {
+		episode := &models.Episode{}
+		series := &models.Series{}
+		progress := &models.ListeningProgress{}
+		var coverImages []byte
+		err := rows.Scan(
+			&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description, &episode.Duration, &episode.ProcessingStatus,
+			&episode.EpisodeNumber, &episode.CoinPrice, &episode.IsLocked, &episode.FreeAt, &episode.AudioLanguage,
+			&episode.CreatedAt, &episode.UpdatedAt,
+			&series.ID, &series.Title, &series.Description, &series.CoverImage, &coverImages, &series.Author, &series.Category,
+			&series.IsPremium, &series.TotalEpisodes, &series.CreatedBy, &series.CreatedAt, &series.UpdatedAt,
+			&progress.UserID, &progress.EpisodeID, &progress.Position, &progress.Duration,
+			&progress.Completed, &progress.ClientUpdatedAt, &progress.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan continue listening: %v", err)
+		}
+		if err := json.Unmarshal(coverImages, &series.CoverImages); err != nil {
+			return nil, fmt.Errorf("failed to decode cover images: %v", err)
+		}
+		items = append(items, &models.ContinueListeningItem{
+			Episode:  episode,
+			Series:   series,
+			Progress: progress,
+		})
+	}
+
+	return items, nil
+}
+
+// Analytics operations
+
+// InsertListeningEvents appends a batch of events in one statement. Events for unknown
+// episodes are dropped rather than failing the batch. It returns how many were stored.
+func (s *SupabaseService) InsertListeningEvents(ctx context.Context, events []*models.ListeningEvent) (int64, error) {
+	query := `
+		INSERT INTO listening_events (user_id, episode_id, session_id, type, position, client_timestamp)
+		SELECT ev.user_id, ev.episode_id, ev.session_id, ev.type, ev.position, ev.client_timestamp
+		FROM jsonb_to_recordset($1::jsonb) AS ev(user_id UUID, episode_id UUID, session_id UUID, type TEXT, position INTEGER, client_timestamp TIMESTAMP WITH TIME ZONE)
+		JOIN episodes e ON e.id = ev.episode_id
+	`
+
+	rows, err := json.Marshal(events)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal events: %v", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, query, string(rows))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert listening events: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetRollupWatermark returns the time the named rollup has processed events up to,
+// or the zero time if it has never completed
+func (s *SupabaseService) GetRollupWatermark(ctx context.Context, name string) (time.Time, error) {
+	query := `SELECT rolled_up_to FROM rollup_watermarks WHERE name = $1`
+
+	var rolledUpTo time.Time
+	err := s.db.QueryRowContext(ctx, query, name).Scan(&rolledUpTo)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get rollup watermark: %v", err)
+	}
+
+	return rolledUpTo, nil
+}
+
+func (s *SupabaseService) SetRollupWatermark(ctx context.Context, name string, rolledUpTo time.Time) error {
+	query := `
+		INSERT INTO rollup_watermarks (name, rolled_up_to)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET rolled_up_to = EXCLUDED.rolled_up_to
+	`
+
+	_, err := s.db.ExecContext(ctx, query, name, rolledUpTo)
+	if err != nil {
+		return fmt.Errorf("failed to set rollup watermark: %v", err)
+	}
+
+	return nil
+}
+
+// MergeListeningSessions folds the events received in (since, until] into the per-session
+// summaries and returns the episodes whose sessions changed. A session's summary only ever
+// grows (furthest position, whether it played or completed), so merging an event twice
+// leaves it unchanged and overlapping windows are safe.
+func (s *SupabaseService) MergeListeningSessions(ctx context.Context, since, until time.Time) ([]uuid.UUID, error) {
+	query := `
+		WITH batch AS (
+			SELECT episode_id, session_id,
+			       MAX(position) FILTER (WHERE type <> 'seek') AS furthest,
+			       BOOL_OR(type = 'play') AS played,
+			       BOOL_OR(type = 'complete') AS completed
+			FROM listening_events
+			WHERE received_at > $1 AND received_at <= $2
+			GROUP BY episode_id, session_id
+		), merged AS (
+			INSERT INTO listening_sessions (episode_id, session_id, furthest, played, completed, updated_at)
+			SELECT episode_id, session_id, furthest, played, completed, NOW() FROM batch
+			ON CONFLICT (episode_id, session_id)
+			DO UPDATE SET furthest = GREATEST(listening_sessions.furthest, EXCLUDED.furthest),
+			              played = listening_sessions.played OR EXCLUDED.played,
+			              completed = listening_sessions.completed OR EXCLUDED.completed,
+			              updated_at = NOW()
+			RETURNING episode_id
+		)
+		SELECT DISTINCT episode_id FROM merged
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge listening sessions: %v", err)
+	}
+	defer rows.Close()
+
+	var episodeIDs []uuid.UUID
+	for rows.Next() {
+		var episodeID uuid.UUID
+		if err := rows.Scan(&episodeID); err != nil {
+			return nil, fmt.Errorf("failed to scan episode ID: %v", err)
+		}
+		episodeIDs = append(episodeIDs, episodeID)
+	}
+
+	return episodeIDs, nil
+}
+
+// GetEpisodeReachHistogram groups the episode's playback sessions by how far into the
+// episode they got, in 5% buckets (20 is the end). A session's reach is the furthest
+// position it reported outside of seeks, or the whole episode once it completed.
+func (s *SupabaseService) GetEpisodeReachHistogram(ctx context.Context, episodeID uuid.UUID, duration int) ([]reachBucket, error) {
+	query := `
+		WITH reach AS (
+			SELECT completed,
+			       CASE WHEN completed THEN 1.0
+			            WHEN $2 > 0 THEN LEAST(COALESCE(furthest, 0)::numeric / $2, 1.0)
+			            ELSE 0 END AS reach
+			FROM listening_sessions
+			WHERE episode_id = $1 AND played
+		)
+		SELECT LEAST(FLOOR(reach * 20)::int, 20) AS bucket, COUNT(*), COUNT(*) FILTER (WHERE completed), SUM(reach)
+		FROM reach
+		GROUP BY bucket
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, episodeID, duration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reach histogram: %v", err)
+	}
+	defer rows.Close()
+
+	var buckets []reachBucket
+	for rows.Next() {
+		var bucket reachBucket
+		if err := rows.Scan(&bucket.Bucket, &bucket.Sessions, &bucket.Completions, &bucket.ReachSum); err != nil {
+			return nil, fmt.Errorf("failed to scan reach bucket: %v", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+func (s *SupabaseService) UpsertEpisodeMetrics(ctx context.Context, metrics *models.EpisodeMetrics) error {
+	query := `
+		INSERT INTO episode_metrics (episode_id, starts, completions, avg_listen_through, completion_rate, drop_off_curve, rolled_up_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (episode_id)
+		DO UPDATE SET starts = EXCLUDED.starts, completions = EXCLUDED.completions,
+		              avg_listen_through = EXCLUDED.avg_listen_through, completion_rate = EXCLUDED.completion_rate,
+		              drop_off_curve = EXCLUDED.drop_off_curve, rolled_up_at = EXCLUDED.rolled_up_at
+	`
+
+	curve, err := json.Marshal(metrics.DropOffCurve)
+	if err != nil {
+		return fmt.Errorf("failed to marshal drop-off curve: %v", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, query,
+		metrics.EpisodeID, metrics.Starts, metrics.Completions, metrics.AvgListenThrough,
+		metrics.CompletionRate, string(curve), metrics.RolledUpAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save episode metrics: %v", err)
+	}
+
+	return nil
+}
+
+// GetSeriesAnalytics returns every episode of the series with its metrics, in episode order
+func (s *SupabaseService) GetSeriesAnalytics(ctx context.Context, seriesID uuid.UUID) ([]*models.EpisodeAnalytics, error) {
+	query := `
+		SELECT e.id, e.series_id, e.title, e.duration, e.episode_number, e.created_at, e.updated_at,
+		       m.episode_id, m.starts, m.completions, m.avg_listen_through, m.completion_rate, m.drop_off_curve, m.rolled_up_at
+		FROM episodes e
+		LEFT JOIN episode_metrics m ON m.episode_id = e.id
+		WHERE e.series_id = $1 AND e.deleted_at IS NULL
+		ORDER BY e.episode_number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series analytics: %v", err)
+	}
+	defer rows.Close()
+
+	analytics := []*models.EpisodeAnalytics{}
 	for rows.Next() {
 		episode := &models.Episode{}
+		var (
+			metricsEpisodeID                 *uuid.UUID
+			starts, completions              sql.NullInt64
+			avgListenThrough, completionRate sql.NullFloat64
+			curve                            []byte
+			rolledUpAt                       sql.NullTime
+		)
 		err := rows.Scan(
-			&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description,
-			&episode.AudioURL, &episode.Duration, &episode.EpisodeNumber,
-			&episode.CoinPrice, &episode.IsLocked, &episode.CreatedAt, &episode.UpdatedAt,
+			&episode.ID, &episode.SeriesID, &episode.Title, &episode.Duration, &episode.EpisodeNumber,
+			&episode.CreatedAt, &episode.UpdatedAt,
+			&metricsEpisodeID, &starts, &completions, &avgListenThrough, &completionRate, &curve, &rolledUpAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan episode: %v", err)
+			return nil, fmt.Errorf("failed to scan episode analytics: %v", err)
 		}
-		episodes = append(episodes, episode)
-	}
-
-	return episodes, nil
-}
-
-func (s *SupabaseService) GetEpisodeByID(ctx context.Context, episodeID uuid.UUID) (*models.Episode, error) {
-	query := `
-		SELECT id, series_id, title, description, audio_url, duration, episode_number, coin_price, is_locked, created_at, updated_at
-		FROM episodes WHERE id = $1
-	`
-
-	episode := &models.Episode{}
-	err := s.db.QueryRowContext(ctx, query, episodeID).Scan(
-		&episode.ID, &episode.SeriesID, &episode.Title, &episode.Description,
-		&episode.AudioURL, &episode.Duration, &episode.EpisodeNumber,
-		&episode.CoinPrice, &episode.IsLocked, &episode.CreatedAt, &episode.UpdatedAt,
-	)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to get episode: %v", err)
+		item := &models.EpisodeAnalytics{Episode: episode}
+		if metricsEpisodeID != nil {
+			item.Metrics = &models.EpisodeMetrics{
+				EpisodeID:        *metricsEpisodeID,
+				Starts:           int(starts.Int64),
+				Completions:      int(completions.Int64),
+				AvgListenThrough: avgListenThrough.Float64,
+				CompletionRate:   completionRate.Float64,
+				RolledUpAt:       rolledUpAt.Time,
+			}
+			if err := json.Unmarshal(curve, &item.Metrics.DropOffCurve); err != nil {
+				return nil, fmt.Errorf("failed to decode drop-off curve: %v", err)
+			}
+		}
+		analytics = append(analytics, item)
 	}
 
-	return episode, nil
+	return analytics, nil
 }
 
 // Purchase operations
@@ -376,6 +4199,86 @@ func (s *SupabaseService) HasUserPurchasedEpisode(ctx context.Context, userID, e
 	return count > 0, nil
 }
 
+// GetEpisodeAccess reports, in one query, whether the user can currently play each of the
+// episodes, keyed by episode ID; episodes that do not exist are left out. It applies the
+// rules of EpisodeService.GetAccessSource: a purchase always grants access, while free
+// episodes and subscriptions covering premium series outside early access only do once the
+// episode and its series are released.
+func (s *SupabaseService) GetEpisodeAccess(ctx context.Context, userID uuid.UUID, episodeIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `
+		SELECT e.id,
+		       EXISTS (
+		           SELECT 1 FROM purchases p
+		           WHERE p.user_id = $1 AND p.episode_id = e.id AND p.status = 'completed'
+		       ) OR (
+		           e.deleted_at IS NULL AND s.deleted_at IS NULL
+		           AND (e.status = 'published' OR (e.status = 'scheduled' AND e.publish_at <= NOW()))
+		           AND (s.status = 'published' OR (s.status = 'scheduled' AND s.publish_at <= NOW()))
+		           AND (
+		               (e.free_at IS NOT NULL AND e.free_at <= NOW())
+		               OR (e.free_at IS NULL AND NOT e.is_locked)
+		               OR (e.free_at IS NULL AND s.is_premium AND EXISTS (
+		                   SELECT 1 FROM (
+		                       SELECT status, current_period_end, grace_until FROM subscriptions
+		                       WHERE user_id = $1 AND status IN ('pending', 'active', 'past_due', 'cancelled')
+		                       ORDER BY created_at DESC LIMIT 1
+		                   ) sub
+		                   WHERE (sub.status IN ('active', 'cancelled') AND sub.current_period_end > NOW())
+		                      OR (sub.status = 'past_due' AND sub.grace_until > NOW())
+		               ))
+		           )
+		       )
+		FROM episodes e
+		JOIN series s ON s.id = e.series_id
+		WHERE e.id IN (SELECT jsonb_array_elements_text($2::jsonb)::uuid)
+	`
+
+	access := map[uuid.UUID]bool{}
+	if len(episodeIDs) == 0 {
+		return access, nil
+	}
+
+	ids, err := json.Marshal(episodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal episode IDs: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, userID, string(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check episode access: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var episodeID uuid.UUID
+		var playable bool
+		if err := rows.Scan(&episodeID, &playable); err != nil {
+			return nil, fmt.Errorf("failed to scan episode access: %v", err)
+		}
+		access[episodeID] = playable
+	}
+
+	return access, nil
+}
+
+// Streaming operations
+func (s *SupabaseService) RecordStreamUsage(ctx context.Context, userID, episodeID uuid.UUID, bytes int64) error {
+	query := `
+		INSERT INTO stream_usage (user_id, episode_id, day, bytes_served, requests)
+		VALUES ($1, $2, CURRENT_DATE, $3, 1)
+		ON CONFLICT (user_id, episode_id, day)
+		DO UPDATE SET bytes_served = stream_usage.bytes_served + EXCLUDED.bytes_served,
+		              requests = stream_usage.requests + 1
+	`
+
+	_, err := s.db.ExecContext(ctx, query, userID, episodeID, bytes)
+	if err != nil {
+		return fmt.Errorf("failed to record stream usage: %v", err)
+	}
+
+	return nil
+}
+
 // Coin operations
 func (s *SupabaseService) UpdateUserCoins(ctx context.Context, userID uuid.UUID, amount int) error {
 	query := `
@@ -415,8 +4318,8 @@ func (s *SupabaseService) CreateCoinTransaction(ctx context.Context, transaction
 // Payment operations
 func (s *SupabaseService) CreatePayment(ctx context.Context, payment *models.Payment) error {
 	query := `
-		INSERT INTO payments (id, user_id, amount, currency, coins, gateway, gateway_ref, status, payment_data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO payments (id, user_id, amount, currency, coins, gateway, gateway_ref, status, payment_data, subscription_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	payment.ID = uuid.New()
@@ -426,7 +4329,7 @@ func (s *SupabaseService) CreatePayment(ctx context.Context, payment *models.Pay
 	_, err := s.db.ExecContext(ctx, query,
 		payment.ID, payment.UserID, payment.Amount, payment.Currency,
 		payment.Coins, payment.Gateway, payment.GatewayRef, payment.Status,
-		payment.PaymentData, payment.CreatedAt, payment.UpdatedAt,
+		payment.PaymentData, payment.SubscriptionID, payment.CreatedAt, payment.UpdatedAt,
 	)
 
 	if err != nil {
@@ -450,6 +4353,264 @@ func (s *SupabaseService) UpdatePayment(ctx context.Context, paymentID uuid.UUID
 	return nil
 }
 
+func (s *SupabaseService) GetPaymentByGatewayRef(ctx context.Context, gatewayRef string) (*models.Payment, error) {
+	query := `
+		SELECT id, user_id, amount, currency, coins, gateway, gateway_ref, status, COALESCE(payment_data::text, ''), subscription_id, created_at, updated_at
+		FROM payments WHERE gateway_ref = $1
+	`
+
+	payment := &models.Payment{}
+	err := s.db.QueryRowContext(ctx, query, gatewayRef).Scan(
+		&payment.ID, &payment.UserID, &payment.Amount, &payment.Currency,
+		&payment.Coins, &payment.Gateway, &payment.GatewayRef, &payment.Status,
+		&payment.PaymentData, &payment.SubscriptionID, &payment.CreatedAt, &payment.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %v", err)
+	}
+
+	return payment, nil
+}
+
+// Subscription operations
+func (s *SupabaseService) GetSubscriptionPlans(ctx context.Context) ([]*models.SubscriptionPlan, error) {
+	query := `
+		SELECT id, name, interval, price, currency, is_active, created_at
+		FROM subscription_plans WHERE is_active = true ORDER BY currency, price
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription plans: %v", err)
+	}
+	defer rows.Close()
+
+	var plans []*models.SubscriptionPlan
+	for rows.Next() {
+		plan := &models.SubscriptionPlan{}
+		err := rows.Scan(
+			&plan.ID, &plan.Name, &plan.Interval, &plan.Price,
+			&plan.Currency, &plan.IsActive, &plan.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription plan: %v", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+func (s *SupabaseService) GetSubscriptionPlanByID(ctx context.Context, planID uuid.UUID) (*models.SubscriptionPlan, error) {
+	query := `
+		SELECT id, name, interval, price, currency, is_active, created_at
+		FROM subscription_plans WHERE id = $1
+	`
+
+	plan := &models.SubscriptionPlan{}
+	err := s.db.QueryRowContext(ctx, query, planID).Scan(
+		&plan.ID, &plan.Name, &plan.Interval, &plan.Price,
+		&plan.Currency, &plan.IsActive, &plan.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription plan: %v", err)
+	}
+
+	return plan, nil
+}
+
+func (s *SupabaseService) CreateSubscription(ctx context.Context, subscription *models.Subscription) error {
+	query := `
+		INSERT INTO subscriptions (id, user_id, plan_id, status, gateway, current_period_start, current_period_end, grace_until, cancel_at_period_end, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	subscription.ID = uuid.New()
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = time.Now()
+
+	_, err := s.db.ExecContext(ctx, query,
+		subscription.ID, subscription.UserID, subscription.PlanID, subscription.Status,
+		subscription.Gateway, subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd,
+		subscription.GraceUntil, subscription.CancelAtPeriodEnd,
+		subscription.CreatedAt, subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SupabaseService) UpdateSubscription(ctx context.Context, subscription *models.Subscription) error {
+	query := `
+		UPDATE subscriptions
+		SET status = $2, current_period_start = $3, current_period_end = $4,
+		    grace_until = $5, cancel_at_period_end = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	subscription.UpdatedAt = time.Now()
+	_, err := s.db.ExecContext(ctx, query,
+		subscription.ID, subscription.Status, subscription.CurrentPeriodStart,
+		subscription.CurrentPeriodEnd, subscription.GraceUntil,
+		subscription.CancelAtPeriodEnd, subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SupabaseService) GetSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*models.Subscription, error) {
+	query := `
+		SELECT id, user_id, plan_id, status, gateway, current_period_start, current_period_end, grace_until, cancel_at_period_end, created_at, updated_at
+		FROM subscriptions WHERE id = $1
+	`
+
+	subscription := &models.Subscription{}
+	err := s.db.QueryRowContext(ctx, query, subscriptionID).Scan(
+		&subscription.ID, &subscription.UserID, &subscription.PlanID, &subscription.Status,
+		&subscription.Gateway, &subscription.CurrentPeriodStart, &subscription.CurrentPeriodEnd,
+		&subscription.GraceUntil, &subscription.CancelAtPeriodEnd,
+		&subscription.CreatedAt, &subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %v", err)
+	}
+
+	return subscription, nil
+}
+
+// GetCurrentSubscription returns the user's most recent non-expired subscription, or nil if there is none
+func (s *SupabaseService) GetCurrentSubscription(ctx context.Context, userID uuid.UUID) (*models.Subscription, error) {
+	query := `
+		SELECT id, user_id, plan_id, status, gateway, current_period_start, current_period_end, grace_until, cancel_at_period_end, created_at, updated_at
+		FROM subscriptions
+		WHERE user_id = $1 AND status IN ('pending', 'active', 'past_due', 'cancelled')
+		ORDER BY created_at DESC LIMIT 1
+	`
+
+	subscription := &models.Subscription{}
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(
+		&subscription.ID, &subscription.UserID, &subscription.PlanID, &subscription.Status,
+		&subscription.Gateway, &subscription.CurrentPeriodStart, &subscription.CurrentPeriodEnd,
+		&subscription.GraceUntil, &subscription.CancelAtPeriodEnd,
+		&subscription.CreatedAt, &subscription.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current subscription: %v", err)
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptionsDue returns subscriptions whose period or grace period has ended
+func (s *SupabaseService) GetSubscriptionsDue(ctx context.Context, now time.Time) ([]*models.Subscription, error) {
+	query := `
+		SELECT id, user_id, plan_id, status, gateway, current_period_start, current_period_end, grace_until, cancel_at_period_end, created_at, updated_at
+		FROM subscriptions
+		WHERE (status IN ('active', 'cancelled') AND current_period_end <= $1)
+		   OR (status = 'past_due' AND grace_until <= $1)
+		ORDER BY current_period_end
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due subscriptions: %v", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*models.Subscription
+	for rows.Next() {
+		subscription := &models.Subscription{}
+		err := rows.Scan(
+			&subscription.ID, &subscription.UserID, &subscription.PlanID, &subscription.Status,
+			&subscription.Gateway, &subscription.CurrentPeriodStart, &subscription.CurrentPeriodEnd,
+			&subscription.GraceUntil, &subscription.CancelAtPeriodEnd,
+			&subscription.CreatedAt, &subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %v", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// Audit log operations
+func (s *SupabaseService) insertAuditLogEntry(ctx context.Context, tx *sql.Tx, entry *models.AuditLogEntry) error {
+	query := `
+		INSERT INTO admin_audit_log (id, actor_id, action, entity_type, entity_id, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %v", err)
+	}
+
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now()
+
+	_, err = tx.ExecContext(ctx, query,
+		entry.ID, entry.ActorID, entry.Action, entry.EntityType, entry.EntityID, string(changes), entry.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to record audit log entry: %v", err)
+	}
+
+	return nil
+}
+
+// GetAuditLog returns audit log entries, newest first. An empty entityType or a nil
+// entityID matches every entry.
+func (s *SupabaseService) GetAuditLog(ctx context.Context, entityType string, entityID *uuid.UUID, limit, offset int) ([]*models.AuditLogEntry, error) {
+	query := `
+		SELECT id, actor_id, action, entity_type, entity_id, changes, created_at
+		FROM admin_audit_log
+		WHERE ($1 = '' OR entity_type = $1) AND ($2::uuid IS NULL OR entity_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, entityType, entityID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %v", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditLogEntry{}
+	for rows.Next() {
+		entry := &models.AuditLogEntry{}
+		var changes []byte
+		err := rows.Scan(
+			&entry.ID, &entry.ActorID, &entry.Action, &entry.EntityType, &entry.EntityID, &changes, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit log entry: %v", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode audit changes: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
 // Admin operations
 func (s *SupabaseService) GetAdminStats(ctx context.Context) (*models.AdminStats, error) {
 	// Get total users
@@ -459,35 +4620,22 @@ func (s *SupabaseService) GetAdminStats(ctx context.Context) (*models.AdminStats
 		return nil, fmt.Errorf("failed to get total users: %v", err)
 	}
 
-	// Get total series
+	// Get total series, leaving out deleted ones
 	var totalSeries int
-	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM series").Scan(&totalSeries)
+	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM series WHERE deleted_at IS NULL").Scan(&totalSeries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total series: %v", err)
 	}
 
-	// Get total episodes
+	// Get total episodes, leaving out deleted ones and those of deleted series
 	var totalEpisodes int
-	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM episodes").Scan(&totalEpisodes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total episodes: %v", err)
-	}
-
-	// Get total revenue (sum of all completed payments)
-	var totalRevenue int
-	err = s.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount), 0) FROM payments WHERE status = 'completed'").Scan(&totalRevenue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total revenue: %v", err)
-	}
-
-	// Get monthly revenue
-	var monthlyRevenue int
 	err = s.db.QueryRowContext(ctx, `
-		SELECT COALESCE(SUM(amount), 0) FROM payments 
-		WHERE status = 'completed' AND created_at >= NOW() - INTERVAL '1 month'
-	`).Scan(&monthlyRevenue)
+		SELECT COUNT(*) FROM episodes e
+		JOIN series s ON s.id = e.series_id
+		WHERE e.deleted_at IS NULL AND s.deleted_at IS NULL
+	`).Scan(&totalEpisodes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get monthly revenue: %v", err)
+		return nil, fmt.Errorf("failed to get total episodes: %v", err)
 	}
 
 	// Get active users (users with activity in last 30 days)
@@ -501,17 +4649,169 @@ func (s *SupabaseService) GetAdminStats(ctx context.Context) (*models.AdminStats
 	}
 
 	stats := &models.AdminStats{
-		TotalUsers:     totalUsers,
-		TotalSeries:    totalSeries,
-		TotalEpisodes:  totalEpisodes,
-		TotalRevenue:   totalRevenue,
-		MonthlyRevenue: monthlyRevenue,
-		ActiveUsers:    activeUsers,
+		TotalUsers:    totalUsers,
+		TotalSeries:   totalSeries,
+		TotalEpisodes: totalEpisodes,
+		ActiveUsers:   activeUsers,
 	}
 
 	return stats, nil
 }
 
+// GetRevenueBreakdown returns completed payment revenue in [from, to) per currency and gateway
+func (s *SupabaseService) GetRevenueBreakdown(ctx context.Context, from, to time.Time) ([]*models.RevenueBreakdown, error) {
+	query := `
+		SELECT currency, gateway, SUM(amount), COUNT(*), COUNT(DISTINCT user_id)
+		FROM payments
+		WHERE status = 'completed' AND created_at >= $1 AND created_at < $2
+		GROUP BY currency, gateway
+		ORDER BY currency, gateway
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue breakdown: %v", err)
+	}
+	defer rows.Close()
+
+	breakdown := []*models.RevenueBreakdown{}
+	for rows.Next() {
+		revenue := &models.RevenueBreakdown{}
+		err := rows.Scan(&revenue.Currency, &revenue.Gateway, &revenue.Amount, &revenue.Payments, &revenue.PayingUsers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revenue breakdown: %v", err)
+		}
+		breakdown = append(breakdown, revenue)
+	}
+
+	return breakdown, nil
+}
+
+// GetRevenueSeries returns completed payment revenue per currency for every interval
+// (day, week or month) between from and to. Periods without payments are included with
+// zero revenue for each currency that had payments in the range.
+func (s *SupabaseService) GetRevenueSeries(ctx context.Context, from, to time.Time, interval string) ([]*models.RevenuePoint, error) {
+	query := `
+		WITH periods AS (
+			SELECT generate_series(date_trunc($3, $1::timestamptz), $2::timestamptz - INTERVAL '1 microsecond', ('1 ' || $3)::interval) AS period_start
+		), currencies AS (
+			SELECT DISTINCT currency FROM payments
+			WHERE status = 'completed' AND created_at >= $1 AND created_at < $2
+		)
+		SELECT p.period_start, c.currency, COALESCE(SUM(pay.amount), 0), COUNT(pay.id)
+		FROM periods p
+		CROSS JOIN currencies c
+		LEFT JOIN payments pay ON pay.currency = c.currency AND pay.status = 'completed'
+		     AND pay.created_at >= GREATEST(p.period_start, $1) AND pay.created_at < LEAST(p.period_start + ('1 ' || $3)::interval, $2)
+		GROUP BY p.period_start, c.currency
+		ORDER BY p.period_start, c.currency
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue series: %v", err)
+	}
+	defer rows.Close()
+
+	points := []*models.RevenuePoint{}
+	for rows.Next() {
+		point := &models.RevenuePoint{}
+		if err := rows.Scan(&point.PeriodStart, &point.Currency, &point.Amount, &point.Payments); err != nil {
+			return nil, fmt.Errorf("failed to scan revenue point: %v", err)
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// GetRevenueByCurrency returns completed payment revenue and paying users in [from, to) per currency
+func (s *SupabaseService) GetRevenueByCurrency(ctx context.Context, from, to time.Time) ([]*models.CurrencyARPPU, error) {
+	query := `
+		SELECT currency, SUM(amount), COUNT(DISTINCT user_id)
+		FROM payments
+		WHERE status = 'completed' AND created_at >= $1 AND created_at < $2
+		GROUP BY currency
+		ORDER BY currency
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue by currency: %v", err)
+	}
+	defer rows.Close()
+
+	revenue := []*models.CurrencyARPPU{}
+	for rows.Next() {
+		item := &models.CurrencyARPPU{}
+		if err := rows.Scan(&item.Currency, &item.Amount, &item.PayingUsers); err != nil {
+			return nil, fmt.Errorf("failed to scan currency revenue: %v", err)
+		}
+		revenue = append(revenue, item)
+	}
+
+	return revenue, nil
+}
+
+// GetCoinFlow returns the coins bought through completed payments and the coins spent
+// unlocking content in [from, to)
+func (s *SupabaseService) GetCoinFlow(ctx context.Context, from, to time.Time) (int64, int64, error) {
+	query := `
+		SELECT
+			(SELECT COALESCE(SUM(coins), 0) FROM payments
+			 WHERE status = 'completed' AND created_at >= $1 AND created_at < $2),
+			(SELECT COALESCE(-SUM(amount), 0) FROM coin_transactions
+			 WHERE type = 'purchase' AND amount < 0 AND created_at >= $1 AND created_at < $2)
+	`
+
+	var purchased, spent int64
+	err := s.db.QueryRowContext(ctx, query, from, to).Scan(&purchased, &spent)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get coin flow: %v", err)
+	}
+
+	return purchased, spent, nil
+}
+
+// GetSignupCohorts groups users who signed up in [from, to) by interval and counts how many
+// of each group have made a completed payment since, and how long that first payment took
+func (s *SupabaseService) GetSignupCohorts(ctx context.Context, from, to time.Time, interval string) ([]*models.SignupCohort, error) {
+	query := `
+		WITH signups AS (
+			SELECT u.id, u.created_at, date_trunc($3, u.created_at) AS period_start,
+			       (SELECT MIN(p.created_at) FROM payments p WHERE p.user_id = u.id AND p.status = 'completed') AS first_payment_at
+			FROM users u
+			WHERE u.created_at >= $1 AND u.created_at < $2
+		)
+		SELECT period_start, COUNT(*), COUNT(first_payment_at),
+		       AVG(EXTRACT(EPOCH FROM first_payment_at - created_at) / 86400)
+		FROM signups
+		GROUP BY period_start
+		ORDER BY period_start
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, from, to, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signup cohorts: %v", err)
+	}
+	defer rows.Close()
+
+	cohorts := []*models.SignupCohort{}
+	for rows.Next() {
+		cohort := &models.SignupCohort{}
+		var avgDays sql.NullFloat64
+		if err := rows.Scan(&cohort.PeriodStart, &cohort.Signups, &cohort.Converted, &avgDays); err != nil {
+			return nil, fmt.Errorf("failed to scan signup cohort: %v", err)
+		}
+		if avgDays.Valid {
+			cohort.AvgDaysToFirstPurchase = &avgDays.Float64
+		}
+		cohorts = append(cohorts, cohort)
+	}
+
+	return cohorts, nil
+}
+
 // Helper methods
 func (s *SupabaseService) GetClient() interface{} {
 	return s.db
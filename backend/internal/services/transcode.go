@@ -0,0 +1,594 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// transcodeStaleAfter is how long a job may stay running before it is assumed abandoned
+// by a crashed worker and handed to another one
+const transcodeStaleAfter = 2 * time.Hour
+
+// waveformSampleRate is the rate audio is decoded at for peak detection. Peaks only
+// follow the envelope, so a low rate keeps decoding cheap.
+const waveformSampleRate = 8000
+
+// waveformPeaksPerSecond is the resolution peaks are collected at before being reduced to WaveformPeaks
+const waveformPeaksPerSecond = 100
+
+// loudnessRange is the EBU R128 loudness range target passed to ffmpeg's loudnorm filter
+const loudnessRange = "11"
+
+// ErrTranscodeJobNotFailed is returned when retrying a job that has not failed
+var ErrTranscodeJobNotFailed = errors.New("only failed transcode jobs can be retried")
+
+// loudnessMeasurement is the first-pass output of ffmpeg's loudnorm filter
+type loudnessMeasurement struct {
+	InputI       string `json:"input_i"`
+	InputTP      string `json:"input_tp"`
+	InputLRA     string `json:"input_lra"`
+	InputThresh  string `json:"input_thresh"`
+	TargetOffset string `json:"target_offset"`
+}
+
+type TranscodeService struct {
+	config           *config.Config
+	supabase         *SupabaseService
+	storageService   *StorageService
+	packagingService *PackagingService
+}
+
+func NewTranscodeService(cfg *config.Config, supabase *SupabaseService, storageService *StorageService, packagingService *PackagingService) *TranscodeService {
+	return &TranscodeService{
+		config:           cfg,
+		supabase:         supabase,
+		storageService:   storageService,
+		packagingService: packagingService,
+	}
+}
+
+// EnqueueEpisode queues loudness normalization and transcoding of the episode's audio.
+// An episode that is already queued or running keeps its existing job.
+func (s *TranscodeService) EnqueueEpisode(ctx context.Context, episodeID uuid.UUID) (*models.TranscodeJob, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.supabase.GetActiveTranscodeJob(ctx, episodeID, "transcode")
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		return job, nil
+	}
+
+	job = &models.TranscodeJob{
+		EpisodeID:   episodeID,
+		Kind:        "transcode",
+		Status:      "queued",
+		MaxAttempts: s.config.TranscodeMaxAttempts,
+	}
+	if err := s.supabase.CreateTranscodeJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	episode.ProcessingStatus = "queued"
+	if err := s.supabase.UpdateEpisodeRenditions(ctx, episode); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// EnqueueTrack queues loudness normalization of one of the episode's additional audio tracks.
+// A track that is already waiting keeps its job; one being processed gets a new job, so audio
+// uploaded since the running job started is normalized too.
+func (s *TranscodeService) EnqueueTrack(ctx context.Context, track *models.AudioTrack) (*models.TranscodeJob, error) {
+	job, err := s.supabase.GetQueuedTrackJob(ctx, track.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		return job, nil
+	}
+
+	job = &models.TranscodeJob{
+		EpisodeID:   track.EpisodeID,
+		Kind:        "track",
+		TrackID:     &track.ID,
+		Status:      "queued",
+		MaxAttempts: s.config.TranscodeMaxAttempts,
+	}
+	if err := s.supabase.CreateTranscodeJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := s.supabase.SetAudioTrackStatus(ctx, track.ID, "queued"); err != nil {
+		return nil, err
+	}
+	track.ProcessingStatus = "queued"
+
+	return job, nil
+}
+
+func (s *TranscodeService) GetJobs(ctx context.Context, status string, limit, offset int) ([]*models.TranscodeJob, error) {
+	return s.supabase.GetTranscodeJobs(ctx, status, limit, offset)
+}
+
+func (s *TranscodeService) GetJob(ctx context.Context, jobID uuid.UUID) (*models.TranscodeJob, error) {
+	return s.supabase.GetTranscodeJobByID(ctx, jobID)
+}
+
+// RetryJob puts a failed job back on the queue with a fresh set of attempts
+func (s *TranscodeService) RetryJob(ctx context.Context, jobID uuid.UUID) (*models.TranscodeJob, error) {
+	job, err := s.supabase.GetTranscodeJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != "failed" {
+		return nil, ErrTranscodeJobNotFailed
+	}
+
+	job.Status = "queued"
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.CompletedAt = nil
+	if err := s.supabase.UpdateTranscodeJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := s.setJobTargetStatus(ctx, job, "queued"); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ProcessNext claims the next due job and runs it, reporting whether there was one.
+// Failed attempts are retried with a growing delay until MaxAttempts is reached. Abandoned
+// jobs are handed to this worker while they have attempts left, and failed otherwise.
+func (s *TranscodeService) ProcessNext(ctx context.Context) (bool, error) {
+	staleBefore := time.Now().Add(-transcodeStaleAfter)
+	if err := s.failStaleJobs(ctx, staleBefore); err != nil {
+		return false, err
+	}
+
+	job, err := s.supabase.ClaimTranscodeJob(ctx, staleBefore)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	transcodeErr := s.runJob(ctx, job)
+	switch {
+	case transcodeErr == nil:
+		job.Status = "completed"
+		job.LastError = nil
+		job.CompletedAt = &now
+	case job.Attempts >= job.MaxAttempts:
+		message := transcodeErr.Error()
+		job.Status = "failed"
+		job.LastError = &message
+		job.CompletedAt = &now
+	default:
+		message := transcodeErr.Error()
+		job.Status = "queued"
+		job.LastError = &message
+		job.RunAt = now.Add(time.Duration(job.Attempts*job.Attempts) * time.Minute)
+	}
+
+	if transcodeErr != nil {
+		log.Printf("%s attempt %d/%d for episode %s failed: %v", job.Kind, job.Attempts, job.MaxAttempts, job.EpisodeID, transcodeErr)
+
+		status := "queued"
+		if job.Status == "failed" {
+			status = "failed"
+		}
+		if err := s.setJobTargetStatus(context.Background(), job, status); err != nil {
+			log.Printf("Failed to update %s status for episode %s: %v", job.Kind, job.EpisodeID, err)
+		}
+	}
+
+	// The job outcome is saved even if the worker is shutting down, so it is not rerun needlessly
+	if err := s.supabase.UpdateTranscodeJob(context.Background(), job); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// failStaleJobs fails abandoned jobs that have used up their attempts, along with the
+// episode, package or track they were processing
+func (s *TranscodeService) failStaleJobs(ctx context.Context, staleBefore time.Time) error {
+	jobs, err := s.supabase.FailStaleTranscodeJobs(ctx, staleBefore, "worker stopped responding on the last attempt")
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		log.Printf("%s job %s for episode %s was abandoned after %d attempts", job.Kind, job.ID, job.EpisodeID, job.Attempts)
+		if err := s.setJobTargetStatus(ctx, job, "failed"); err != nil {
+			log.Printf("Failed to update %s status for episode %s: %v", job.Kind, job.EpisodeID, err)
+		}
+	}
+
+	return nil
+}
+
+// runJob does the work of one claimed job
+func (s *TranscodeService) runJob(ctx context.Context, job *models.TranscodeJob) error {
+	switch job.Kind {
+	case "package":
+		return s.packagingService.PackageEpisode(ctx, job.EpisodeID)
+	case "track":
+		if job.TrackID == nil {
+			return fmt.Errorf("track job has no track")
+		}
+		return s.TranscodeTrack(ctx, *job.TrackID)
+	default:
+		return s.TranscodeEpisode(ctx, job.EpisodeID)
+	}
+}
+
+// RunWorker drains due jobs on every tick until the context is cancelled
+func (s *TranscodeService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			found, err := s.ProcessNext(ctx)
+			if err != nil {
+				log.Printf("Transcode worker failed: %v", err)
+			}
+			if !found || err != nil || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TranscodeEpisode normalizes the episode's original upload to the EBU R128 loudness target,
+// stores AAC and Opus renditions next to it in the audio bucket and generates its waveform.
+// Loudness is measured in a first pass so the second pass can apply a linear gain instead of
+// dynamic compression.
+func (s *TranscodeService) TranscodeEpisode(ctx context.Context, episodeID uuid.UUID) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+
+	episode.ProcessingStatus = "processing"
+	if err := s.supabase.UpdateEpisodeRenditions(ctx, episode); err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "transcode-"+episode.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	sourcePath := filepath.Join(workDir, "source")
+	if err := s.storageService.DownloadAudio(ctx, episode.AudioURL, sourcePath); err != nil {
+		return err
+	}
+
+	filter, err := s.normalizationFilter(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+
+	renditions := []struct {
+		extension   string
+		contentType string
+		codecArgs   []string
+		objectPath  *string
+	}{
+		{".m4a", "audio/mp4", []string{"-c:a", "aac", "-b:a", s.config.AACBitrate, "-movflags", "+faststart"}, &episode.AACAudioURL},
+		{".opus", "audio/ogg", []string{"-c:a", "libopus", "-b:a", s.config.OpusBitrate}, &episode.OpusAudioURL},
+	}
+
+	for _, rendition := range renditions {
+		outputPath := filepath.Join(workDir, "normalized"+rendition.extension)
+		if err := s.encodeNormalized(ctx, sourcePath, filter, rendition.codecArgs, outputPath); err != nil {
+			return err
+		}
+
+		objectPath := fmt.Sprintf("%s/normalized/%s%s", episode.SeriesID, episode.ID, rendition.extension)
+		if err := s.uploadRendition(ctx, outputPath, objectPath, rendition.contentType); err != nil {
+			return err
+		}
+		*rendition.objectPath = objectPath
+	}
+
+	// Peaks are taken from the normalized audio so waveforms are comparable across narrators
+	peaks, err := s.generateWaveform(ctx, filepath.Join(workDir, "normalized.m4a"))
+	if err != nil {
+		return err
+	}
+	if err := s.supabase.UpsertEpisodeWaveform(ctx, &models.EpisodeWaveform{EpisodeID: episode.ID, Peaks: peaks}); err != nil {
+		return err
+	}
+
+	episode.ProcessingStatus = "ready"
+	return s.supabase.UpdateEpisodeRenditions(ctx, episode)
+}
+
+// TranscodeTrack normalizes one of an episode's additional audio tracks to the same loudness
+// target as the episode's own audio and stores an AAC rendition next to it. Tracks have no
+// Opus rendition, waveform or HLS package.
+func (s *TranscodeService) TranscodeTrack(ctx context.Context, trackID uuid.UUID) error {
+	track, err := s.supabase.GetAudioTrackByID(ctx, trackID)
+	if err != nil {
+		return err
+	}
+
+	episode, err := s.supabase.GetEpisodeByID(ctx, track.EpisodeID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.supabase.SetAudioTrackStatus(ctx, track.ID, "processing"); err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "transcode-"+track.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	sourcePath := filepath.Join(workDir, "source")
+	if err := s.storageService.DownloadAudio(ctx, track.AudioURL, sourcePath); err != nil {
+		return err
+	}
+
+	filter, err := s.normalizationFilter(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(workDir, "normalized.m4a")
+	codecArgs := []string{"-c:a", "aac", "-b:a", s.config.AACBitrate, "-movflags", "+faststart"}
+	if err := s.encodeNormalized(ctx, sourcePath, filter, codecArgs, outputPath); err != nil {
+		return err
+	}
+
+	// Named after the upload, so a replaced track never overwrites the rendition being served
+	upload := strings.TrimSuffix(path.Base(track.AudioURL), path.Ext(track.AudioURL))
+	objectPath := fmt.Sprintf("%s/normalized/%s.m4a", episode.SeriesID, upload)
+	if err := s.uploadRendition(ctx, outputPath, objectPath, "audio/mp4"); err != nil {
+		return err
+	}
+
+	saved, err := s.supabase.SaveAudioTrackRendition(ctx, track.ID, track.AudioURL, objectPath)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		log.Printf("Audio track %s was replaced while it was being normalized; its new audio has its own job", track.ID)
+	}
+
+	return nil
+}
+
+// normalizationFilter measures the source's loudness and returns the loudnorm filter that
+// brings it to the configured target with a linear gain
+func (s *TranscodeService) normalizationFilter(ctx context.Context, sourcePath string) (string, error) {
+	measurement, err := s.measureLoudness(ctx, sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("loudnorm=I=%s:TP=%s:LRA=%s:measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true",
+		s.config.LoudnessTarget, s.config.LoudnessTruePeak, loudnessRange,
+		measurement.InputI, measurement.InputTP, measurement.InputLRA, measurement.InputThresh, measurement.TargetOffset), nil
+}
+
+// encodeNormalized applies the loudnorm filter to the source and encodes it with the codec arguments
+func (s *TranscodeService) encodeNormalized(ctx context.Context, sourcePath, filter string, codecArgs []string, outputPath string) error {
+	// loudnorm resamples to 192 kHz internally, so the output rate is set explicitly
+	args := []string{"-hide_banner", "-loglevel", "error", "-y", "-i", sourcePath, "-vn", "-af", filter, "-ar", "48000"}
+	args = append(args, codecArgs...)
+	args = append(args, outputPath)
+
+	cmd := exec.CommandContext(ctx, s.config.FFmpegPath, args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg failed for %s: %v: %s", filepath.Ext(outputPath), err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// measureLoudness runs the analysis pass of the loudnorm filter
+func (s *TranscodeService) measureLoudness(ctx context.Context, sourcePath string) (*loudnessMeasurement, error) {
+	filter := fmt.Sprintf("loudnorm=I=%s:TP=%s:LRA=%s:print_format=json",
+		s.config.LoudnessTarget, s.config.LoudnessTruePeak, loudnessRange)
+
+	cmd := exec.CommandContext(ctx, s.config.FFmpegPath,
+		"-hide_banner", "-nostats",
+		"-i", sourcePath,
+		"-vn", "-af", filter,
+		"-f", "null", "-",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("loudness analysis failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// The measurement is printed as the last JSON object in ffmpeg's log output
+	start := strings.LastIndex(string(output), "{")
+	end := strings.LastIndex(string(output), "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("loudness analysis produced no measurement")
+	}
+
+	var measurement loudnessMeasurement
+	if err := json.Unmarshal(output[start:end+1], &measurement); err != nil {
+		return nil, fmt.Errorf("failed to parse loudness measurement: %v", err)
+	}
+
+	// Digital silence measures as -inf, which loudnorm cannot normalize
+	if strings.Contains(measurement.InputI, "inf") {
+		return nil, fmt.Errorf("audio is silent")
+	}
+
+	return &measurement, nil
+}
+
+// generateWaveform decodes the audio to mono PCM and returns WaveformPeaks peak amplitudes
+// between 0 and 1, each covering an equal slice of the episode
+func (s *TranscodeService) generateWaveform(ctx context.Context, path string) ([]float64, error) {
+	cmd := exec.CommandContext(ctx, s.config.FFmpegPath,
+		"-hide_banner", "-loglevel", "error",
+		"-i", path,
+		"-vn", "-ac", "1", "-ar", strconv.Itoa(waveformSampleRate),
+		"-f", "s16le", "-acodec", "pcm_s16le", "-",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start waveform decode: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start waveform decode: %v", err)
+	}
+
+	// Collect fine-grained peaks while streaming, since the sample count is not known up front
+	fine := readPeaks(bufio.NewReader(stdout), waveformSampleRate/waveformPeaksPerSecond)
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("waveform decode failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if len(fine) == 0 {
+		return nil, fmt.Errorf("waveform decode produced no audio")
+	}
+
+	return reducePeaks(fine, s.config.WaveformPeaks), nil
+}
+
+// readPeaks reads 16-bit little-endian mono PCM and returns the highest absolute sample of
+// every samplesPerPeak samples. A trailing partial sample is ignored.
+func readPeaks(r io.Reader, samplesPerPeak int) []int {
+	var fine []int
+	peak, count := 0, 0
+	sample := make([]byte, 2)
+	for {
+		if _, err := io.ReadFull(r, sample); err != nil {
+			break
+		}
+		value := int(int16(binary.LittleEndian.Uint16(sample)))
+		if value < 0 {
+			value = -value
+		}
+		if value > peak {
+			peak = value
+		}
+		count++
+		if count == samplesPerPeak {
+			fine = append(fine, peak)
+			peak, count = 0, 0
+		}
+	}
+	if count > 0 {
+		fine = append(fine, peak)
+	}
+	return fine
+}
+
+// reducePeaks merges fine-grained peaks into peakCount peaks between 0 and 1, rounded to two
+// decimals, each the highest of an equal slice. A peakCount of zero or less, or more than
+// there are fine peaks, keeps every fine peak.
+func reducePeaks(fine []int, peakCount int) []float64 {
+	if peakCount <= 0 || peakCount > len(fine) {
+		peakCount = len(fine)
+	}
+
+	peaks := make([]float64, peakCount)
+	for i := range peaks {
+		start := i * len(fine) / peakCount
+		end := (i + 1) * len(fine) / peakCount
+		highest := 0
+		for _, value := range fine[start:end] {
+			if value > highest {
+				highest = value
+			}
+		}
+		peaks[i] = math.Round(float64(highest)/32768*100) / 100
+	}
+	return peaks
+}
+
+func (s *TranscodeService) uploadRendition(ctx context.Context, path, objectPath, contentType string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	if err := s.storageService.UploadObject(ctx, s.config.AudioBucketName, objectPath, contentType, file); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectPath, err)
+	}
+
+	return nil
+}
+
+// setJobTargetStatus reports a queued or failed job on what it produces: the episode's
+// processing status for transcodes, the track's for track jobs, the package status for packaging
+func (s *TranscodeService) setJobTargetStatus(ctx context.Context, job *models.TranscodeJob, status string) error {
+	switch {
+	case job.Kind == "package":
+		if status == "queued" {
+			status = "pending"
+		}
+		return s.supabase.SetEpisodePackageStatus(ctx, job.EpisodeID, status, job.LastError)
+	case job.Kind == "track" && job.TrackID != nil:
+		return s.supabase.SetAudioTrackStatus(ctx, *job.TrackID, status)
+	default:
+		return s.setEpisodeStatus(ctx, job.EpisodeID, status)
+	}
+}
+
+// setEpisodeStatus updates the episode's processing status, keeping any existing renditions
+func (s *TranscodeService) setEpisodeStatus(ctx context.Context, episodeID uuid.UUID, status string) error {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+
+	episode.ProcessingStatus = status
+	return s.supabase.UpdateEpisodeRenditions(ctx, episode)
+}
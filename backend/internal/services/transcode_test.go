@@ -0,0 +1,62 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// pcm encodes samples as 16-bit little-endian mono PCM, as ffmpeg writes it for the waveform
+func pcm(samples ...int16) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+func TestReadPeaks(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           []byte
+		samplesPerPeak int
+		want           []int
+	}{
+		{"no audio", nil, 2, nil},
+		{"whole windows", pcm(1, -5, 3, 2), 2, []int{5, 3}},
+		{"partial last window", pcm(4, 1, -7), 2, []int{4, 7}},
+		{"most negative sample", pcm(-32768, 0), 2, []int{32768}},
+		{"odd trailing byte ignored", append(pcm(9, 2), 0x7f), 1, []int{9, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readPeaks(bytes.NewReader(tt.data), tt.samplesPerPeak); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readPeaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReducePeaks(t *testing.T) {
+	fine := []int{0, 16384, 32768, 8192, 3277, 0}
+
+	tests := []struct {
+		name      string
+		peakCount int
+		want      []float64
+	}{
+		{"one peak per slice", 3, []float64{0.5, 1, 0.1}},
+		{"whole episode", 1, []float64{1}},
+		{"uneven slices", 4, []float64{0, 1, 0.25, 0.1}},
+		{"more peaks than samples", 10, []float64{0, 0.5, 1, 0.25, 0.1, 0}},
+		{"unset count", 0, []float64{0, 0.5, 1, 0.25, 0.1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reducePeaks(fine, tt.peakCount); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reducePeaks(%d) = %v, want %v", tt.peakCount, got, tt.want)
+			}
+		})
+	}
+}
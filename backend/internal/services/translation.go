@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTranslationNotFound is returned when a series or episode has no translation for a language
+	ErrTranslationNotFound = errors.New("translation not found")
+	// ErrInvalidTranslation is returned for a malformed language tag, or one that is already the
+	// content's own language
+	ErrInvalidTranslation = errors.New("invalid translation")
+)
+
+type TranslationService struct {
+	supabase *SupabaseService
+}
+
+func NewTranslationService(supabase *SupabaseService) *TranslationService {
+	return &TranslationService{
+		supabase: supabase,
+	}
+}
+
+// GetSeriesTranslations returns every translation of the series
+func (s *TranslationService) GetSeriesTranslations(ctx context.Context, seriesID uuid.UUID) ([]*models.Translation, error) {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.supabase.GetSeriesTranslations(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNilTranslations(translations[series.ID]), nil
+}
+
+// SetSeriesTranslation creates or replaces the series' title and description in language and
+// records who changed what
+func (s *TranslationService) SetSeriesTranslation(ctx context.Context, actorID, seriesID uuid.UUID, language string, req *models.TranslationRequest) (*models.Translation, error) {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	language, err = translationLanguage(language, series.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.supabase.GetSeriesTranslations(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	translation, entry := applyTranslationRequest(actorID, "series", series.ID, language, translations[series.ID], req)
+	if len(entry.Changes) == 0 {
+		return translation, nil
+	}
+
+	if err := s.supabase.UpsertSeriesTranslation(ctx, series.ID, translation, entry); err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// DeleteSeriesTranslation removes the series' translation for language; listeners asking for
+// it get the next language they accept
+func (s *TranslationService) DeleteSeriesTranslation(ctx context.Context, actorID, seriesID uuid.UUID, language string) error {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	translations, err := s.supabase.GetSeriesTranslations(ctx, []uuid.UUID{series.ID})
+	if err != nil {
+		return err
+	}
+
+	language = canonicalLanguageTag(language)
+	entry, err := removeTranslationEntry(actorID, "series", series.ID, language, translations[series.ID])
+	if err != nil {
+		return err
+	}
+
+	return s.supabase.DeleteSeriesTranslation(ctx, series.ID, language, entry)
+}
+
+// GetEpisodeTranslations returns every translation of the episode
+func (s *TranslationService) GetEpisodeTranslations(ctx context.Context, episodeID uuid.UUID) ([]*models.Translation, error) {
+	episode, _, err := s.getEpisode(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.supabase.GetEpisodeTranslations(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNilTranslations(translations[episode.ID]), nil
+}
+
+// SetEpisodeTranslation creates or replaces the episode's title and description in language
+// and records who changed what. Episode text is written in its series' language.
+func (s *TranslationService) SetEpisodeTranslation(ctx context.Context, actorID, episodeID uuid.UUID, language string, req *models.TranslationRequest) (*models.Translation, error) {
+	episode, series, err := s.getEpisode(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	language, err = translationLanguage(language, series.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.supabase.GetEpisodeTranslations(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	translation, entry := applyTranslationRequest(actorID, "episode", episode.ID, language, translations[episode.ID], req)
+	if len(entry.Changes) == 0 {
+		return translation, nil
+	}
+
+	if err := s.supabase.UpsertEpisodeTranslation(ctx, episode.ID, translation, entry); err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// DeleteEpisodeTranslation removes the episode's translation for language
+func (s *TranslationService) DeleteEpisodeTranslation(ctx context.Context, actorID, episodeID uuid.UUID, language string) error {
+	episode, _, err := s.getEpisode(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+
+	translations, err := s.supabase.GetEpisodeTranslations(ctx, []uuid.UUID{episode.ID})
+	if err != nil {
+		return err
+	}
+
+	language = canonicalLanguageTag(language)
+	entry, err := removeTranslationEntry(actorID, "episode", episode.ID, language, translations[episode.ID])
+	if err != nil {
+		return err
+	}
+
+	return s.supabase.DeleteEpisodeTranslation(ctx, episode.ID, language, entry)
+}
+
+func (s *TranslationService) getSeries(ctx context.Context, seriesID uuid.UUID) (*models.Series, error) {
+	series, err := s.supabase.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSeriesNotFound, err)
+	}
+	if series.DeletedAt != nil {
+		return nil, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+func (s *TranslationService) getEpisode(ctx context.Context, episodeID uuid.UUID) (*models.Episode, *models.Series, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, nil, ErrEpisodeNotFound
+	}
+
+	series, err := s.supabase.GetSeriesByID(ctx, episode.SeriesID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	return episode, series, nil
+}
+
+// translationLanguage validates and normalizes the language of a new translation
+func translationLanguage(language, sourceLanguage string) (string, error) {
+	if len(language) > 10 || !languageTagPattern.MatchString(language) {
+		return "", fmt.Errorf("%w: language must be a BCP 47 tag such as hi or pt-BR", ErrInvalidTranslation)
+	}
+	language = canonicalLanguageTag(language)
+	if strings.EqualFold(language, sourceLanguage) {
+		return "", fmt.Errorf("%w: %s is the original language; edit the title and description directly", ErrInvalidTranslation, language)
+	}
+	return language, nil
+}
+
+// applyTranslationRequest builds the translation req describes and an audit log entry with
+// what it changes compared to the existing translations
+func applyTranslationRequest(actorID uuid.UUID, entityType string, entityID uuid.UUID, language string, existing []*models.Translation, req *models.TranslationRequest) (*models.Translation, *models.AuditLogEntry) {
+	previous := &models.Translation{}
+	for _, translation := range existing {
+		if translation.Language == language {
+			previous = translation
+		}
+	}
+
+	translation := &models.Translation{
+		Language:    language,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   previous.CreatedAt,
+		UpdatedAt:   previous.UpdatedAt,
+	}
+
+	entry := newAuditLogEntry(actorID, "update", entityType, entityID)
+	recordChange(entry, "title:"+language, previous.Title, translation.Title)
+	recordChange(entry, "description:"+language, previous.Description, translation.Description)
+
+	return translation, entry
+}
+
+// removeTranslationEntry builds the audit log entry for deleting the translation for language,
+// or returns ErrTranslationNotFound if there is none
+func removeTranslationEntry(actorID uuid.UUID, entityType string, entityID uuid.UUID, language string, existing []*models.Translation) (*models.AuditLogEntry, error) {
+	for _, translation := range existing {
+		if translation.Language == language {
+			entry := newAuditLogEntry(actorID, "update", entityType, entityID)
+			recordChange(entry, "title:"+language, translation.Title, "")
+			recordChange(entry, "description:"+language, translation.Description, "")
+			return entry, nil
+		}
+	}
+	return nil, ErrTranslationNotFound
+}
+
+func nonNilTranslations(translations []*models.Translation) []*models.Translation {
+	if translations == nil {
+		return []*models.Translation{}
+	}
+	return translations
+}
+
+// localizeSeries replaces each series' title and description with the translation that best
+// matches the preferred languages. Series whose own language matches better, or that have no
+// matching translation, are left as they are.
+func localizeSeries(ctx context.Context, supabase *SupabaseService, series []*models.Series, languages []string) error {
+	if len(languages) == 0 || len(series) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(series))
+	for i, item := range series {
+		ids[i] = item.ID
+	}
+
+	translations, err := supabase.GetSeriesTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range series {
+		if translation := pickTranslation(item.Language, translations[item.ID], languages); translation != nil {
+			item.Title = translation.Title
+			if translation.Description != "" {
+				item.Description = translation.Description
+			}
+			item.DisplayLanguage = translation.Language
+		}
+	}
+
+	return nil
+}
+
+// localizeEpisodes does for episodes what localizeSeries does for series. Episode text is
+// written in sourceLanguage, the language of their series.
+func localizeEpisodes(ctx context.Context, supabase *SupabaseService, episodes []*models.Episode, sourceLanguage string, languages []string) error {
+	if len(languages) == 0 || len(episodes) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		ids[i] = episode.ID
+	}
+
+	translations, err := supabase.GetEpisodeTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, episode := range episodes {
+		if translation := pickTranslation(sourceLanguage, translations[episode.ID], languages); translation != nil {
+			episode.Title = translation.Title
+			if translation.Description != "" {
+				episode.Description = translation.Description
+			}
+			episode.DisplayLanguage = translation.Language
+		}
+	}
+
+	return nil
+}
+
+// pickTranslation returns the translation to serve for the preferred languages, or nil when
+// the original text in sourceLanguage should be served
+func pickTranslation(sourceLanguage string, translations []*models.Translation, languages []string) *models.Translation {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	available := []string{sourceLanguage}
+	for _, translation := range translations {
+		available = append(available, translation.Language)
+	}
+
+	language, ok := matchLanguage(available, languages)
+	if !ok || language == sourceLanguage {
+		return nil
+	}
+
+	for _, translation := range translations {
+		if translation.Language == language {
+			return translation
+		}
+	}
+	return nil
+}
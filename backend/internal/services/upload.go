@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"audio-series-app/backend/internal/config"
+	"audio-series-app/backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAudioTooLarge is returned when an upload exceeds MaxAudioFileSize
+	ErrAudioTooLarge = errors.New("audio file too large")
+	// ErrUnsupportedAudioType is returned when an upload is not a supported audio format
+	ErrUnsupportedAudioType = errors.New("unsupported audio type")
+)
+
+// allowedAudioTypes maps sniffed content types to the extension the file is stored with
+var allowedAudioTypes = map[string]string{
+	"audio/mpeg":      ".mp3",
+	"audio/mp4":       ".m4a",
+	"audio/aac":       ".aac",
+	"audio/wave":      ".wav",
+	"audio/wav":       ".wav",
+	"audio/x-wav":     ".wav",
+	"audio/ogg":       ".ogg",
+	"audio/flac":      ".flac",
+	"application/ogg": ".ogg",
+	"video/mp4":       ".m4a", // M4A files sniff as the MP4 container
+}
+
+// audioTypesByExtension covers formats content sniffing cannot recognise, such as MP3 without ID3 tags
+var audioTypesByExtension = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".flac": "audio/flac",
+}
+
+// AudioProbe holds the properties ffprobe reports for an audio file
+type AudioProbe struct {
+	Duration   int // in seconds, rounded up
+	Codec      string
+	Bitrate    int // in bits per second
+	SampleRate int
+	Channels   int
+}
+
+type UploadService struct {
+	config           *config.Config
+	supabase         *SupabaseService
+	storageService   *StorageService
+	transcodeService *TranscodeService
+}
+
+func NewUploadService(cfg *config.Config, supabase *SupabaseService, storageService *StorageService, transcodeService *TranscodeService) *UploadService {
+	return &UploadService{
+		config:           cfg,
+		supabase:         supabase,
+		storageService:   storageService,
+		transcodeService: transcodeService,
+	}
+}
+
+// MaxUploadSize returns the largest audio file accepted, in bytes
+func (s *UploadService) MaxUploadSize() int64 {
+	return s.config.GetMaxAudioFileSize()
+}
+
+// CreateEpisodeFromUpload validates and probes an uploaded audio file, stores it in the
+// audio bucket, creates the episode with the probed duration, codec and bitrate, and
+// queues it for loudness normalization. The stored file is deleted again if the episode
+// cannot be created, for example for an unknown series or a taken episode number.
+func (s *UploadService) CreateEpisodeFromUpload(ctx context.Context, episode *models.Episode, file io.Reader, filename string) error {
+	if err := normalizeAudioLanguage(episode); err != nil {
+		return err
+	}
+
+	objectPath, probe, err := s.storeAudio(ctx, episode.SeriesID, file, filename)
+	if err != nil {
+		return err
+	}
+
+	episode.AudioURL = objectPath
+	episode.Duration = probe.Duration
+	episode.AudioCodec = probe.Codec
+	episode.AudioBitrate = probe.Bitrate
+
+	if err := s.supabase.CreateEpisode(ctx, episode); err != nil {
+		s.deleteAudio(ctx, objectPath)
+		return err
+	}
+
+	// The original stays playable while normalization runs, so a queueing failure is not fatal;
+	// the episode can be queued again from the admin endpoints
+	job, err := s.transcodeService.EnqueueEpisode(ctx, episode.ID)
+	if err != nil {
+		log.Printf("Failed to queue transcode for episode %s: %v", episode.ID, err)
+		return nil
+	}
+	episode.ProcessingStatus = job.Status
+
+	return nil
+}
+
+// AddAudioTrack validates, probes and stores an uploaded audio file as the episode's track in
+// language, replacing any track it already has in that language, and queues its loudness
+// normalization. Only the episode's own audio gets Opus and HLS renditions.
+func (s *UploadService) AddAudioTrack(ctx context.Context, actorID, episodeID uuid.UUID, language string, file io.Reader, filename string) (*models.AudioTrack, error) {
+	episode, err := s.supabase.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEpisodeNotFound, err)
+	}
+	if episode.DeletedAt != nil {
+		return nil, ErrEpisodeNotFound
+	}
+
+	if len(language) > 10 || !languageTagPattern.MatchString(language) {
+		return nil, fmt.Errorf("%w: language must be a BCP 47 tag such as hi or yo", ErrInvalidAudioTrack)
+	}
+	language = canonicalLanguageTag(language)
+	if strings.EqualFold(language, episode.AudioLanguage) {
+		return nil, fmt.Errorf("%w: %s is the episode's own audio language", ErrInvalidAudioTrack, language)
+	}
+
+	objectPath, probe, err := s.storeAudio(ctx, episode.SeriesID, file, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	track := &models.AudioTrack{
+		EpisodeID:    episode.ID,
+		Language:     language,
+		AudioURL:     objectPath,
+		Duration:     probe.Duration,
+		AudioCodec:   probe.Codec,
+		AudioBitrate: probe.Bitrate,
+	}
+
+	entry := newAuditLogEntry(actorID, "update", "episode", episode.ID)
+	entry.Changes["audio_track:"+language] = models.AuditChange{Old: nil, New: objectPath}
+
+	if err := s.supabase.UpsertAudioTrack(ctx, track, entry); err != nil {
+		s.deleteAudio(ctx, objectPath)
+		return nil, err
+	}
+
+	// The upload is served as is until it is normalized, so a queueing failure is not fatal
+	if _, err := s.transcodeService.EnqueueTrack(ctx, track); err != nil {
+		log.Printf("Failed to queue transcode for audio track %s: %v", track.ID, err)
+	}
+
+	return track, nil
+}
+
+// storeAudio checks an uploaded audio file's size and format, probes it and stores it in the
+// audio bucket under the series, returning its storage path
+func (s *UploadService) storeAudio(ctx context.Context, seriesID uuid.UUID, file io.Reader, filename string) (string, *AudioProbe, error) {
+	maxSize := s.MaxUploadSize()
+
+	// Spool to disk so ffprobe can seek through the file
+	tmp, err := os.CreateTemp("", "episode-upload-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	written, err := io.Copy(tmp, io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read upload: %v", err)
+	}
+	if written > maxSize {
+		return "", nil, ErrAudioTooLarge
+	}
+
+	contentType, extension, err := sniffAudioType(tmp, filename)
+	if err != nil {
+		return "", nil, err
+	}
+
+	probe, err := s.ProbeAudio(ctx, tmp.Name())
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", nil, fmt.Errorf("failed to rewind upload: %v", err)
+	}
+
+	objectPath := fmt.Sprintf("%s/%s%s", seriesID, uuid.New(), extension)
+	err = s.storageService.UploadObject(ctx, s.config.AudioBucketName, objectPath, contentType, tmp)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to store audio: %w", err)
+	}
+
+	return objectPath, probe, nil
+}
+
+// deleteAudio removes an upload that no episode or track refers to. Failures are only
+// logged, so the caller still returns the error that left the file unused.
+func (s *UploadService) deleteAudio(ctx context.Context, objectPath string) {
+	if err := s.storageService.DeleteObject(ctx, s.config.AudioBucketName, objectPath); err != nil {
+		log.Printf("Failed to delete unused upload %s: %v", objectPath, err)
+	}
+}
+
+// sniffAudioType checks the file's leading bytes against the allowed audio types,
+// falling back to the filename extension when the content is not recognised.
+// ffprobe has the final say on whether the file really is audio.
+func sniffAudioType(file *os.File, filename string) (string, string, error) {
+	header := make([]byte, 512)
+	n, err := file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return "", "", fmt.Errorf("failed to read upload: %v", err)
+	}
+
+	contentType := http.DetectContentType(header[:n])
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+
+	if contentType == "application/octet-stream" {
+		extension := strings.ToLower(filepath.Ext(filename))
+		if byExtension, ok := audioTypesByExtension[extension]; ok {
+			return byExtension, extension, nil
+		}
+	}
+
+	extension, ok := allowedAudioTypes[contentType]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedAudioType, contentType)
+	}
+
+	switch contentType {
+	case "video/mp4":
+		contentType = "audio/mp4"
+	case "application/ogg":
+		contentType = "audio/ogg"
+	}
+
+	return contentType, extension, nil
+}
+
+// ProbeAudio runs ffprobe on a local file and returns its duration, codec and bitrate
+func (s *UploadService) ProbeAudio(ctx context.Context, path string) (*AudioProbe, error) {
+	cmd := exec.CommandContext(ctx, s.config.FFprobePath,
+		"-v", "error",
+		"-select_streams", "a:0",
+		"-show_entries", "format=duration,bit_rate:stream=codec_name,bit_rate,sample_rate,channels",
+		"-of", "json",
+		filepath.Clean(path),
+	)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: ffprobe could not read the file: %v", ErrUnsupportedAudioType, err)
+	}
+
+	var result struct {
+		Format struct {
+			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
+		} `json:"format"`
+		Streams []struct {
+			CodecName  string `json:"codec_name"`
+			BitRate    string `json:"bit_rate"`
+			SampleRate string `json:"sample_rate"`
+			Channels   int    `json:"channels"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %v", err)
+	}
+
+	if len(result.Streams) == 0 {
+		return nil, fmt.Errorf("%w: no audio stream found", ErrUnsupportedAudioType)
+	}
+
+	stream := result.Streams[0]
+	duration, _ := strconv.ParseFloat(result.Format.Duration, 64)
+	if duration <= 0 {
+		return nil, fmt.Errorf("%w: could not determine duration", ErrUnsupportedAudioType)
+	}
+
+	// Container bitrate is more reliable for VBR files; fall back to the stream's
+	bitrate, _ := strconv.Atoi(result.Format.BitRate)
+	if bitrate == 0 {
+		bitrate, _ = strconv.Atoi(stream.BitRate)
+	}
+	sampleRate, _ := strconv.Atoi(stream.SampleRate)
+
+	return &AudioProbe{
+		Duration:   int(math.Ceil(duration)),
+		Codec:      stream.CodecName,
+		Bitrate:    bitrate,
+		SampleRate: sampleRate,
+		Channels:   stream.Channels,
+	}, nil
+}
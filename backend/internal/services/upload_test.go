@@ -0,0 +1,54 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSniffAudioType(t *testing.T) {
+	m4a := []byte("\x00\x00\x00\x18ftypM4A \x00\x00\x00\x00isommp42")
+
+	tests := []struct {
+		name          string
+		content       []byte
+		filename      string
+		wantType      string
+		wantExtension string
+		wantErr       error
+	}{
+		{"mp3 with ID3 tag", []byte("ID3\x04\x00\x00\x00\x00\x00\x00"), "episode.bin", "audio/mpeg", ".mp3", nil},
+		{"mp3 without tags falls back to extension", []byte("\xff\xfb\x90\x64\x00\x00\x00\x00"), "Episode.MP3", "audio/mpeg", ".mp3", nil},
+		{"wav", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), "episode", "audio/wave", ".wav", nil},
+		{"ogg reported as audio", []byte("OggS\x00\x02\x00\x00\x00\x00"), "episode.opus", "audio/ogg", ".ogg", nil},
+		{"m4a reported as audio", m4a, "episode.m4a", "audio/mp4", ".m4a", nil},
+		{"flac by extension", []byte("fLaC\x00\x00\x00\x22"), "episode.flac", "audio/flac", ".flac", nil},
+		{"unknown bytes with unknown extension", []byte("fLaC\x00\x00\x00\x22"), "episode.bin", "", "", ErrUnsupportedAudioType},
+		{"text renamed to mp3", []byte("just some notes about the episode"), "episode.mp3", "", "", ErrUnsupportedAudioType},
+		{"image", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "cover.png", "", "", ErrUnsupportedAudioType},
+		{"empty file", nil, "episode.wav", "", "", ErrUnsupportedAudioType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "upload")
+			if err := os.WriteFile(path, tt.content, 0600); err != nil {
+				t.Fatal(err)
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			contentType, extension, err := sniffAudioType(file, tt.filename)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("sniffAudioType() error = %v, want %v", err, tt.wantErr)
+			}
+			if contentType != tt.wantType || extension != tt.wantExtension {
+				t.Errorf("sniffAudioType() = %q, %q, want %q, %q", contentType, extension, tt.wantType, tt.wantExtension)
+			}
+		})
+	}
+}
@@ -4,6 +4,14 @@
 -- Enable UUID extension
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+-- Full-text search: accents are stripped and words are not stemmed, so Hindi and Yoruba
+-- titles and their transliterations match whether or not they are typed with diacritics
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE TEXT SEARCH CONFIGURATION app_search (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION app_search
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+
 -- Users table
 CREATE TABLE users (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -25,11 +33,21 @@ CREATE TABLE series (
     title VARCHAR(255) NOT NULL,
     description TEXT,
     cover_image TEXT,
+    cover_images JSONB, -- variant URLs by size and format
     author VARCHAR(255) NOT NULL,
     category VARCHAR(100),
+    language VARCHAR(10) NOT NULL DEFAULT 'en', -- BCP 47 tag
     is_premium BOOLEAN DEFAULT false,
     total_episodes INTEGER DEFAULT 0,
     created_by UUID REFERENCES users(id),
+    status VARCHAR(20) DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    publish_at TIMESTAMP WITH TIME ZONE, -- when a scheduled series goes live, or when it went live
+    deleted_at TIMESTAMP WITH TIME ZONE, -- soft delete; purchases keep resolving
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('app_search', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('app_search', COALESCE(author, '')), 'B') ||
+        setweight(to_tsvector('app_search', COALESCE(description, '')), 'C')
+    ) STORED,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -42,12 +60,287 @@ CREATE TABLE episodes (
     description TEXT,
     audio_url TEXT NOT NULL,
     duration INTEGER DEFAULT 0, -- in seconds
+    audio_codec VARCHAR(20),
+    audio_bitrate INTEGER, -- in bits per second
+    processing_status VARCHAR(20) DEFAULT 'unprocessed' CHECK (processing_status IN ('unprocessed', 'queued', 'processing', 'ready', 'failed')),
+    aac_audio_url TEXT, -- loudness-normalized renditions
+    opus_audio_url TEXT,
     episode_number INTEGER NOT NULL,
     coin_price INTEGER DEFAULT 0,
     is_locked BOOLEAN DEFAULT true,
+    status VARCHAR(20) DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    publish_at TIMESTAMP WITH TIME ZONE, -- when a scheduled episode goes live, or when it went live
+    free_at TIMESTAMP WITH TIME ZONE, -- end of early access: coins only before, free for everyone after
+    audio_language VARCHAR(10) NOT NULL DEFAULT 'en', -- BCP 47 tag of the spoken audio; dubbed episodes differ from the series language
+    drip_queued BOOLEAN DEFAULT false, -- an admin queued the draft for the series' drip schedule
+    drip_scheduled BOOLEAN DEFAULT false, -- publish_at was assigned by the series' drip schedule
+    deleted_at TIMESTAMP WITH TIME ZONE, -- soft delete; purchases keep resolving
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('app_search', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('app_search', COALESCE(description, '')), 'D')
+    ) STORED,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Episode audio tracks table (dubbed audio alongside the episode's own, one per language)
+CREATE TABLE episode_audio_tracks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL, -- BCP 47 tag; never the episode's own audio_language
+    audio_url TEXT NOT NULL, -- storage path
+    duration INTEGER DEFAULT 0, -- in seconds
+    audio_codec VARCHAR(20),
+    audio_bitrate INTEGER, -- in bits per second
+    processing_status VARCHAR(20) DEFAULT 'unprocessed' CHECK (processing_status IN ('unprocessed', 'queued', 'processing', 'ready', 'failed')),
+    processed_audio_url TEXT, -- storage path of the loudness-normalized AAC rendition
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (episode_id, language)
+);
+
+-- Series translations table (title and description in languages other than the series' own)
+CREATE TABLE series_translations (
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL, -- BCP 47 tag
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (series_id, language)
+);
+
+-- Episode translations table
+CREATE TABLE episode_translations (
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL, -- BCP 47 tag
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (episode_id, language)
+);
+
+-- Categories table (admin-managed genres, sub-genres and tags)
+CREATE TABLE categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('genre', 'tag')),
+    parent_id UUID REFERENCES categories(id), -- sub-genres point at their genre
+    display_names JSONB NOT NULL DEFAULT '{}', -- localized names by language tag
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Series categories table (many-to-many tagging of series)
+CREATE TABLE series_categories (
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0, -- the first genre is the series' primary one
+    PRIMARY KEY (series_id, category_id)
+);
+
+-- Creators table (authors, narrators and producers)
+CREATE TABLE creators (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    bio TEXT,
+    avatar_url TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Series credits table (who wrote, narrated or produced a series)
+CREATE TABLE series_credits (
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    creator_id UUID REFERENCES creators(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('author', 'narrator', 'producer')),
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (series_id, creator_id, role)
+);
+
+-- Episode credits table (per-episode narrators and other contributors)
+CREATE TABLE episode_credits (
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    creator_id UUID REFERENCES creators(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('author', 'narrator', 'producer')),
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (episode_id, creator_id, role)
+);
+
+-- Creator follows table
+CREATE TABLE creator_follows (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    creator_id UUID REFERENCES creators(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, creator_id)
+);
+
+-- Series follows table (drives new-episode notifications)
+CREATE TABLE series_follows (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, series_id)
+);
+
+-- Episode favorites table
+CREATE TABLE episode_favorites (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, episode_id)
+);
+
+-- Listen-later queue table (each user's episodes in the order they chose)
+CREATE TABLE listen_later (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL, -- from 1
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, episode_id)
+);
+
+-- Series reviews table (one rating, with an optional review, per user per series)
+CREATE TABLE series_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'flagged', 'hidden')), -- hidden reviews leave the public list and the rating
+    report_count INTEGER NOT NULL DEFAULT 0,
+    reply TEXT, -- public reply from the team
+    replied_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    replied_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    UNIQUE(series_id, episode_number)
+    UNIQUE (series_id, user_id)
+);
+
+-- Review reports table (one per reporting user per review)
+CREATE TABLE review_reports (
+    review_id UUID REFERENCES series_reviews(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('spam', 'abuse', 'spoiler', 'other')),
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- Episode comments table (replies have a parent_id and are never replied to)
+CREATE TABLE episode_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES episode_comments(id) ON DELETE CASCADE,
+    position INTEGER CHECK (position >= 0), -- in seconds from the start of the episode; NULL for the whole episode
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'flagged', 'hidden')), -- hidden comments leave the public thread
+    like_count INTEGER NOT NULL DEFAULT 0,
+    report_count INTEGER NOT NULL DEFAULT 0,
+    deleted_at TIMESTAMP WITH TIME ZONE, -- set when the author deletes a comment that has replies; the body becomes a tombstone
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Comment posts table (when each user posted a comment or reply, kept after the comment is
+-- deleted so deleting does not reset the comment rate limits)
+CREATE TABLE comment_posts (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Comment likes table
+CREATE TABLE comment_likes (
+    comment_id UUID REFERENCES episode_comments(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+-- Comment reports table (one per reporting user per comment)
+CREATE TABLE comment_reports (
+    comment_id UUID REFERENCES episode_comments(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('spam', 'abuse', 'spoiler', 'other')),
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+-- Notifications table (new episodes of followed series)
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'new_episode' CHECK (kind IN ('new_episode')),
+    series_id UUID REFERENCES series(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, kind, episode_id)
+);
+
+-- Drip schedules table (weekly automatic releases of a series' draft episodes)
+CREATE TABLE drip_schedules (
+    series_id UUID PRIMARY KEY REFERENCES series(id) ON DELETE CASCADE,
+    weekdays JSONB NOT NULL, -- 0 = Sunday ... 6 = Saturday
+    release_time TIME NOT NULL, -- local to timezone
+    timezone VARCHAR(64) NOT NULL, -- IANA name, e.g. Asia/Kolkata
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Episode packages table (encrypted HLS renditions)
+CREATE TABLE episode_packages (
+    episode_id UUID PRIMARY KEY REFERENCES episodes(id) ON DELETE CASCADE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    version INTEGER DEFAULT 0, -- live version; renditions live under <episode_id>/<version>/
+    playlist_path TEXT,
+    bitrates TEXT,
+    encryption_key BYTEA,
+    encryption_iv BYTEA,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Episode chapters table (chapter markers set by admins)
+CREATE TABLE episode_chapters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    start_time INTEGER NOT NULL CHECK (start_time >= 0), -- in seconds
+    title VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Episode waveforms table (peak amplitudes for the audio player)
+CREATE TABLE episode_waveforms (
+    episode_id UUID PRIMARY KEY REFERENCES episodes(id) ON DELETE CASCADE,
+    peaks JSONB NOT NULL, -- array of numbers from 0 to 1
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Transcode jobs table (loudness normalization and HLS packaging queue)
+CREATE TABLE transcode_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    kind VARCHAR(20) DEFAULT 'transcode' CHECK (kind IN ('transcode', 'package', 'track')),
+    track_id UUID REFERENCES episode_audio_tracks(id) ON DELETE CASCADE, -- for track jobs
+    status VARCHAR(20) DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+    attempts INTEGER DEFAULT 0,
+    max_attempts INTEGER DEFAULT 3,
+    last_error TEXT,
+    run_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
 -- Purchases table
@@ -80,6 +373,32 @@ CREATE TABLE coin_transactions (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Subscription plans table
+CREATE TABLE subscription_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    interval VARCHAR(10) NOT NULL CHECK (interval IN ('month', 'year')),
+    price INTEGER NOT NULL, -- in smallest currency unit
+    currency VARCHAR(3) NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Subscriptions table
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    plan_id UUID REFERENCES subscription_plans(id),
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'past_due', 'cancelled', 'expired')),
+    gateway VARCHAR(20) NOT NULL CHECK (gateway IN ('razorpay', 'paystack')),
+    current_period_start TIMESTAMP WITH TIME ZONE,
+    current_period_end TIMESTAMP WITH TIME ZONE,
+    grace_until TIMESTAMP WITH TIME ZONE,
+    cancel_at_period_end BOOLEAN DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 -- Payments table
 CREATE TABLE payments (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -91,6 +410,7 @@ CREATE TABLE payments (
     gateway_ref VARCHAR(255) NOT NULL,
     status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed')),
     payment_data JSONB,
+    subscription_id UUID REFERENCES subscriptions(id) ON DELETE SET NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -106,17 +426,135 @@ CREATE TABLE coin_bundles (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Stream usage table (bandwidth served per user, episode and day)
+CREATE TABLE stream_usage (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    day DATE NOT NULL DEFAULT CURRENT_DATE,
+    bytes_served BIGINT NOT NULL DEFAULT 0,
+    requests INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, episode_id, day)
+);
+
+-- Listening progress table (last-writer-wins by client timestamp)
+CREATE TABLE listening_progress (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0, -- in seconds
+    duration INTEGER NOT NULL DEFAULT 0, -- in seconds
+    completed BOOLEAN DEFAULT false,
+    client_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, episode_id)
+);
+
+-- Listening events table (append-only player events, aggregated by the analytics rollup)
+CREATE TABLE listening_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('play', 'pause', 'seek', 'complete', 'drop_off')),
+    position INTEGER NOT NULL DEFAULT 0, -- in seconds
+    client_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Listening sessions table (per-session summary of listening_events, merged in by the rollup)
+CREATE TABLE listening_sessions (
+    episode_id UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    furthest INTEGER, -- furthest position outside of seeks, in seconds
+    played BOOLEAN NOT NULL DEFAULT false,
+    completed BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (episode_id, session_id)
+);
+
+-- Episode metrics table (rolled up from listening_sessions)
+CREATE TABLE episode_metrics (
+    episode_id UUID PRIMARY KEY REFERENCES episodes(id) ON DELETE CASCADE,
+    starts INTEGER NOT NULL DEFAULT 0,
+    completions INTEGER NOT NULL DEFAULT 0,
+    avg_listen_through NUMERIC(5,4) NOT NULL DEFAULT 0,
+    completion_rate NUMERIC(5,4) NOT NULL DEFAULT 0,
+    drop_off_curve JSONB NOT NULL DEFAULT '[]', -- share of starts still listening at 0%, 5%, ... 100%
+    rolled_up_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Series rankings table (catalog sort keys, refreshed in the background)
+CREATE TABLE series_rankings (
+    series_id UUID PRIMARY KEY REFERENCES series(id) ON DELETE CASCADE,
+    play_count BIGINT NOT NULL DEFAULT 0, -- episode starts, from episode_metrics
+    trending_score DOUBLE PRECISION NOT NULL DEFAULT 0, -- recent plays, decaying with age
+    rating_average NUMERIC(3,2) NOT NULL DEFAULT 0,
+    rating_count INTEGER NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Rollup watermarks table (how far each rollup job has processed events)
+CREATE TABLE rollup_watermarks (
+    name VARCHAR(50) PRIMARY KEY,
+    rolled_up_to TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Admin audit log table (who changed which series or episode, and how)
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'reorder')),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('series', 'episode', 'category', 'creator', 'review', 'comment')),
+    entity_id UUID NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}', -- field name to {"old": ..., "new": ...}
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 -- Indexes for better performance
 CREATE INDEX idx_users_email ON users(email);
 CREATE INDEX idx_series_created_by ON series(created_by);
 CREATE INDEX idx_episodes_series_id ON episodes(series_id);
-CREATE INDEX idx_episodes_episode_number ON episodes(series_id, episode_number);
+-- Episode numbers only have to be unique among episodes that have not been deleted
+CREATE UNIQUE INDEX idx_episodes_episode_number ON episodes(series_id, episode_number) WHERE deleted_at IS NULL;
 CREATE INDEX idx_purchases_user_id ON purchases(user_id);
 CREATE INDEX idx_purchases_episode_id ON purchases(episode_id);
 CREATE INDEX idx_purchases_series_id ON purchases(series_id);
 CREATE INDEX idx_coin_transactions_user_id ON coin_transactions(user_id);
 CREATE INDEX idx_payments_user_id ON payments(user_id);
 CREATE INDEX idx_payments_gateway_ref ON payments(gateway_ref);
+CREATE INDEX idx_payments_subscription_id ON payments(subscription_id);
+CREATE INDEX idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX idx_stream_usage_day ON stream_usage(day);
+CREATE INDEX idx_subscriptions_status_period_end ON subscriptions(status, current_period_end);
+CREATE INDEX idx_transcode_jobs_status_run_at ON transcode_jobs(status, run_at);
+CREATE INDEX idx_transcode_jobs_episode_id ON transcode_jobs(episode_id);
+CREATE INDEX idx_episode_chapters_episode_id ON episode_chapters(episode_id, start_time);
+CREATE INDEX idx_listening_progress_in_progress ON listening_progress(user_id, updated_at DESC) WHERE completed = false;
+CREATE INDEX idx_listening_events_episode_session ON listening_events(episode_id, session_id);
+CREATE INDEX idx_listening_events_received_at ON listening_events(received_at);
+CREATE INDEX idx_admin_audit_log_entity ON admin_audit_log(entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at DESC);
+CREATE INDEX idx_series_scheduled ON series(publish_at) WHERE status = 'scheduled';
+CREATE INDEX idx_series_catalog_newest ON series(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_series_search ON series USING GIN (search_vector);
+CREATE INDEX idx_episodes_search ON episodes USING GIN (search_vector);
+CREATE INDEX idx_series_catalog_filters ON series(split_part(LOWER(language), '-', 1), is_premium) WHERE deleted_at IS NULL;
+CREATE INDEX idx_series_categories_category_id ON series_categories(category_id);
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX idx_series_credits_creator_id ON series_credits(creator_id);
+CREATE INDEX idx_episode_credits_creator_id ON episode_credits(creator_id);
+CREATE INDEX idx_creator_follows_creator_id ON creator_follows(creator_id);
+CREATE INDEX idx_series_follows_series_id ON series_follows(series_id);
+CREATE INDEX idx_listen_later_user_position ON listen_later(user_id, position);
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+CREATE INDEX idx_series_reviews_series_created ON series_reviews(series_id, created_at DESC);
+CREATE INDEX idx_series_reviews_moderation ON series_reviews(status, report_count) WHERE status <> 'visible' OR report_count > 0;
+CREATE INDEX idx_episode_comments_episode_created ON episode_comments(episode_id, created_at DESC) WHERE parent_id IS NULL;
+CREATE INDEX idx_episode_comments_parent_created ON episode_comments(parent_id, created_at) WHERE parent_id IS NOT NULL;
+CREATE INDEX idx_comment_posts_user_created ON comment_posts(user_id, created_at DESC);
+CREATE INDEX idx_episode_comments_moderation ON episode_comments(status, report_count) WHERE status <> 'visible' OR report_count > 0;
+CREATE INDEX idx_episodes_audio_language ON episodes(series_id, audio_language);
+CREATE INDEX idx_episodes_scheduled ON episodes(publish_at) WHERE status = 'scheduled';
 
 -- Triggers to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -139,6 +577,53 @@ CREATE TRIGGER update_episodes_updated_at BEFORE UPDATE ON episodes
 CREATE TRIGGER update_payments_updated_at BEFORE UPDATE ON payments
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_episode_packages_updated_at BEFORE UPDATE ON episode_packages
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_subscriptions_updated_at BEFORE UPDATE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_transcode_jobs_updated_at BEFORE UPDATE ON transcode_jobs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_episode_waveforms_updated_at BEFORE UPDATE ON episode_waveforms
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_series_reviews_updated_at BEFORE UPDATE ON series_reviews
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_episode_comments_updated_at BEFORE UPDATE ON episode_comments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_episode_audio_tracks_updated_at BEFORE UPDATE ON episode_audio_tracks
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_series_translations_updated_at BEFORE UPDATE ON series_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_episode_translations_updated_at BEFORE UPDATE ON episode_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_categories_updated_at BEFORE UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_creators_updated_at BEFORE UPDATE ON creators
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_drip_schedules_updated_at BEFORE UPDATE ON drip_schedules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Listening events are append-only; rows may only go away with their user or episode
+CREATE OR REPLACE FUNCTION prevent_listening_event_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'listening_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_listening_events_update BEFORE UPDATE ON listening_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_listening_event_update();
+
 -- Function to update series total_episodes count
 CREATE OR REPLACE FUNCTION update_series_episode_count()
 RETURNS TRIGGER AS $$
@@ -146,8 +631,18 @@ BEGIN
     IF TG_OP = 'INSERT' THEN
         UPDATE series SET total_episodes = total_episodes + 1 WHERE id = NEW.series_id;
         RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        -- Soft-deleted episodes no longer count towards the series
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            UPDATE series SET total_episodes = total_episodes - 1 WHERE id = NEW.series_id;
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            UPDATE series SET total_episodes = total_episodes + 1 WHERE id = NEW.series_id;
+        END IF;
+        RETURN NEW;
     ELSIF TG_OP = 'DELETE' THEN
-        UPDATE series SET total_episodes = total_episodes - 1 WHERE id = OLD.series_id;
+        IF OLD.deleted_at IS NULL THEN
+            UPDATE series SET total_episodes = total_episodes - 1 WHERE id = OLD.series_id;
+        END IF;
         RETURN OLD;
     END IF;
     RETURN NULL;
@@ -155,7 +650,7 @@ END;
 $$ language 'plpgsql';
 
 CREATE TRIGGER update_series_episode_count_trigger
-    AFTER INSERT OR DELETE ON episodes
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at ON episodes
     FOR EACH ROW EXECUTE FUNCTION update_series_episode_count();
 
 -- Insert default coin bundles
@@ -169,16 +664,45 @@ INSERT INTO coin_bundles (name, coins, price, currency, is_active) VALUES
 ('250 Coins', 250, 19900, 'NGN', true),
 ('500 Coins', 500, 39900, 'NGN', true);
 
+-- Insert default subscription plans
+INSERT INTO subscription_plans (name, interval, price, currency, is_active) VALUES
+('VIP Monthly', 'month', 19900, 'INR', true),
+('VIP Annual', 'year', 199900, 'INR', true),
+('VIP Monthly', 'month', 150000, 'NGN', true),
+('VIP Annual', 'year', 1500000, 'NGN', true);
+
+-- Insert default genres
+INSERT INTO categories (slug, name, kind, display_names, sort_order) VALUES
+('mystery', 'Mystery', 'genre', '{"hi": "रहस्य"}', 1),
+('horror', 'Horror', 'genre', '{"hi": "डरावनी"}', 2),
+('romance', 'Romance', 'genre', '{"hi": "रोमांस"}', 3),
+('thriller', 'Thriller', 'genre', '{"hi": "थ्रिलर"}', 4);
+
 -- Insert sample data for testing
 INSERT INTO users (email, first_name, last_name, coin_balance, role) VALUES
 ('admin@audioseries.com', 'Admin', 'User', 1000, 'admin'),
 ('user@example.com', 'John', 'Doe', 100, 'user');
 
-INSERT INTO series (title, description, cover_image, author, category, is_premium, total_episodes, created_by) VALUES
-('Forbidden Nights', 'A thrilling audio series about mystery and suspense', 'https://example.com/cover1.jpg', 'Jane Smith', 'Mystery', true, 10, (SELECT id FROM users WHERE email = 'admin@audioseries.com')),
-('Urban Legends', 'Modern urban legends brought to life', 'https://example.com/cover2.jpg', 'Mike Johnson', 'Horror', false, 8, (SELECT id FROM users WHERE email = 'admin@audioseries.com'));
+INSERT INTO series (title, description, cover_image, author, category, is_premium, total_episodes, created_by, status, publish_at) VALUES
+('Forbidden Nights', 'A thrilling audio series about mystery and suspense', 'https://example.com/cover1.jpg', 'Jane Smith', 'Mystery', true, 10, (SELECT id FROM users WHERE email = 'admin@audioseries.com'), 'published', NOW()),
+('Urban Legends', 'Modern urban legends brought to life', 'https://example.com/cover2.jpg', 'Mike Johnson', 'Horror', false, 8, (SELECT id FROM users WHERE email = 'admin@audioseries.com'), 'published', NOW());
+
+INSERT INTO episodes (series_id, title, description, audio_url, duration, episode_number, coin_price, is_locked, status, publish_at) VALUES
+((SELECT id FROM series WHERE title = 'Forbidden Nights'), 'Episode 1: The Beginning', 'The story begins with a mysterious discovery', 'https://example.com/audio1.mp3', 1800, 1, 10, true, 'published', NOW()),
+((SELECT id FROM series WHERE title = 'Forbidden Nights'), 'Episode 2: The Investigation', 'The plot thickens as clues are uncovered', 'https://example.com/audio2.mp3', 1800, 2, 15, true, 'published', NOW()),
+((SELECT id FROM series WHERE title = 'Urban Legends'), 'Episode 1: The Legend Begins', 'The first urban legend comes to life', 'https://example.com/audio3.mp3', 1200, 1, 5, false, 'published', NOW()); 
+
+INSERT INTO series_categories (series_id, category_id) VALUES
+((SELECT id FROM series WHERE title = 'Forbidden Nights'), (SELECT id FROM categories WHERE slug = 'mystery')),
+((SELECT id FROM series WHERE title = 'Urban Legends'), (SELECT id FROM categories WHERE slug = 'horror'));
+
+INSERT INTO creators (slug, name, bio) VALUES
+('jane-smith', 'Jane Smith', 'Mystery writer and audio dramatist'),
+('mike-johnson', 'Mike Johnson', 'Collector of modern urban legends');
+
+INSERT INTO series_credits (series_id, creator_id, role) VALUES
+((SELECT id FROM series WHERE title = 'Forbidden Nights'), (SELECT id FROM creators WHERE slug = 'jane-smith'), 'author'),
+((SELECT id FROM series WHERE title = 'Urban Legends'), (SELECT id FROM creators WHERE slug = 'mike-johnson'), 'author');
 
-INSERT INTO episodes (series_id, title, description, audio_url, duration, episode_number, coin_price, is_locked) VALUES
-((SELECT id FROM series WHERE title = 'Forbidden Nights'), 'Episode 1: The Beginning', 'The story begins with a mysterious discovery', 'https://example.com/audio1.mp3', 1800, 1, 10, true),
-((SELECT id FROM series WHERE title = 'Forbidden Nights'), 'Episode 2: The Investigation', 'The plot thickens as clues are uncovered', 'https://example.com/audio2.mp3', 1800, 2, 15, true),
-((SELECT id FROM series WHERE title = 'Urban Legends'), 'Episode 1: The Legend Begins', 'The first urban legend comes to life', 'https://example.com/audio3.mp3', 1200, 1, 5, false); 
\ No newline at end of file
+INSERT INTO series_translations (series_id, language, title, description) VALUES
+((SELECT id FROM series WHERE title = 'Forbidden Nights'), 'hi', 'वर्जित रातें', 'रहस्य और रोमांच से भरी एक ऑडियो सीरीज़');